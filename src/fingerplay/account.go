@@ -1,16 +1,21 @@
 package main
 
 import (
-	"math/rand"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	log "code.google.com/p/log4go"
 )
 
 const (
 	RobotSessionStatusUnused = 0
 	RobotSessionStatusUsed   = 1
+
+	// 吊销的token保留的时间，账户服务的token有效期不会超过这个时间
+	RevokedTokenKeepSecond = 24 * 3600
 )
 
 type RobotSession struct {
@@ -28,14 +33,43 @@ func (rs *RobotSession) Reset() {
 	rs.Balance = 0
 }
 
+// 账户服务，AccountManager通过http调用真实的服务，测试时可以替换
+type Accounts interface {
+	DescribeUser(request *DescribeUserRequest, response *DescribeUserResponse) (err error)
+	Transfer(request *TransferRequest, response *TransferResponse) (err error)
+	LoginAI(request *LoginAIRequest, response *LoginAIResponse) (err error)
+	LogoutAI(request *LogoutAIRequest, response *LogoutAIResponse) (err error)
+	RobotSessionsInUse() (n int)
+	RobotSessionsIdle() (n int)
+	Ping(timeout time.Duration) (err error)
+	// 与DescribeUser相同，但token_cache_second内使用缓存的结果，余额可能不是最新的
+	ValidateToken(request *DescribeUserRequest, response *DescribeUserResponse) (err error)
+	// 吊销后DescribeUser和ValidateToken都返回ErrTokenRevoked，返回缓存中token对应的uid，没有时为0
+	RevokeToken(accessToken string) (uid int)
+	// 缓存中token对应的uid，没有缓存时为0，不请求账户服务
+	CachedUid(accessToken string) (uid int)
+}
+
+// 验证过的token
+type cachedToken struct {
+	data     DescribeUserResponseData
+	expireTs int64
+}
+
 type AccountManager struct {
 	endpointDescribeUser string
 	endpointTransfer     string
 	endpointLoginAI      string
+	conf                 *ConfigValue
 	robotSessionMux      sync.RWMutex
 	idleRobotSessions    []*RobotSession
 	robotSessionMap      map[string]*RobotSession
-	rand                 *rand.Rand
+	rand                 Rand
+	clock                Clock
+	tokenMux             sync.Mutex
+	tokenCache           map[string]*cachedToken
+	revokedTokens        map[string]int64
+	tokenSweepTs         int64
 }
 
 func NewAccountManager(endpointDescribeUser, endpointTransfer, endpointLoginAI string) *AccountManager {
@@ -43,8 +77,12 @@ func NewAccountManager(endpointDescribeUser, endpointTransfer, endpointLoginAI s
 	am.endpointDescribeUser = endpointDescribeUser
 	am.endpointTransfer = endpointTransfer
 	am.endpointLoginAI = endpointLoginAI
+	am.conf = confValue
 	am.robotSessionMap = make(map[string]*RobotSession)
-	am.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	am.rand = newRand(DefaultClock)
+	am.clock = DefaultClock
+	am.tokenCache = make(map[string]*cachedToken)
+	am.revokedTokens = make(map[string]int64)
 	return am
 }
 
@@ -62,21 +100,141 @@ func (am *AccountManager) DescribeUser(request *DescribeUserRequest, response *D
 		//FbOpenId string  `json:"fb_open_id"`
 		//Nickname string  `json:"nickname"`
 		//Balance  float64 `json:"balance"`
-		response.Data.Uid = Conf.RobotUid
-		response.Data.FbOpenId = Conf.RobotFbOpenId
+		response.Data.Uid = am.conf.Get().RobotUid
+		response.Data.FbOpenId = am.conf.Get().RobotFbOpenId
 		//response.Nickname
 		response.Data.Balance = rs.Balance
 
 		return
 	}
-	return Post(am.endpointDescribeUser, request, response)
+
+	if am.isRevoked(request.AccessToken) {
+		response.Code = ResponseCodeBadAccessToken
+		return ErrTokenRevoked
+	}
+
+	span := request.StartSpan("AccountManager.DescribeUser", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { endSpan(span, response.Code, err) }()
+
+	begin := time.Now()
+	err = Post(am.endpointDescribeUser, request, response)
+	observeUpstream("describe_user", begin, err, response.Code)
+
+	if err == nil && response.Code == ResponseCodeOK {
+		am.cacheToken(request.AccessToken, &response.Data)
+	}
+	return
+}
+
+// 比赛中的每个请求都要检查token，有缓存时每个token每个周期最多请求一次账户服务
+func (am *AccountManager) ValidateToken(request *DescribeUserRequest, response *DescribeUserResponse) (err error) {
+	if am.GetRobotSession(request.AccessToken) == nil {
+		now := am.clock.Now().Unix()
+		am.tokenMux.Lock()
+		ct := am.tokenCache[request.AccessToken]
+		_, revoked := am.revokedTokens[request.AccessToken]
+		am.tokenMux.Unlock()
+
+		if revoked {
+			response.Code = ResponseCodeBadAccessToken
+			return ErrTokenRevoked
+		}
+		if ct != nil && now < ct.expireTs {
+			observeTokenCache(true)
+			response.Data = ct.data
+			return
+		}
+		observeTokenCache(false)
+	}
+	return am.DescribeUser(request, response)
+}
+
+func (am *AccountManager) RevokeToken(accessToken string) (uid int) {
+	now := am.clock.Now().Unix()
+
+	am.tokenMux.Lock()
+	if ct := am.tokenCache[accessToken]; ct != nil {
+		uid = ct.data.Uid
+		delete(am.tokenCache, accessToken)
+	}
+	am.revokedTokens[accessToken] = now + RevokedTokenKeepSecond
+	am.tokenMux.Unlock()
+
+	log.Info("RevokeToken: accesstoken=%s, uid=%d", redactToken(accessToken), uid)
+	return
+}
+
+func (am *AccountManager) isRevoked(accessToken string) (revoked bool) {
+	am.tokenMux.Lock()
+	_, revoked = am.revokedTokens[accessToken]
+	am.tokenMux.Unlock()
+	return
+}
+
+// token_cache_second为0时不缓存
+func (am *AccountManager) cacheToken(accessToken string, data *DescribeUserResponseData) {
+	ttl := int64(am.conf.Get().TokenCacheSecond)
+	now := am.clock.Now().Unix()
+
+	am.tokenMux.Lock()
+	defer am.tokenMux.Unlock()
+
+	am.sweepTokens(now)
+
+	// 吊销之前发出的请求可能在吊销之后才返回
+	if _, ok := am.revokedTokens[accessToken]; ok || ttl <= 0 {
+		return
+	}
+	am.tokenCache[accessToken] = &cachedToken{data: *data, expireTs: now + ttl}
+}
+
+// 每分钟清理一次过期的记录，调用者需要持有am.tokenMux
+func (am *AccountManager) sweepTokens(now int64) {
+	if now-am.tokenSweepTs < 60 {
+		return
+	}
+	am.tokenSweepTs = now
+	for k, ct := range am.tokenCache {
+		if now >= ct.expireTs {
+			delete(am.tokenCache, k)
+		}
+	}
+	for k, ts := range am.revokedTokens {
+		if now >= ts {
+			delete(am.revokedTokens, k)
+		}
+	}
+}
+
+func (am *AccountManager) CachedUid(accessToken string) (uid int) {
+	now := am.clock.Now().Unix()
+	am.tokenMux.Lock()
+	if ct := am.tokenCache[accessToken]; ct != nil && now < ct.expireTs {
+		uid = ct.data.Uid
+	}
+	am.tokenMux.Unlock()
+	return
+}
+
+// 缓存的token数和吊销的token数，用于监控
+func (am *AccountManager) TokenCacheSize() (cached, revoked int) {
+	am.tokenMux.Lock()
+	cached, revoked = len(am.tokenCache), len(am.revokedTokens)
+	am.tokenMux.Unlock()
+	return
 }
 
 func (am *AccountManager) Transfer(request *TransferRequest, response *TransferResponse) (err error) {
 	fromRs := am.GetRobotSession(request.FromAccessToken)
 	toRs := am.GetRobotSession(request.ToAccessToken)
 
+	span := request.StartSpan("AccountManager.Transfer", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("match_id", request.MatchId), attribute.Int("round", request.Round)))
+	defer func() { endSpan(span, response.Code, err) }()
+
+	begin := time.Now()
 	err = Post(am.endpointTransfer, request, response)
+	observeUpstream("transfer", begin, err, response.Code)
 
 	if fromRs != nil {
 		fromRs.Balance -= (request.Amount + request.FromCost)
@@ -91,6 +249,20 @@ func (am *AccountManager) Transfer(request *TransferRequest, response *TransferR
 	return
 }
 
+func (am *AccountManager) RobotSessionsIdle() (n int) {
+	am.robotSessionMux.RLock()
+	n = len(am.idleRobotSessions)
+	am.robotSessionMux.RUnlock()
+	return
+}
+
+func (am *AccountManager) RobotSessionsInUse() (n int) {
+	am.robotSessionMux.RLock()
+	n = len(am.robotSessionMap)
+	am.robotSessionMux.RUnlock()
+	return
+}
+
 func (am *AccountManager) LogoutAI(request *LogoutAIRequest, response *LogoutAIResponse) (err error) {
 	am.robotSessionMux.Lock()
 	rs := am.robotSessionMap[request.AccessToken]
@@ -100,9 +272,9 @@ func (am *AccountManager) LogoutAI(request *LogoutAIRequest, response *LogoutAIR
 	}
 	am.robotSessionMux.Unlock()
 	if rs != nil {
-		log.Debug("LogoutAI ok: accesstoken=%s", rs.AccessToken)
+		log.Debug("LogoutAI ok: accesstoken=%s", redactToken(rs.AccessToken))
 	} else {
-		log.Debug("LogoutAI failed: not found accesstoken=%s", request.AccessToken)
+		log.Debug("LogoutAI failed: not found accesstoken=%s", redactToken(request.AccessToken))
 		err = ErrAccessToken
 	}
 
@@ -147,12 +319,14 @@ func (am *AccountManager) LoginAI(request *LoginAIRequest, response *LoginAIResp
 
 	response.Data.AccessToken = rs.AccessToken
 
-	log.Debug("LoginAI: accesstoken=%s", rs.AccessToken)
+	log.Debug("LoginAI: accesstoken=%s", redactToken(rs.AccessToken))
 	return
 	//return Post(am.endpointLoginAI, request, response)
 }
 
 type DescribeUserRequest struct {
+	Traced
+
 	AccessToken string `json:"access_token"`
 }
 
@@ -170,6 +344,8 @@ type DescribeUserResponseData struct {
 }
 
 type TransferRequest struct {
+	Traced
+
 	MatchId         string  `json:"-"`
 	Round           int     `json:"-"`
 	Level           int     `json:"-"`
@@ -219,7 +395,3 @@ type LoginAIResponse struct {
 type LoginAIResponseData struct {
 	AccessToken string `json:"access_token"`
 }
-
-var (
-	DefaultAccountManager *AccountManager
-)
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fingerplay/wallettest"
+)
+
+// 统计describe_user的请求数，before在转给假账户服务之前调用
+type countingWallet struct {
+	wallet *wallettest.Wallet
+	calls  int32
+	before func()
+}
+
+func (w *countingWallet) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == wallettest.PathDescribeUser {
+		atomic.AddInt32(&w.calls, 1)
+		if w.before != nil {
+			w.before()
+		}
+	}
+	w.wallet.ServeHTTP(rw, r)
+}
+
+func (w *countingWallet) Calls() int {
+	return int(atomic.LoadInt32(&w.calls))
+}
+
+func newTestAccounts(t *testing.T, cacheSecond int) (*AccountManager, *countingWallet, *ManualClock) {
+	wallet := &countingWallet{wallet: wallettest.New(wallettest.Options{
+		Users: []*wallettest.User{{Uid: 100001, AccessToken: "player-1", Balance: 1000}},
+	})}
+	srv := httptest.NewServer(wallet)
+	t.Cleanup(srv.Close)
+
+	cfg := newTestConfig(t)
+	cfg.TokenCacheSecond = cacheSecond
+	am := NewAccountManager(srv.URL+wallettest.PathDescribeUser, srv.URL+wallettest.PathTransfer, "")
+	am.conf = NewConfigValue(cfg)
+	clock := NewManualClock(time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local))
+	am.clock = clock
+	return am, wallet, clock
+}
+
+func validateToken(am *AccountManager, accessToken string) (*DescribeUserResponse, error) {
+	response := &DescribeUserResponse{}
+	err := am.ValidateToken(&DescribeUserRequest{AccessToken: accessToken}, response)
+	return response, err
+}
+
+func TestTokenCache(t *testing.T) {
+	am, wallet, clock := newTestAccounts(t, 60)
+
+	if uid := am.CachedUid("player-1"); uid != 0 {
+		t.Errorf("CachedUid() = %d before validating, want 0", uid)
+	}
+	for i := 0; i < 3; i++ {
+		response, err := validateToken(am, "player-1")
+		if err != nil || response.Code != ResponseCodeOK || response.Data.Uid != 100001 {
+			t.Fatalf("ValidateToken() = %+v, %v, want uid 100001", response, err)
+		}
+		clock.Advance(10 * time.Second)
+	}
+	if n := wallet.Calls(); n != 1 {
+		t.Errorf("describe_user called %d times, want the cache to answer", n)
+	}
+	if uid := am.CachedUid("player-1"); uid != 100001 {
+		t.Errorf("CachedUid() = %d, want 100001", uid)
+	}
+
+	// token_cache_second之后重新请求账户服务
+	clock.Advance(30 * time.Second)
+	if uid := am.CachedUid("player-1"); uid != 0 {
+		t.Errorf("CachedUid() = %d after the ttl, want 0", uid)
+	}
+	validateToken(am, "player-1")
+	if n := wallet.Calls(); n != 2 {
+		t.Errorf("describe_user called %d times, want a new request after the ttl", n)
+	}
+
+	// 为0时不缓存
+	am, wallet, _ = newTestAccounts(t, 0)
+	validateToken(am, "player-1")
+	validateToken(am, "player-1")
+	if n := wallet.Calls(); n != 2 {
+		t.Errorf("describe_user called %d times with token_cache_second = 0, want 2", n)
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	am, wallet, clock := newTestAccounts(t, 60)
+	validateToken(am, "player-1")
+
+	if uid := am.RevokeToken("player-1"); uid != 100001 {
+		t.Errorf("RevokeToken() = %d, want the cached uid", uid)
+	}
+	if _, err := validateToken(am, "player-1"); err != ErrTokenRevoked {
+		t.Errorf("ValidateToken() = %v after revoking, want %v", err, ErrTokenRevoked)
+	}
+	response := &DescribeUserResponse{}
+	if err := am.DescribeUser(&DescribeUserRequest{AccessToken: "player-1"}, response); err != ErrTokenRevoked || response.Code != ResponseCodeBadAccessToken {
+		t.Errorf("DescribeUser() = %d, %v after revoking, want %v", response.Code, err, ErrTokenRevoked)
+	}
+	if n := wallet.Calls(); n != 1 {
+		t.Errorf("describe_user called %d times, want no request for a revoked token", n)
+	}
+
+	// 吊销记录保留RevokedTokenKeepSecond，在下一次写缓存时清理
+	clock.Advance(RevokedTokenKeepSecond * time.Second)
+	validateToken(am, "player-2")
+	if cached, revoked := am.TokenCacheSize(); cached != 1 || revoked != 0 {
+		t.Errorf("TokenCacheSize() = %d, %d, want the revocation swept", cached, revoked)
+	}
+}
+
+func TestRevokeDuringDescribeUser(t *testing.T) {
+	am, wallet, _ := newTestAccounts(t, 60)
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	wallet.before = func() {
+		close(entered)
+		<-release
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := validateToken(am, "player-1")
+		done <- err
+	}()
+
+	// 请求已经发出，在返回之前吊销
+	<-entered
+	am.RevokeToken("player-1")
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("in-flight ValidateToken() = %v, want the answer from before the revocation", err)
+	}
+
+	// 晚到的结果不能进入缓存
+	if cached, _ := am.TokenCacheSize(); cached != 0 {
+		t.Errorf("%d tokens cached, want none after the revocation", cached)
+	}
+	if uid := am.CachedUid("player-1"); uid != 0 {
+		t.Errorf("CachedUid() = %d, want 0 for a revoked token", uid)
+	}
+	if _, err := validateToken(am, "player-1"); err != ErrTokenRevoked {
+		t.Errorf("ValidateToken() = %v, want %v", err, ErrTokenRevoked)
+	}
+}
@@ -0,0 +1,719 @@
+package main
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	log "code.google.com/p/log4go"
+)
+
+const (
+	// Authorization: Bearer <admin_token>
+	AdminAuthScheme = "Bearer "
+
+	AdminLedgerLimit    = 20
+	AdminLedgerMaxLimit = 200
+
+	AdminFraudAlertLimit    = 100
+	AdminFraudAlertMaxLimit = FraudAlertKeep
+	// 串通分析时读取的流水条数
+	AdminFraudScanLimit    = 1000
+	AdminFraudScanMaxLimit = 10000
+)
+
+// 管理接口，监听在单独的地址上，只给运维和客服使用。
+// 集群模式下比赛和暂停只作用于收到请求的节点，队列是共享的。
+type AdminApi struct {
+	bindAddr string
+	// 所属的Server，管理接口可以操作它的所有组件
+	srv    *Server
+	server *fasthttp.Server
+}
+
+type AdminResponse struct {
+	Code int         `json:"code"`
+	Msg  string      `json:"msg"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+func (response *AdminResponse) JSON() []byte {
+	v, _ := json.Marshal(response)
+	return v
+}
+
+type AdminMatchRequest struct {
+	MatchId string `json:"match_id"`
+	// 是否退还已经结算的回合
+	Refund bool `json:"refund"`
+}
+
+type AdminKickRequest struct {
+	Uid int `json:"uid"`
+}
+
+type AdminRevokeTokenRequest struct {
+	AccessToken string `json:"access_token"`
+}
+
+type AdminFraudClearRequest struct {
+	Uids [2]int `json:"uids"`
+}
+
+type AdminLevelRequest struct {
+	Level int `json:"level"`
+}
+
+type AdminDrainRequest struct {
+	// 等待回合结算的时间，0时使用shutdown_timeout_second
+	TimeoutSecond int `json:"timeout_second"`
+}
+
+type AdminWaiting struct {
+	Uid      int    `json:"uid"`
+	Nickname string `json:"nickname"`
+	Robot    bool   `json:"robot"`
+	Node     string `json:"node,omitempty"`
+	Ts       int64  `json:"ts"`
+}
+
+type AdminWaitingList struct {
+	Level   int             `json:"level"`
+	Paused  bool            `json:"paused"`
+	Waiting []*AdminWaiting `json:"waiting"`
+}
+
+type AdminCompetitor struct {
+	Uid         int     `json:"uid"`
+	Nickname    string  `json:"nickname"`
+	Robot       bool    `json:"robot"`
+	Balance     float64 `json:"balance"`
+	Status      int     `json:"status"`
+	KeepAliveTs int64   `json:"keep_alive_ts"`
+}
+
+type AdminMatch struct {
+	MatchId     string             `json:"match_id"`
+	Level       int                `json:"level"`
+	Round       int                `json:"round"`
+	Disposed    bool               `json:"disposed"`
+	CreatedTs   int64              `json:"created_ts"`
+	Competitors []*AdminCompetitor `json:"competitors"`
+	Rounds      []*SettledRound    `json:"rounds,omitempty"`
+}
+
+type AdminRefund struct {
+	Round   int     `json:"round"`
+	FromUid int     `json:"from_uid"`
+	ToUid   int     `json:"to_uid"`
+	Amount  float64 `json:"amount"`
+	Code    int     `json:"code"`
+}
+
+type AdminDisposeResult struct {
+	MatchId string         `json:"match_id"`
+	Refunds []*AdminRefund `json:"refunds"`
+}
+
+type AdminConfigCheck struct {
+	File   string   `json:"file"`
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors"`
+}
+
+type AdminLeaderboardRebuild struct {
+	Replayed   int64 `json:"replayed"`
+	Archived   int   `json:"archived"`
+	SpoolBytes int64 `json:"spool_bytes"`
+	// 读取的流水数和重建的榜单数
+	Entries int `json:"entries"`
+	Rebuilt int `json:"rebuilt"`
+}
+
+type AdminDrainResult struct {
+	Draining bool `json:"draining"`
+	Matches  int  `json:"matches"`
+}
+
+type AdminRobotUsage struct {
+	Idle            int         `json:"idle"`
+	Playing         map[int]int `json:"playing"`
+	SessionsInUse   int         `json:"sessions_in_use"`
+	SessionsIdle    int         `json:"sessions_idle"`
+	MaxRobotUid     int         `json:"max_robot_uid"`
+	LifetimeSeconds int64       `json:"lifetime_seconds"`
+}
+
+func NewAdminApi(bindAddr string, srv *Server) *AdminApi {
+	api := &AdminApi{}
+	api.bindAddr = bindAddr
+	api.srv = srv
+	api.server = &fasthttp.Server{Handler: api.fastHttpHandler}
+	return api
+}
+
+func (api *AdminApi) Start() (err error) {
+	go func() {
+		log.Info("admin listen at %s", api.bindAddr)
+		if err := api.server.ListenAndServe(api.bindAddr); err != nil {
+			log.Error("admin ListenAndServe(%q) failed: %s", api.bindAddr, err)
+		}
+	}()
+	return
+}
+
+func (api *AdminApi) Shutdown(deadline time.Time) (err error) {
+	return shutdownServer(api.server, deadline)
+}
+
+func (api *AdminApi) authorized(ctx *fasthttp.RequestCtx) bool {
+	return checkAdminToken(api.srv.conf.Get().AdminToken, string(ctx.Request.Header.Peek("Authorization")))
+}
+
+// auth为Authorization的值，比较时间与令牌内容无关
+func checkAdminToken(token, auth string) bool {
+	if token == "" || !strings.HasPrefix(auth, AdminAuthScheme) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, AdminAuthScheme)), []byte(token)) == 1
+}
+
+func (api *AdminApi) fastHttpHandler(ctx *fasthttp.RequestCtx) {
+	response := &AdminResponse{}
+
+	defer func() {
+		ctx.SetContentType("application/json")
+		ctx.Write(response.JSON())
+	}()
+
+	if !api.authorized(ctx) {
+		log.Warn("admin unauthorized %s %s from %s", ctx.Method(), ctx.Path(), ctx.RemoteIP())
+		ctx.SetStatusCode(fasthttp.StatusUnauthorized)
+		response.Code = ResponseCodeBadSession
+		response.Msg = "unauthorized"
+		return
+	}
+
+	impl := api.srv.Logic
+	if impl == nil {
+		ctx.SetStatusCode(fasthttp.StatusServiceUnavailable)
+		response.Code = ResponseCodeInternalError
+		response.Msg = ErrNotInitialized.Error()
+		return
+	}
+
+	var (
+		err error
+	)
+
+	method := ctx.Method()
+	path := string(ctx.Path())
+
+	switch {
+	case bytes.Equal(method, GET) && path == "/admin/v1/waiting":
+		response.Data, err = impl.adminWaiting()
+	case bytes.Equal(method, GET) && path == "/admin/v1/matches":
+		response.Data = impl.adminMatches()
+	case bytes.Equal(method, GET) && path == "/admin/v1/match":
+		response.Data, err = impl.adminMatch(string(ctx.QueryArgs().Peek("match_id")))
+	case bytes.Equal(method, GET) && path == "/admin/v1/robots":
+		response.Data = api.srv.adminRobots()
+	case bytes.Equal(method, POST) && path == "/admin/v1/match/dispose":
+		request := &AdminMatchRequest{}
+		if err = parse(request, ctx); err == nil {
+			response.Data, err = impl.ForceDispose(request.MatchId, request.Refund)
+		}
+	case bytes.Equal(method, POST) && path == "/admin/v1/kick":
+		request := &AdminKickRequest{}
+		if err = parse(request, ctx); err == nil {
+			response.Data = map[string]int{"kicked": impl.Kick(request.Uid)}
+		}
+	case bytes.Equal(method, POST) && path == "/admin/v1/token/revoke":
+		request := &AdminRevokeTokenRequest{}
+		if err = parse(request, ctx); err == nil {
+			var kicked int
+			kicked, err = impl.RevokeToken(request.AccessToken)
+			response.Data = map[string]int{"kicked": kicked}
+		}
+	case bytes.Equal(method, POST) && path == "/admin/v1/level/pause":
+		request := &AdminLevelRequest{}
+		if err = parse(request, ctx); err == nil {
+			err = impl.PauseLevel(request.Level, true)
+		}
+	case bytes.Equal(method, POST) && path == "/admin/v1/level/resume":
+		request := &AdminLevelRequest{}
+		if err = parse(request, ctx); err == nil {
+			err = impl.PauseLevel(request.Level, false)
+		}
+	case bytes.Equal(method, GET) && path == "/admin/v1/ledger":
+		args := ctx.QueryArgs()
+		uid, _ := args.GetUint("uid")
+		offset, _ := args.GetUint("offset")
+		limit, _ := args.GetUint("limit")
+		response.Data, err = api.srv.adminLedger(uid, offset, limit)
+	case bytes.Equal(method, GET) && path == "/admin/v1/fraud/alerts":
+		args := ctx.QueryArgs()
+		uid, _ := args.GetUint("uid")
+		limit, _ := args.GetUint("limit")
+		response.Data, err = api.srv.adminFraudAlerts(uid, string(args.Peek("kind")), limit)
+	case bytes.Equal(method, GET) && path == "/admin/v1/fraud/pairs":
+		args := ctx.QueryArgs()
+		uid, _ := args.GetUint("uid")
+		limit, _ := args.GetUint("limit")
+		response.Data, err = api.srv.adminFraudPairs(uid, limit)
+	case bytes.Equal(method, POST) && path == "/admin/v1/fraud/clear":
+		request := &AdminFraudClearRequest{}
+		if err = parse(request, ctx); err == nil {
+			var cleared bool
+			cleared, err = api.srv.ClearFraud(request.Uids[0], request.Uids[1])
+			response.Data = map[string]bool{"cleared": cleared}
+		}
+	case bytes.Equal(method, POST) && path == "/admin/v1/config/validate":
+		response.Data = adminConfigCheck()
+	case bytes.Equal(method, POST) && path == "/admin/v1/leaderboard/rebuild":
+		response.Data, err = api.srv.adminLeaderboardRebuild()
+	case bytes.Equal(method, POST) && path == "/admin/v1/drain":
+		request := &AdminDrainRequest{}
+		if len(ctx.PostBody()) > 0 {
+			err = parse(request, ctx)
+		}
+		if err == nil {
+			response.Data = impl.adminDrain(request.TimeoutSecond)
+		}
+	default:
+		ctx.SetStatusCode(fasthttp.StatusNotFound)
+		response.Code = ResponseCodeBadRequestFormat
+		response.Msg = "not found"
+		return
+	}
+
+	if bytes.Equal(method, POST) {
+		log.Info("admin %s %s from %s: %s, err=%v", method, path, ctx.RemoteIP(), ctx.PostBody(), err)
+	}
+
+	switch e := err.(type) {
+	case nil:
+	case *RemoteMatchError:
+		// 比赛在其他节点上，需要到那个节点操作
+		ctx.SetStatusCode(fasthttp.StatusConflict)
+		response.Code = ResponseCodeBadMatchId
+		response.Msg = e.Error()
+	case *json.SyntaxError, *json.UnmarshalTypeError:
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		response.Code = ResponseCodeBadRequestFormat
+		response.Msg = e.Error()
+	default:
+		switch err {
+		case ErrUid, ErrFraudKind:
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			response.Code = ResponseCodeBadRequestFormat
+		case ErrAccessToken:
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			response.Code = ResponseCodeBadAccessToken
+		case ErrMatchId:
+			ctx.SetStatusCode(fasthttp.StatusNotFound)
+			response.Code = ResponseCodeBadMatchId
+		case ErrLevel:
+			ctx.SetStatusCode(fasthttp.StatusNotFound)
+			response.Code = ResponseCodeBadLevel
+		default:
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			response.Code = ResponseCodeInternalError
+		}
+		response.Msg = err.Error()
+	}
+}
+
+// 集群模式下返回共享队列，否则返回本节点的队列
+func (impl *LogicImpl) adminWaiting() (lists []*AdminWaitingList, err error) {
+	for _, wl := range impl.getWaitingLists() {
+		awl := &AdminWaitingList{Level: wl.level, Paused: wl.isPaused(), Waiting: []*AdminWaiting{}}
+
+		wl.mux.RLock()
+		for _, wd := range wl.list {
+			awl.Waiting = append(awl.Waiting, &AdminWaiting{Uid: wd.uid, Nickname: wd.nickname, Robot: !wd.IsMan(), Ts: wd.ts})
+		}
+		wl.mux.RUnlock()
+
+		if impl.cluster.Enabled() {
+			var (
+				list []*clusterWaiting
+			)
+
+			if list, err = impl.cluster.Waiting(wl.level); err != nil {
+				return
+			}
+			for _, cw := range list {
+				awl.Waiting = append(awl.Waiting, &AdminWaiting{Uid: cw.Uid, Nickname: cw.Nickname, Robot: !impl.cluster.isMan(cw.Uid), Node: cw.Node, Ts: cw.Ts})
+			}
+		}
+
+		lists = append(lists, awl)
+	}
+
+	sort.Slice(lists, func(i, j int) bool { return lists[i].Level < lists[j].Level })
+	return
+}
+
+func (impl *LogicImpl) adminMatches() (matches []*AdminMatch) {
+	matches = []*AdminMatch{}
+
+	impl.matchMux.RLock()
+	sessions := make([]*MatchSession, 0, len(impl.matchSessionMap))
+	for _, ms := range impl.matchSessionMap {
+		sessions = append(sessions, ms)
+	}
+	impl.matchMux.RUnlock()
+
+	for _, ms := range sessions {
+		matches = append(matches, ms.admin(false))
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].CreatedTs < matches[j].CreatedTs })
+	return
+}
+
+func (impl *LogicImpl) adminMatch(matchId string) (match *AdminMatch, err error) {
+	ms, err := impl.lookupMatchSession(matchId)
+	if err != nil {
+		return
+	}
+	if ms == nil {
+		return nil, ErrMatchId
+	}
+	return ms.admin(true), nil
+}
+
+func (ms *MatchSession) admin(rounds bool) *AdminMatch {
+	ms.mux.RLock()
+	defer ms.mux.RUnlock()
+
+	match := &AdminMatch{}
+	match.MatchId = ms.MatchId
+	match.Level = ms.Level
+	match.Round = ms.Round
+	match.Disposed = ms.isDisposed()
+	match.CreatedTs = ms.createdTs
+	for _, cp := range ms.Competitors {
+		match.Competitors = append(match.Competitors, &AdminCompetitor{
+			Uid:         cp.uid,
+			Nickname:    cp.Nickname,
+			Robot:       !cp.IsMan(),
+			Balance:     cp.Balance,
+			Status:      cp.Status(),
+			KeepAliveTs: cp.GetKeepAliveTs(),
+		})
+	}
+	// 复制一份，退款会修改Refunded
+	for _, settled := range ms.settled {
+		if !rounds {
+			break
+		}
+		_settled := *settled
+		match.Rounds = append(match.Rounds, &_settled)
+	}
+	return match
+}
+
+// 结束比赛，已经出拳的玩家收到KickOut；refund时退还本节点上已经结算的回合
+func (impl *LogicImpl) ForceDispose(matchId string, refund bool) (result *AdminDisposeResult, err error) {
+	ms, err := impl.lookupMatchSession(matchId)
+	if err != nil {
+		return
+	}
+	if ms == nil {
+		return nil, ErrMatchId
+	}
+
+	impl.matchMux.Lock()
+	delete(impl.matchSessionMap, matchId)
+	impl.matchMux.Unlock()
+
+	// 等待进行中的结算完成后再结束
+	impl.closeMatch(ms, ResponseCodeKickOut)
+
+	if impl.cluster.Enabled() {
+		impl.cluster.DeleteMatch(matchId)
+	}
+
+	result = &AdminDisposeResult{MatchId: matchId, Refunds: []*AdminRefund{}}
+	if refund {
+		result.Refunds = ms.refund(impl)
+	}
+	return
+}
+
+// 反向转账，赢家把实际到账的金额退还给输家，平台抽成不退还；
+// 排行榜和个人统计不回滚
+func (ms *MatchSession) refund(impl *LogicImpl) (refunds []*AdminRefund) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+
+	refunds = []*AdminRefund{}
+	for _, settled := range ms.settled {
+		t := settled.transfer
+		if t == nil || settled.Code != ResponseCodeOK || settled.Refunded {
+			continue
+		}
+
+		request := &TransferRequest{}
+		request.Traced = t.Traced
+		request.MatchId = t.MatchId
+		request.Round = t.Round
+		request.Level = t.Level
+		request.FromUid = t.ToUid
+		request.FromAccessToken = t.ToAccessToken
+		request.ToUid = t.FromUid
+		request.ToAccessToken = t.FromAccessToken
+		request.Amount = t.Amount - t.ToCost
+
+		response := &TransferResponse{}
+
+		code := ResponseCodeOK
+		if err := impl.accountManager.Transfer(request, response); err != nil {
+			log.Error("Refund failed: %s, request: %#v", err, request)
+			code = ResponseCodeInternalError
+		} else if response.Code != ResponseCodeOK {
+			log.Error("Refund failed: bad code, request: %#v, response: %#v", request, response)
+			code = response.Code
+		}
+
+		settled.Refunded = code == ResponseCodeOK
+		impl.onIncomingRefund(request, response, code)
+
+		refunds = append(refunds, &AdminRefund{
+			Round:   request.Round,
+			FromUid: request.FromUid,
+			ToUid:   request.ToUid,
+			Amount:  request.Amount,
+			Code:    code,
+		})
+	}
+	return
+}
+
+func (impl *LogicImpl) onIncomingRefund(request *TransferRequest, response *TransferResponse, code int) {
+	status := LedgerStatusRefund
+	if code != ResponseCodeOK {
+		status = LedgerStatusRefundFailed
+	}
+
+	ts := impl.clock.Now().Unix()
+	for _, e := range []struct {
+		uid, counterparty int
+		amount, balance   float64
+	}{
+		{request.FromUid, request.ToUid, -request.Amount, response.Data.FromBalance},
+		{request.ToUid, request.FromUid, request.Amount, response.Data.ToBalance},
+	} {
+		entry := &LedgerEntry{}
+		entry.MatchId = request.MatchId
+		entry.Round = request.Round
+		entry.Level = request.Level
+		entry.Uid = e.uid
+		entry.CounterpartyUid = e.counterparty
+		entry.Amount = e.amount
+		entry.Balance = e.balance
+		entry.Status = status
+		entry.Ts = ts
+		impl.history.OnLedger(entry)
+	}
+}
+
+// 把uid从所有等级的队列中移除，返回移除的数量
+func (impl *LogicImpl) Kick(uid int) (n int) {
+	response := &MatchResponse{Code: ResponseCodeKickOut}
+
+	for _, wl := range impl.getWaitingLists() {
+		wl.mux.Lock()
+		list := wl.list[:0]
+		for _, wd := range wl.list {
+			if wd.uid == uid {
+				wd.Notify(response)
+				n++
+				continue
+			}
+			list = append(list, wd)
+		}
+		wl.list = list
+		wl.mux.Unlock()
+	}
+
+	if impl.cluster.Enabled() {
+		n += impl.cluster.Kick(uid, response)
+	}
+	return
+}
+
+// 吊销access token，集群中的所有节点都不再接受。缓存中有对应的玩家时把玩家从所有队列中移除，
+// 返回移除的数量；进行中的比赛在下一个请求时返回BadAccessToken
+func (impl *LogicImpl) RevokeToken(accessToken string) (kicked int, err error) {
+	if accessToken == "" {
+		return 0, ErrAccessToken
+	}
+
+	uid := impl.accountManager.RevokeToken(accessToken)
+	if impl.cluster.Enabled() {
+		impl.cluster.RevokeToken(accessToken)
+	}
+
+	if uid > 0 {
+		kicked = impl.Kick(uid)
+	}
+	return
+}
+
+// 暂停后拒绝新的匹配并通知等待中的玩家，已经开始的比赛不受影响
+func (impl *LogicImpl) PauseLevel(level int, paused bool) (err error) {
+	wl := impl.getWaitingList(level)
+	if wl == nil {
+		return ErrLevel
+	}
+
+	if !paused {
+		atomic.StoreInt32(&wl.paused, 0)
+		return
+	}
+
+	if !atomic.CompareAndSwapInt32(&wl.paused, 0, 1) {
+		return
+	}
+
+	response := &MatchResponse{Code: ResponseCodeMaintenance}
+	wl.drain(response)
+	if impl.cluster.Enabled() {
+		impl.cluster.DrainLevel(level, response)
+	}
+	return
+}
+
+func (s *Server) adminRobots() *AdminRobotUsage {
+	usage := &AdminRobotUsage{}
+	usage.Idle, usage.Playing = s.Robots.Usage()
+	usage.SessionsInUse = s.Accounts.RobotSessionsInUse()
+	usage.SessionsIdle = s.Accounts.RobotSessionsIdle()
+	usage.MaxRobotUid = s.conf.Get().MaxRobotUid
+	usage.LifetimeSeconds = s.conf.Get().RobotLifetimeSecond
+	return usage
+}
+
+func (s *Server) adminLedger(uid, offset, limit int) (entries []*LedgerEntry, err error) {
+	if uid <= 0 {
+		return nil, ErrUid
+	}
+	if limit <= 0 {
+		limit = AdminLedgerLimit
+	} else if limit > AdminLedgerMaxLimit {
+		limit = AdminLedgerMaxLimit
+	}
+
+	if entries, err = s.History.Ledger(uid, offset, limit); err == nil && entries == nil {
+		entries = []*LedgerEntry{}
+	}
+	return
+}
+
+// 内存中最近的告警，集群模式下包括其他节点发现的
+func (s *Server) adminFraudAlerts(uid int, kind string, limit int) (alerts []*FraudAlert, err error) {
+	if kind != "" && !isFraudKind(kind) {
+		return nil, ErrFraudKind
+	}
+	if limit <= 0 {
+		limit = AdminFraudAlertLimit
+	} else if limit > AdminFraudAlertMaxLimit {
+		limit = AdminFraudAlertMaxLimit
+	}
+	return s.Fraud.Alerts(uid, kind, limit), nil
+}
+
+// 按uid最近的流水统计与每个对手的对局，不受fraud_window_second限制
+func (s *Server) adminFraudPairs(uid, limit int) (pairs []*FraudPairStats, err error) {
+	var (
+		entries []*LedgerEntry
+	)
+
+	if uid <= 0 {
+		return nil, ErrUid
+	}
+	if limit <= 0 {
+		limit = AdminFraudScanLimit
+	} else if limit > AdminFraudScanMaxLimit {
+		limit = AdminFraudScanMaxLimit
+	}
+
+	if entries, err = s.History.Ledger(uid, 0, limit); err != nil {
+		return
+	}
+	return AnalyzeLedger(s.conf.Get(), uid, entries), nil
+}
+
+// 确认是误报后清除两个玩家之间的统计，解除拦截，集群中的所有节点都会清除
+func (s *Server) ClearFraud(uid1, uid2 int) (cleared bool, err error) {
+	if uid1 <= 0 || uid2 <= 0 || uid1 == uid2 {
+		return false, ErrUid
+	}
+
+	cleared = s.Fraud.Clear(uid1, uid2)
+	if s.Cluster.Enabled() {
+		s.Cluster.FraudClear(uid1, uid2)
+	}
+	return
+}
+
+// 按当前的配置文件、环境变量和命令行参数重新加载一次，只检查不生效
+func adminConfigCheck() *AdminConfigCheck {
+	check := &AdminConfigCheck{File: confFile, Errors: []string{}}
+
+	_, err := loadConfig(confFile)
+	switch e := err.(type) {
+	case nil:
+		check.Valid = true
+	case *ConfigError:
+		for _, fe := range e.Errors {
+			check.Errors = append(check.Errors, fe.Error())
+		}
+	default:
+		check.Errors = append(check.Errors, err.Error())
+	}
+	return check
+}
+
+// 先写入队列中的结果和转账流水，再用流水重建未归档周期的榜单，然后归档已经结束的周期。
+// 重建前后几秒内结算的回合可能多算或少算，最好在流量低时或排空后执行。
+func (s *Server) adminLeaderboardRebuild() (rebuild *AdminLeaderboardRebuild, err error) {
+	rebuild = &AdminLeaderboardRebuild{}
+
+	now := s.Clock.Now()
+	before := s.Statistics.Metrics().Replayed
+	s.Statistics.Replay()
+	s.History.Flush()
+	m := s.Statistics.Metrics()
+	rebuild.Replayed = m.Replayed - before
+	rebuild.SpoolBytes = m.SpoolBytes
+
+	lb := s.Statistics.Leaderboard()
+	if rebuild.Entries, rebuild.Rebuilt, err = lb.rebuild(s.Store, now); err != nil {
+		return
+	}
+	rebuild.Archived, err = lb.roll(now)
+	return
+}
+
+// 排空后节点不再接受匹配，/readyz返回503，只能重启恢复
+func (impl *LogicImpl) adminDrain(timeoutSecond int) *AdminDrainResult {
+	if timeoutSecond <= 0 {
+		timeoutSecond = impl.conf.Get().ShutdownTimeoutSecond
+	}
+	impl.Drain(impl.clock.Now().Add(time.Duration(timeoutSecond) * time.Second))
+
+	impl.matchMux.RLock()
+	n := len(impl.matchSessionMap)
+	impl.matchMux.RUnlock()
+	return &AdminDrainResult{Draining: impl.isDraining(), Matches: n}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// 与服务端的Authorization: Bearer <admin_token>一致
+	AuthScheme = "Bearer "
+)
+
+type Response struct {
+	Code int             `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+// 返回码不为0时的错误，Status为http状态码
+type ApiError struct {
+	Status int
+	Code   int
+	Msg    string
+}
+
+func (e *ApiError) Error() string {
+	return fmt.Sprintf("%d %s (code %d)", e.Status, e.Msg, e.Code)
+}
+
+type Api struct {
+	addr   string
+	token  string
+	client *http.Client
+}
+
+func NewApi(addr, token string, timeout time.Duration) *Api {
+	api := &Api{}
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	api.addr = strings.TrimRight(addr, "/")
+	api.token = token
+	api.client = &http.Client{Timeout: timeout}
+	return api
+}
+
+func (api *Api) Get(path string, query url.Values, data interface{}) (err error) {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return api.do("GET", path, nil, data)
+}
+
+func (api *Api) Post(path string, arg interface{}, data interface{}) (err error) {
+	var (
+		v []byte
+	)
+
+	if arg != nil {
+		if v, err = json.Marshal(arg); err != nil {
+			return
+		}
+	}
+	return api.do("POST", path, v, data)
+}
+
+func (api *Api) do(method, path string, body []byte, data interface{}) (err error) {
+	var (
+		req  *http.Request
+		resp *http.Response
+		v    []byte
+	)
+
+	if req, err = http.NewRequest(method, api.addr+path, bytes.NewReader(body)); err != nil {
+		return
+	}
+	req.Header.Set("Authorization", AuthScheme+api.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if resp, err = api.client.Do(req); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if v, err = ioutil.ReadAll(resp.Body); err != nil {
+		return
+	}
+
+	response := &Response{}
+	if err = json.Unmarshal(v, response); err != nil {
+		return fmt.Errorf("%s %s: %d %s", method, path, resp.StatusCode, bytes.TrimSpace(v))
+	}
+
+	if response.Code != 0 {
+		return &ApiError{Status: resp.StatusCode, Code: response.Code, Msg: response.Msg}
+	}
+
+	if data == nil || len(response.Data) == 0 {
+		return
+	}
+	return json.Unmarshal(response.Data, data)
+}
@@ -1,25 +1,584 @@
+// fingerplayctl是管理接口(admin_bind_addr)的命令行工具，便于运维写脚本。
+//
+//	go build -o fingerplayctl ./client
+//	export FINGERPLAYCTL_ADDR=127.0.0.1:8092 FINGERPLAYCTL_TOKEN=...
+//	fingerplayctl matches ls
+//	fingerplayctl -o json queues | jq .
+//
+// 返回码不为0时退出码为1，参数错误时为2。
 package main
 
 import (
-	"math/rand"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+)
+
+const (
+	EnvAddr  = "FINGERPLAYCTL_ADDR"
+	EnvToken = "FINGERPLAYCTL_TOKEN"
+
+	DefaultTimeout = 10 * time.Second
+)
 
-	log "code.google.com/p/log4go"
+var (
+	ErrUsage = errors.New("usage")
 )
 
+var usage = `usage: fingerplayctl [flags] <command> [args]
+
+commands:
+  matches ls                          list matches on the node
+  matches show <match_id>             show a match with its settled rounds
+  matches dispose [-refund] <match_id>
+                                      close a match, optionally refunding settled rounds
+  queues                              list waiting players by level
+  kick <uid>                          remove a player from all queues
+  token revoke <access_token>         reject the token on all nodes and remove its player from the queues
+  levels pause|resume <level>         stop or restart matching on a level
+  robots                              show robot usage
+  config validate                     reload the node's config without applying it
+  ledger show [-offset n] [-limit n] <uid>
+                                      show a player's transfer ledger
+  fraud alerts [-uid n] [-kind kind] [-limit n]
+                                      show recent collusion alerts, newest first
+  fraud pairs [-limit n] <uid>        summarize a player's ledger by counterparty
+  fraud clear <uid> <uid>             forget a pair after a false positive, on all nodes
+  leaderboard rebuild                 recompute open periods from the ledger
+  drain [-timeout seconds]            stop matching and close matches on the node
+
+flags:
+`
+
+type command struct {
+	path []string
+	args string
+	run  func(api *Api, args []string) error
+}
+
+var commands = []*command{
+	{[]string{"matches", "ls"}, "", matchesLs},
+	{[]string{"matches", "show"}, "<match_id>", matchesShow},
+	{[]string{"matches", "dispose"}, "[-refund] <match_id>", matchesDispose},
+	{[]string{"queues"}, "", queues},
+	{[]string{"kick"}, "<uid>", kick},
+	{[]string{"token", "revoke"}, "<access_token>", tokenRevoke},
+	{[]string{"levels", "pause"}, "<level>", levelsPause},
+	{[]string{"levels", "resume"}, "<level>", levelsResume},
+	{[]string{"robots"}, "", robots},
+	{[]string{"config", "validate"}, "", configValidate},
+	{[]string{"ledger", "show"}, "[-offset n] [-limit n] <uid>", ledgerShow},
+	{[]string{"fraud", "alerts"}, "[-uid n] [-kind kind] [-limit n]", fraudAlerts},
+	{[]string{"fraud", "pairs"}, "[-limit n] <uid>", fraudPairs},
+	{[]string{"fraud", "clear"}, "<uid> <uid>", fraudClear},
+	{[]string{"leaderboard", "rebuild"}, "", leaderboardRebuild},
+	{[]string{"drain"}, "[-timeout seconds]", drain},
+}
+
 func main() {
-	s := rand.NewSource(123456789)
-	r := rand.New(s)
-	log.Debug("rand: %d", r.Intn(10000))
-	log.Debug("rand: %d", r.Intn(10000))
-	log.Debug("rand: %d", r.Intn(10000))
-	log.Debug("rand: %d", r.Intn(10000))
-
-	s = rand.NewSource(123456789)
-	r = rand.New(s)
-	log.Debug("rand: %d", r.Intn(10000))
-	log.Debug("rand: %d", r.Intn(10000))
-	log.Debug("rand: %d", r.Intn(10000))
-	log.Debug("rand: %d", r.Intn(10000))
-	time.Sleep(200 * time.Second)
+	var (
+		addr    = flag.String("addr", "", "admin api address, or $"+EnvAddr)
+		token   = flag.String("token", "", "admin token, or $"+EnvToken)
+		timeout = flag.Duration("timeout", DefaultTimeout, "request timeout")
+	)
+	flag.StringVar(&output, "o", OutputTable, "output format: table or json")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if output != OutputTable && output != OutputJSON {
+		fmt.Fprintf(os.Stderr, "bad output format %q\n", output)
+		os.Exit(2)
+	}
+
+	cmd, args := lookup(flag.Args())
+	if cmd == nil {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// 令牌不作为flag的默认值，避免出现在帮助信息中
+	if *addr == "" {
+		*addr = os.Getenv(EnvAddr)
+	}
+	if *token == "" {
+		*token = os.Getenv(EnvToken)
+	}
+	if *addr == "" || *token == "" {
+		fmt.Fprintf(os.Stderr, "-addr and -token (or $%s and $%s) are required\n", EnvAddr, EnvToken)
+		os.Exit(2)
+	}
+
+	err := cmd.run(NewApi(*addr, *token, *timeout), args)
+	switch err {
+	case nil:
+	case ErrUsage:
+		fmt.Fprintf(os.Stderr, "usage: fingerplayctl %s %s\n", strings.Join(cmd.path, " "), cmd.args)
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "fingerplayctl %s: %s\n", strings.Join(cmd.path, " "), err)
+		os.Exit(1)
+	}
+}
+
+func lookup(args []string) (*command, []string) {
+	for _, cmd := range commands {
+		if len(args) < len(cmd.path) {
+			continue
+		}
+		if strings.Join(args[:len(cmd.path)], " ") == strings.Join(cmd.path, " ") {
+			return cmd, args[len(cmd.path):]
+		}
+	}
+	return nil, nil
+}
+
+func argInt(args []string) (n int, err error) {
+	if len(args) != 1 {
+		return 0, ErrUsage
+	}
+	if n, err = strconv.Atoi(args[0]); err != nil {
+		return 0, ErrUsage
+	}
+	return
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	return nil
+}
+
+func matchesLs(api *Api, args []string) (err error) {
+	var (
+		matches []*Match
+	)
+
+	if len(args) != 0 {
+		return ErrUsage
+	}
+	if err = api.Get("/admin/v1/matches", nil, &matches); err != nil {
+		return
+	}
+
+	render(matches, func(w io.Writer) {
+		row(w, "MATCH ID", "LEVEL", "ROUND", "PLAYER 1", "PLAYER 2", "AGE")
+		for _, m := range matches {
+			players := []string{"-", "-"}
+			for i, cp := range m.Competitors {
+				if i < len(players) {
+					players[i] = formatPlayer(cp.Uid, cp.Robot)
+				}
+			}
+			row(w, m.MatchId, m.Level, m.Round, players[0], players[1], formatAge(m.CreatedTs))
+		}
+	})
+	return
+}
+
+func matchesShow(api *Api, args []string) (err error) {
+	var (
+		match *Match
+	)
+
+	if len(args) != 1 {
+		return ErrUsage
+	}
+	if err = api.Get("/admin/v1/match", url.Values{"match_id": {args[0]}}, &match); err != nil {
+		return
+	}
+
+	render(match, func(w io.Writer) {
+		row(w, "MATCH ID", match.MatchId)
+		row(w, "LEVEL", match.Level)
+		row(w, "ROUND", match.Round)
+		row(w, "DISPOSED", formatBool(match.Disposed))
+		row(w, "CREATED", formatTs(match.CreatedTs))
+		row(w)
+		row(w, "UID", "NICKNAME", "ROBOT", "BALANCE", "STATUS", "KEEPALIVE")
+		for _, cp := range match.Competitors {
+			row(w, cp.Uid, cp.Nickname, formatBool(cp.Robot), formatAmount(cp.Balance), cp.Status, formatTs(cp.KeepAliveTs))
+		}
+		row(w)
+		row(w, "ROUND", "RESULT", "OPERATES", "WINS", "CODE", "REFUNDED", "TIME")
+		for _, r := range match.Rounds {
+			row(w, r.Round, r.Result, fmt.Sprintf("%d/%d", r.Operates[0], r.Operates[1]),
+				formatAmount(r.Wins[0])+"/"+formatAmount(r.Wins[1]), r.Code, formatBool(r.Refunded), formatTs(r.Ts))
+		}
+	})
+	return
+}
+
+func matchesDispose(api *Api, args []string) (err error) {
+	var (
+		result *DisposeResult
+	)
+
+	fs := flag.NewFlagSet("dispose", flag.ContinueOnError)
+	refund := fs.Bool("refund", false, "refund settled rounds")
+	if err = parseFlags(fs, args); err != nil || fs.NArg() != 1 {
+		return ErrUsage
+	}
+
+	arg := map[string]interface{}{"match_id": fs.Arg(0), "refund": *refund}
+	if err = api.Post("/admin/v1/match/dispose", arg, &result); err != nil {
+		return
+	}
+
+	render(result, func(w io.Writer) {
+		row(w, "ROUND", "FROM", "TO", "AMOUNT", "CODE")
+		for _, r := range result.Refunds {
+			row(w, r.Round, r.FromUid, r.ToUid, formatAmount(r.Amount), r.Code)
+		}
+	})
+	return
+}
+
+func queues(api *Api, args []string) (err error) {
+	var (
+		lists []*WaitingList
+	)
+
+	if len(args) != 0 {
+		return ErrUsage
+	}
+	if err = api.Get("/admin/v1/waiting", nil, &lists); err != nil {
+		return
+	}
+
+	render(lists, func(w io.Writer) {
+		row(w, "LEVEL", "PAUSED", "UID", "NICKNAME", "ROBOT", "NODE", "WAITING")
+		for _, l := range lists {
+			if len(l.Waiting) == 0 {
+				row(w, l.Level, formatBool(l.Paused), "-", "-", "-", "-", "-")
+			}
+			for _, wd := range l.Waiting {
+				node := wd.Node
+				if node == "" {
+					node = "-"
+				}
+				row(w, l.Level, formatBool(l.Paused), wd.Uid, wd.Nickname, formatBool(wd.Robot), node, formatAge(wd.Ts))
+			}
+		}
+	})
+	return
+}
+
+func kick(api *Api, args []string) (err error) {
+	var (
+		uid    int
+		result map[string]int
+	)
+
+	if uid, err = argInt(args); err != nil {
+		return
+	}
+	if err = api.Post("/admin/v1/kick", map[string]int{"uid": uid}, &result); err != nil {
+		return
+	}
+
+	render(result, func(w io.Writer) {
+		row(w, "UID", "KICKED")
+		row(w, uid, result["kicked"])
+	})
+	return
+}
+
+func tokenRevoke(api *Api, args []string) (err error) {
+	var (
+		result map[string]int
+	)
+
+	if len(args) != 1 {
+		return ErrUsage
+	}
+	if err = api.Post("/admin/v1/token/revoke", map[string]string{"access_token": args[0]}, &result); err != nil {
+		return
+	}
+
+	render(result, func(w io.Writer) {
+		row(w, "KICKED")
+		row(w, result["kicked"])
+	})
+	return
+}
+
+func levelsPause(api *Api, args []string) error {
+	return pause(api, args, "/admin/v1/level/pause", "paused")
+}
+
+func levelsResume(api *Api, args []string) error {
+	return pause(api, args, "/admin/v1/level/resume", "resumed")
+}
+
+func pause(api *Api, args []string, path, state string) (err error) {
+	var (
+		level int
+	)
+
+	if level, err = argInt(args); err != nil {
+		return
+	}
+	if err = api.Post(path, map[string]int{"level": level}, nil); err != nil {
+		return
+	}
+
+	result := map[string]interface{}{"level": level, "state": state}
+	render(result, func(w io.Writer) {
+		row(w, "LEVEL", "STATE")
+		row(w, level, state)
+	})
+	return
+}
+
+func robots(api *Api, args []string) (err error) {
+	var (
+		usage *RobotUsage
+	)
+
+	if len(args) != 0 {
+		return ErrUsage
+	}
+	if err = api.Get("/admin/v1/robots", nil, &usage); err != nil {
+		return
+	}
+
+	render(usage, func(w io.Writer) {
+		row(w, "IDLE", usage.Idle)
+		row(w, "SESSIONS IN USE", usage.SessionsInUse)
+		row(w, "SESSIONS IDLE", usage.SessionsIdle)
+		row(w, "MAX ROBOT UID", usage.MaxRobotUid)
+		row(w, "LIFETIME", time.Duration(usage.LifetimeSeconds)*time.Second)
+		row(w)
+
+		levels := make([]int, 0, len(usage.Playing))
+		for k := range usage.Playing {
+			lv, _ := strconv.Atoi(k)
+			levels = append(levels, lv)
+		}
+		sort.Ints(levels)
+
+		row(w, "LEVEL", "PLAYING")
+		for _, lv := range levels {
+			row(w, lv, usage.Playing[strconv.Itoa(lv)])
+		}
+	})
+	return
+}
+
+func configValidate(api *Api, args []string) (err error) {
+	var (
+		check *ConfigCheck
+	)
+
+	if len(args) != 0 {
+		return ErrUsage
+	}
+	if err = api.Post("/admin/v1/config/validate", nil, &check); err != nil {
+		return
+	}
+
+	render(check, func(w io.Writer) {
+		if check.Valid {
+			row(w, "config "+check.File+" is ok")
+			return
+		}
+		row(w, "config "+check.File+" is invalid:")
+		for _, e := range check.Errors {
+			row(w, "  "+e)
+		}
+	})
+
+	if !check.Valid {
+		return fmt.Errorf("%d errors", len(check.Errors))
+	}
+	return
+}
+
+func ledgerShow(api *Api, args []string) (err error) {
+	var (
+		uid     int
+		entries []*LedgerEntry
+	)
+
+	fs := flag.NewFlagSet("ledger", flag.ContinueOnError)
+	offset := fs.Int("offset", 0, "skip the newest n entries")
+	limit := fs.Int("limit", 0, "number of entries, default 20, at most 200")
+	if err = parseFlags(fs, args); err != nil {
+		return
+	}
+	if uid, err = argInt(fs.Args()); err != nil {
+		return
+	}
+
+	query := url.Values{}
+	query.Set("uid", strconv.Itoa(uid))
+	query.Set("offset", strconv.Itoa(*offset))
+	query.Set("limit", strconv.Itoa(*limit))
+	if err = api.Get("/admin/v1/ledger", query, &entries); err != nil {
+		return
+	}
+
+	render(entries, func(w io.Writer) {
+		row(w, "TIME", "MATCH ID", "ROUND", "LEVEL", "COUNTERPARTY", "AMOUNT", "COST", "BALANCE", "STATUS")
+		for _, e := range entries {
+			row(w, formatTs(e.Ts), e.MatchId, e.Round, e.Level, e.CounterpartyUid,
+				formatAmount(e.Amount), formatAmount(e.Cost), formatAmount(e.Balance), e.Status)
+		}
+	})
+	return
+}
+
+func fraudAlerts(api *Api, args []string) (err error) {
+	var (
+		alerts []*FraudAlert
+	)
+
+	fs := flag.NewFlagSet("fraud alerts", flag.ContinueOnError)
+	uid := fs.Int("uid", 0, "only alerts involving the player")
+	kind := fs.String("kind", "", "repeated_pairing, one_sided_losses, shared_ip, shared_device or fast_moves")
+	limit := fs.Int("limit", 0, "number of alerts, default 100")
+	if err = parseFlags(fs, args); err != nil || fs.NArg() != 0 {
+		return ErrUsage
+	}
+
+	query := url.Values{}
+	query.Set("uid", strconv.Itoa(*uid))
+	query.Set("kind", *kind)
+	query.Set("limit", strconv.Itoa(*limit))
+	if err = api.Get("/admin/v1/fraud/alerts", query, &alerts); err != nil {
+		return
+	}
+
+	render(alerts, func(w io.Writer) {
+		row(w, "TIME", "KIND", "UIDS", "LEVEL", "MATCH ID", "NODE", "DETAIL")
+		for _, a := range alerts {
+			row(w, formatTs(a.Ts), a.Kind, fmt.Sprintf("%d,%d", a.Uids[0], a.Uids[1]), a.Level, a.MatchId, a.Node, a.Detail)
+		}
+	})
+	return
+}
+
+func fraudPairs(api *Api, args []string) (err error) {
+	var (
+		uid   int
+		pairs []*FraudPairStats
+	)
+
+	fs := flag.NewFlagSet("fraud pairs", flag.ContinueOnError)
+	limit := fs.Int("limit", 0, "ledger entries to scan, default 1000, at most 10000")
+	if err = parseFlags(fs, args); err != nil {
+		return
+	}
+	if uid, err = argInt(fs.Args()); err != nil {
+		return
+	}
+
+	query := url.Values{}
+	query.Set("uid", strconv.Itoa(uid))
+	query.Set("limit", strconv.Itoa(*limit))
+	if err = api.Get("/admin/v1/fraud/pairs", query, &pairs); err != nil {
+		return
+	}
+
+	render(pairs, func(w io.Writer) {
+		row(w, "COUNTERPARTY", "MATCHES", "ROUNDS", "LOSSES", "NET", "FIRST", "LAST", "FLAGS")
+		for _, p := range pairs {
+			row(w, p.CounterpartyUid, p.Matches, p.Rounds, p.Losses, formatAmount(p.Net),
+				formatTs(p.FirstTs), formatTs(p.LastTs), strings.Join(p.Flags, ","))
+		}
+	})
+	return
+}
+
+func fraudClear(api *Api, args []string) (err error) {
+	var (
+		uids   [2]int
+		result map[string]bool
+	)
+
+	if len(args) != 2 {
+		return ErrUsage
+	}
+	for i, arg := range args {
+		if uids[i], err = strconv.Atoi(arg); err != nil {
+			return ErrUsage
+		}
+	}
+	if err = api.Post("/admin/v1/fraud/clear", map[string][2]int{"uids": uids}, &result); err != nil {
+		return
+	}
+
+	render(result, func(w io.Writer) {
+		row(w, "UIDS", "CLEARED")
+		row(w, fmt.Sprintf("%d,%d", uids[0], uids[1]), formatBool(result["cleared"]))
+	})
+	return
+}
+
+func leaderboardRebuild(api *Api, args []string) (err error) {
+	var (
+		result *LeaderboardRebuild
+	)
+
+	if len(args) != 0 {
+		return ErrUsage
+	}
+	if err = api.Post("/admin/v1/leaderboard/rebuild", nil, &result); err != nil {
+		return
+	}
+
+	render(result, func(w io.Writer) {
+		row(w, "ENTRIES", "REBUILT", "REPLAYED", "ARCHIVED", "SPOOL BYTES")
+		row(w, result.Entries, result.Rebuilt, result.Replayed, result.Archived, result.SpoolBytes)
+	})
+	return
+}
+
+func drain(api *Api, args []string) (err error) {
+	var (
+		result *DrainResult
+	)
+
+	fs := flag.NewFlagSet("drain", flag.ContinueOnError)
+	timeout := fs.Int("timeout", 0, "seconds to wait for rounds to settle, default shutdown_timeout_second")
+	if err = parseFlags(fs, args); err != nil || fs.NArg() != 0 {
+		return ErrUsage
+	}
+
+	// 服务端等待回合结算后才返回，请求超时要比等待时间长
+	if wait := time.Duration(*timeout)*time.Second + DefaultTimeout; api.client.Timeout < wait {
+		api.client.Timeout = wait
+	}
+	if *timeout == 0 && api.client.Timeout < time.Minute {
+		api.client.Timeout = time.Minute
+	}
+
+	if err = api.Post("/admin/v1/drain", map[string]int{"timeout_second": *timeout}, &result); err != nil {
+		return
+	}
+
+	render(result, func(w io.Writer) {
+		row(w, "DRAINING", "MATCHES")
+		row(w, formatBool(result.Draining), result.Matches)
+	})
+	return
+}
+
+func formatPlayer(uid int, robot bool) string {
+	if robot {
+		return strconv.Itoa(uid) + " (robot)"
+	}
+	return strconv.Itoa(uid)
 }
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+var (
+	output           = OutputTable
+	stdout io.Writer = os.Stdout
+)
+
+// json时原样输出，table时交给各个命令自己排版
+func render(v interface{}, table func(w io.Writer)) {
+	if output == OutputJSON {
+		b, _ := json.MarshalIndent(v, "", "  ")
+		fmt.Fprintln(stdout, string(b))
+		return
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	table(w)
+	w.Flush()
+}
+
+func row(w io.Writer, cols ...interface{}) {
+	s := make([]string, len(cols))
+	for i, c := range cols {
+		s[i] = fmt.Sprint(c)
+	}
+	fmt.Fprintln(w, strings.Join(s, "\t"))
+}
+
+func formatTs(ts int64) string {
+	if ts <= 0 {
+		return "-"
+	}
+	return time.Unix(ts, 0).Format("2006-01-02 15:04:05")
+}
+
+// 距离现在的时间，例如12s、3m4s
+func formatAge(ts int64) string {
+	if ts <= 0 {
+		return "-"
+	}
+	return time.Since(time.Unix(ts, 0)).Truncate(time.Second).String()
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func formatAmount(f float64) string {
+	return fmt.Sprintf("%.2f", f)
+}
@@ -0,0 +1,123 @@
+package main
+
+// 与服务端admin.go中的返回结构对应，只保留需要展示的字段
+
+type Waiting struct {
+	Uid      int    `json:"uid"`
+	Nickname string `json:"nickname"`
+	Robot    bool   `json:"robot"`
+	Node     string `json:"node,omitempty"`
+	Ts       int64  `json:"ts"`
+}
+
+type WaitingList struct {
+	Level   int        `json:"level"`
+	Paused  bool       `json:"paused"`
+	Waiting []*Waiting `json:"waiting"`
+}
+
+type Competitor struct {
+	Uid         int     `json:"uid"`
+	Nickname    string  `json:"nickname"`
+	Robot       bool    `json:"robot"`
+	Balance     float64 `json:"balance"`
+	Status      int     `json:"status"`
+	KeepAliveTs int64   `json:"keep_alive_ts"`
+}
+
+type SettledRound struct {
+	Round    int        `json:"round"`
+	Result   int        `json:"result"`
+	Operates [2]int     `json:"operates"`
+	Wins     [2]float64 `json:"wins"`
+	Code     int        `json:"code"`
+	Refunded bool       `json:"refunded"`
+	Ts       int64      `json:"ts"`
+}
+
+type Match struct {
+	MatchId     string          `json:"match_id"`
+	Level       int             `json:"level"`
+	Round       int             `json:"round"`
+	Disposed    bool            `json:"disposed"`
+	CreatedTs   int64           `json:"created_ts"`
+	Competitors []*Competitor   `json:"competitors"`
+	Rounds      []*SettledRound `json:"rounds,omitempty"`
+}
+
+type Refund struct {
+	Round   int     `json:"round"`
+	FromUid int     `json:"from_uid"`
+	ToUid   int     `json:"to_uid"`
+	Amount  float64 `json:"amount"`
+	Code    int     `json:"code"`
+}
+
+type DisposeResult struct {
+	MatchId string    `json:"match_id"`
+	Refunds []*Refund `json:"refunds"`
+}
+
+type RobotUsage struct {
+	Idle            int            `json:"idle"`
+	Playing         map[string]int `json:"playing"`
+	SessionsInUse   int            `json:"sessions_in_use"`
+	SessionsIdle    int            `json:"sessions_idle"`
+	MaxRobotUid     int            `json:"max_robot_uid"`
+	LifetimeSeconds int64          `json:"lifetime_seconds"`
+}
+
+type ConfigCheck struct {
+	File   string   `json:"file"`
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors"`
+}
+
+type LedgerEntry struct {
+	MatchId         string  `json:"match_id"`
+	Round           int     `json:"round"`
+	Level           int     `json:"level"`
+	Uid             int     `json:"uid"`
+	CounterpartyUid int     `json:"counterparty_uid"`
+	Amount          float64 `json:"amount"`
+	Cost            float64 `json:"cost"`
+	Balance         float64 `json:"balance"`
+	Status          string  `json:"status"`
+	Ts              int64   `json:"ts"`
+}
+
+type FraudAlert struct {
+	Id      string `json:"id"`
+	Kind    string `json:"kind"`
+	Uids    [2]int `json:"uids"`
+	Level   int    `json:"level"`
+	MatchId string `json:"match_id"`
+	Detail  string `json:"detail"`
+	Node    string `json:"node"`
+	Ts      int64  `json:"ts"`
+}
+
+type FraudPairStats struct {
+	Uid             int      `json:"uid"`
+	CounterpartyUid int      `json:"counterparty_uid"`
+	Matches         int      `json:"matches"`
+	Rounds          int      `json:"rounds"`
+	Losses          int      `json:"losses"`
+	Net             float64  `json:"net"`
+	FirstTs         int64    `json:"first_ts"`
+	LastTs          int64    `json:"last_ts"`
+	Flags           []string `json:"flags"`
+}
+
+type LeaderboardRebuild struct {
+	Replayed   int64 `json:"replayed"`
+	Archived   int   `json:"archived"`
+	SpoolBytes int64 `json:"spool_bytes"`
+	Entries    int   `json:"entries"`
+	Rebuilt    int   `json:"rebuilt"`
+}
+
+type DrainResult struct {
+	Draining bool `json:"draining"`
+	Matches  int  `json:"matches"`
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"crypto/rand"
+	"io"
+	mrand "math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 逻辑层的时间和随机数都从Server.Clock取，默认是系统时间和math/rand。
+// 测试时在Start之前把Server.Clock换成ManualClock，匹配、超时、机器人和榜单归档
+// 就可以按步骤重现；DefaultIdSource换成固定的来源后id也是固定的。
+var (
+	DefaultClock Clock = systemClock{}
+	// GetGUID读取的随机字节
+	DefaultIdSource io.Reader = rand.Reader
+
+	randSeq int64
+)
+
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+type sleeper struct {
+	until time.Time
+	ch    chan struct{}
+}
+
+// 手动推进的时钟，Sleep一直阻塞到Advance越过它的唤醒时间
+type ManualClock struct {
+	mux      sync.Mutex
+	now      time.Time
+	sleepers []*sleeper
+}
+
+func NewManualClock(now time.Time) *ManualClock {
+	c := &ManualClock{}
+	c.now = now
+	return c
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) Sleep(d time.Duration) {
+	c.mux.Lock()
+	if d <= 0 {
+		c.mux.Unlock()
+		return
+	}
+	s := &sleeper{until: c.now.Add(d), ch: make(chan struct{})}
+	c.sleepers = append(c.sleepers, s)
+	c.mux.Unlock()
+
+	<-s.ch
+}
+
+// 推进时间，按唤醒时间的先后唤醒到期的Sleep
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	c.now = c.now.Add(d)
+
+	sort.Slice(c.sleepers, func(i, j int) bool { return c.sleepers[i].until.Before(c.sleepers[j].until) })
+	sleepers := c.sleepers[:0]
+	woken := []*sleeper{}
+	for _, s := range c.sleepers {
+		if s.until.After(c.now) {
+			sleepers = append(sleepers, s)
+		} else {
+			woken = append(woken, s)
+		}
+	}
+	c.sleepers = sleepers
+	c.mux.Unlock()
+
+	for _, s := range woken {
+		close(s.ch)
+	}
+}
+
+// 正在Sleep的goroutine数量
+func (c *ManualClock) Sleepers() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return len(c.sleepers)
+}
+
+// 等待至少n个goroutine进入Sleep，推进时间前用它确认循环已经跑完这一轮
+func (c *ManualClock) BlockUntil(n int) {
+	for c.Sleepers() < n {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type Rand interface {
+	Intn(n int) int
+}
+
+// math/rand.Rand不能并发使用，加锁后可以在多个请求之间共享
+type lockedRand struct {
+	mux sync.Mutex
+	r   *mrand.Rand
+}
+
+func NewRand(seed int64) Rand {
+	return &lockedRand{r: mrand.New(mrand.NewSource(seed))}
+}
+
+func (lr *lockedRand) Intn(n int) int {
+	lr.mux.Lock()
+	defer lr.mux.Unlock()
+	return lr.r.Intn(n)
+}
+
+// 种子由时钟和创建顺序决定，使用ManualClock时每次运行都相同
+func newRand(clock Clock) Rand {
+	return NewRand(clock.Now().UnixNano() + atomic.AddInt64(&randSeq, 1))
+}
@@ -0,0 +1,908 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+
+	log "code.google.com/p/log4go"
+)
+
+const (
+	ClusterKeyPrefix       = "fingerplay:"
+	ClusterNodeTTLSecond   = 5
+	ClusterMatchTTLSecond  = 120
+	ClusterLeaderTTLMillis = 3000
+	ClusterAdoptTTLSecond  = 10
+	// 共享队列中等待超过30秒由matcher通知超时，本地再多等一会，
+	// matcher或玩家所在节点失联时不会一直等下去
+	ClusterTicketTimeoutSecond = 40
+
+	ClusterMessagePair   = "pair"
+	ClusterMessageNotify = "notify"
+	ClusterMessageAI     = "ai"
+	ClusterMessageRevoke = "revoke"
+	// 串通检测的告警和清除，发给所有节点
+	ClusterMessageFraudAlert = "fraud_alert"
+	ClusterMessageFraudClear = "fraud_clear"
+
+	HeaderForwardedBy = "X-Fingerplay-Forwarded-By"
+)
+
+var (
+	// 续期或抢占matcher锁
+	clusterLeaderScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+return 0
+`)
+
+	// 停机时让出matcher锁，只删除自己持有的
+	clusterResignScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+	// 令牌桶，ARGV为每秒的速率、容量和当前毫秒数，返回需要等待的毫秒数，0表示放行
+	clusterRateLimitScript = redis.NewScript(1, `
+local rate, burst, now = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3])
+local b = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(b[1]) or burst
+local ts = tonumber(b[2]) or now
+if now > ts then
+	tokens = math.min(burst, tokens + (now - ts) * rate / 1000)
+	ts = now
+end
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", ts)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return wait
+`)
+)
+
+// 比赛在其他节点上，需要把请求转发到Addr
+type RemoteMatchError struct {
+	MatchId string
+	Addr    string
+}
+
+func (e *RemoteMatchError) Error() string {
+	return fmt.Sprintf("match %s is served by %s", e.MatchId, e.Addr)
+}
+
+// 共享等待队列中的一项，Node为玩家请求所在的节点
+type clusterWaiting struct {
+	Ticket      string  `json:"ticket"`
+	Node        string  `json:"node"`
+	Uid         int     `json:"uid"`
+	Balance     float64 `json:"balance"`
+	AccessToken string  `json:"access_token"`
+	Nickname    string  `json:"nickname"`
+	FbOpenId    string  `json:"fb_open_id"`
+	Ts          int64   `json:"ts"`
+	Ip          string  `json:"ip,omitempty"`
+	DeviceId    string  `json:"device_id,omitempty"`
+
+	raw string
+}
+
+type clusterQueue []*clusterWaiting
+
+func (q clusterQueue) Len() int      { return len(q) }
+func (q clusterQueue) Uid(i int) int { return q[i].Uid }
+func (q clusterQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+// 本节点在共享队列中等待的玩家
+type clusterTicket struct {
+	wd    *WaitingData
+	level int
+	cw    *clusterWaiting
+}
+
+// 比赛快照，节点重启或宕机后由其他节点接管
+type clusterMatch struct {
+	Node        string               `json:"node"`
+	Level       int                  `json:"level"`
+	MatchId     string               `json:"match_id"`
+	Round       int                  `json:"round"`
+	Competitors []*clusterCompetitor `json:"competitors"`
+}
+
+type clusterCompetitor struct {
+	Uid         int     `json:"uid"`
+	AccessToken string  `json:"access_token"`
+	Ticket      string  `json:"ticket"`
+	Balance     float64 `json:"balance"`
+	Nickname    string  `json:"nickname"`
+	Avatar      string  `json:"avatar"`
+}
+
+type clusterMessage struct {
+	Type     string          `json:"type"`
+	Level    int             `json:"level,omitempty"`
+	A        *clusterWaiting `json:"a,omitempty"`
+	B        *clusterWaiting `json:"b,omitempty"`
+	Ticket   string          `json:"ticket,omitempty"`
+	Response *MatchResponse  `json:"response,omitempty"`
+	Balance  float64         `json:"balance,omitempty"`
+	// 吊销的token，发给所有节点
+	AccessToken string `json:"access_token,omitempty"`
+	// 清除时只有Uids
+	Alert *FraudAlert `json:"alert,omitempty"`
+}
+
+// Cluster把等待队列和比赛会话放在redis中，使多个节点可以部署在负载均衡之后。
+// 比赛仍然由创建它的节点处理，其他节点收到请求时转发过去；
+// 所属节点失联后，收到请求的节点根据快照接管比赛。
+// 机器人的账号只保存在本地，机器人和它的比赛总是在同一个节点上。
+// nil表示单机模式。
+type Cluster struct {
+	ctx           *Context
+	impl          *LogicImpl
+	nodeId        string
+	advertiseAddr string
+	mux           sync.Mutex
+	tickets       map[string]*clusterTicket
+	// 当前的订阅连接，Close时关闭以结束阻塞的Receive
+	psc     *redis.PubSubConn
+	stop    chan struct{}
+	stopped int32
+	loops   sync.WaitGroup
+}
+
+func NewCluster(ctx *Context, impl *LogicImpl, nodeId, advertiseAddr string) *Cluster {
+	c := &Cluster{}
+	c.ctx = ctx
+	c.impl = impl
+	c.nodeId = nodeId
+	c.advertiseAddr = advertiseAddr
+	c.tickets = make(map[string]*clusterTicket)
+	c.stop = make(chan struct{})
+	return c
+}
+
+func (c *Cluster) Start() {
+	c.loops.Add(2)
+	go c.heartbeatLoop()
+	go c.subscribeLoop()
+}
+
+// 在对局排空之后调用：停止心跳和订阅，让出matcher锁并删除本节点，
+// 之后不再参与匹配。redis连接由调用者关闭。
+func (c *Cluster) Close() {
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return
+	}
+
+	close(c.stop)
+	c.mux.Lock()
+	if c.psc != nil {
+		c.psc.Close()
+	}
+	c.mux.Unlock()
+	c.loops.Wait()
+
+	if conn, err := c.ctx.GetRedisSession(); err == nil {
+		if _, err = clusterResignScript.Do(conn, leaderKey(), c.nodeId); err != nil {
+			log.Error("Cluster resign failed: %s", err)
+		}
+		conn.Close()
+	}
+	if _, err := c.do("DEL", nodeKey(c.nodeId)); err != nil {
+		log.Error("Cluster remove node %s failed: %s", c.nodeId, err)
+	}
+	log.Info("Cluster node %s closed", c.nodeId)
+}
+
+func (c *Cluster) isStopped() bool {
+	return atomic.LoadInt32(&c.stopped) == 1
+}
+
+// 等待d，Close时提前返回false
+func (c *Cluster) wait(d time.Duration) bool {
+	select {
+	case <-c.stop:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+func (c *Cluster) Enabled() bool { return c != nil }
+
+func (c *Cluster) NodeId() string { return c.nodeId }
+
+// 按本节点的配置判断，所有节点的max_robot_uid应该相同
+func (c *Cluster) isMan(uid int) bool { return c.impl.conf.Get().isMan(uid) }
+
+func (c *Cluster) do(command string, args ...interface{}) (reply interface{}, err error) {
+	conn, err := c.ctx.GetRedisSession()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	return conn.Do(command, args...)
+}
+
+func nodeKey(nodeId string) string    { return ClusterKeyPrefix + "node:" + nodeId }
+func channelKey(nodeId string) string { return ClusterKeyPrefix + "channel:" + nodeId }
+func waitingKey(level int) string     { return ClusterKeyPrefix + "waiting:" + strconv.Itoa(level) }
+func matchKey(matchId string) string  { return ClusterKeyPrefix + "match:" + matchId }
+func adoptKey(matchId string) string  { return ClusterKeyPrefix + "adopt:" + matchId }
+func leaderKey() string               { return ClusterKeyPrefix + "matcher" }
+func broadcastKey() string            { return ClusterKeyPrefix + "broadcast" }
+func nonceKey(nonce string) string    { return ClusterKeyPrefix + "nonce:" + nonce }
+func rateKey(key string) string       { return ClusterKeyPrefix + "rate:" + key }
+
+func (c *Cluster) heartbeatLoop() {
+	defer c.loops.Done()
+	for {
+		if _, err := c.do("SET", nodeKey(c.nodeId), c.advertiseAddr, "EX", ClusterNodeTTLSecond); err != nil {
+			log.Error("Cluster heartbeat failed: %s", err)
+		}
+		if !c.wait(1 * time.Second) {
+			return
+		}
+	}
+}
+
+func (c *Cluster) subscribeLoop() {
+	defer c.loops.Done()
+	for {
+		if err := c.subscribe(); err != nil && !c.isStopped() {
+			log.Error("Cluster subscribe failed: %s", err)
+		}
+		if !c.wait(1 * time.Second) {
+			return
+		}
+	}
+}
+
+func (c *Cluster) subscribe() (err error) {
+	rm := c.ctx.rm
+	if rm == nil {
+		return ErrRedisNotConnected
+	}
+
+	psc, err := rm.Subscribe()
+	if err != nil {
+		return
+	}
+	defer psc.Close()
+
+	c.mux.Lock()
+	c.psc = &psc
+	c.mux.Unlock()
+	if c.isStopped() {
+		return
+	}
+
+	if err = psc.Subscribe(channelKey(c.nodeId), broadcastKey()); err != nil {
+		return
+	}
+
+	for {
+		switch v := psc.Receive().(type) {
+		case redis.Message:
+			msg := &clusterMessage{}
+			if err := json.Unmarshal(v.Data, msg); err != nil {
+				log.Error("Cluster bad message %q: %s", v.Data, err)
+				continue
+			}
+			c.handle(msg)
+		case error:
+			return v
+		}
+	}
+}
+
+func (c *Cluster) publish(nodeId string, msg *clusterMessage) (err error) {
+	if nodeId == c.nodeId {
+		c.handle(msg)
+		return
+	}
+
+	var (
+		b []byte
+	)
+
+	if b, err = json.Marshal(msg); err != nil {
+		return
+	}
+
+	var (
+		n int
+	)
+
+	// 节点失联后没有订阅者，消息会丢失
+	if n, err = redis.Int(c.do("PUBLISH", channelKey(nodeId), b)); err == nil && n == 0 {
+		err = ErrNoSubscriber
+	}
+	return
+}
+
+// 发给包括自己在内的所有节点
+func (c *Cluster) broadcast(msg *clusterMessage) (err error) {
+	var (
+		b []byte
+	)
+
+	if b, err = json.Marshal(msg); err != nil {
+		return
+	}
+	_, err = c.do("PUBLISH", broadcastKey(), b)
+	return
+}
+
+func (c *Cluster) handle(msg *clusterMessage) {
+	switch msg.Type {
+	case ClusterMessagePair:
+		c.onPair(msg.Level, msg.A, msg.B)
+	case ClusterMessageNotify:
+		if wd := c.takeTicket(msg.Ticket); wd != nil {
+			wd.Notify(msg.Response)
+		}
+	case ClusterMessageAI:
+		c.impl.robots.GoGoGo(msg.Level, msg.Balance)
+	case ClusterMessageRevoke:
+		c.impl.accountManager.RevokeToken(msg.AccessToken)
+	case ClusterMessageFraudAlert:
+		if msg.Alert != nil {
+			c.impl.fraud.OnRemoteAlert(msg.Alert)
+		}
+	case ClusterMessageFraudClear:
+		if msg.Alert != nil {
+			c.impl.fraud.Clear(msg.Alert.Uids[0], msg.Alert.Uids[1])
+		}
+	default:
+		log.Error("Cluster unknown message type: %s", msg.Type)
+	}
+}
+
+func (c *Cluster) takeTicket(ticket string) (wd *WaitingData) {
+	c.mux.Lock()
+	if t := c.tickets[ticket]; t != nil {
+		wd = t.wd
+		delete(c.tickets, ticket)
+	}
+	c.mux.Unlock()
+	return
+}
+
+// 在本地等待超过ClusterTicketTimeoutSecond的玩家从共享队列中移除并通知超时，
+// 每个节点都执行，matcher失联或者配对消息丢失时玩家也能返回
+func (c *Cluster) sweepTickets(now int64) {
+	var (
+		expired []*clusterTicket
+	)
+
+	c.mux.Lock()
+	for ticket, t := range c.tickets {
+		if now-t.wd.ts > ClusterTicketTimeoutSecond {
+			expired = append(expired, t)
+			delete(c.tickets, ticket)
+		}
+	}
+	c.mux.Unlock()
+
+	for _, t := range expired {
+		c.remove(t.level, t.cw)
+		log.Warn("Cluster ticket %s of uid %d expired", t.cw.Ticket, t.cw.Uid)
+		t.wd.Notify(&MatchResponse{Code: ResponseCodeWaitMatchTimeout})
+	}
+}
+
+func (c *Cluster) notify(cw *clusterWaiting, response *MatchResponse) {
+	msg := &clusterMessage{Type: ClusterMessageNotify, Ticket: cw.Ticket, Response: response}
+	if err := c.publish(cw.Node, msg); err != nil {
+		log.Error("Cluster notify %s@%s failed: %s", cw.Ticket, cw.Node, err)
+	}
+}
+
+// 加入共享等待队列，匹配结果通过本地的wd.ch返回
+func (c *Cluster) PushWaiting(level int, wd *WaitingData) (err error) {
+	cw := &clusterWaiting{
+		Ticket:      GetGUID(),
+		Node:        c.nodeId,
+		Uid:         wd.uid,
+		Balance:     wd.balance,
+		AccessToken: wd.accessToken,
+		Nickname:    wd.nickname,
+		FbOpenId:    wd.fbOpenId,
+		Ts:          wd.ts,
+		Ip:          wd.ip,
+		DeviceId:    wd.deviceId,
+	}
+
+	var (
+		b []byte
+	)
+
+	if b, err = json.Marshal(cw); err != nil {
+		return
+	}
+	cw.raw = string(b)
+
+	c.mux.Lock()
+	c.tickets[cw.Ticket] = &clusterTicket{wd: wd, level: level, cw: cw}
+	c.mux.Unlock()
+
+	if _, err = c.do("ZADD", waitingKey(level), wd.ts, b); err != nil {
+		c.takeTicket(cw.Ticket)
+	}
+	return
+}
+
+// 停机时通知本节点等待中的玩家，并从共享队列中移除
+func (c *Cluster) Drain(response *MatchResponse) {
+	for _, wl := range c.impl.getWaitingLists() {
+		c.DrainLevel(wl.level, response)
+	}
+
+	c.mux.Lock()
+	tickets := c.tickets
+	c.tickets = make(map[string]*clusterTicket)
+	c.mux.Unlock()
+
+	for _, t := range tickets {
+		t.wd.Notify(response)
+	}
+}
+
+// 移除本节点在该等级共享队列中的玩家并通知他们
+func (c *Cluster) DrainLevel(level int, response *MatchResponse) {
+	raws, err := redis.Strings(c.do("ZRANGE", waitingKey(level), 0, -1))
+	if err != nil {
+		log.Error("Cluster drain level %d failed: %s", level, err)
+		return
+	}
+
+	for _, raw := range raws {
+		cw := &clusterWaiting{}
+		if json.Unmarshal([]byte(raw), cw) != nil || cw.Node != c.nodeId {
+			continue
+		}
+		cw.raw = raw
+		if c.remove(level, cw) {
+			if wd := c.takeTicket(cw.Ticket); wd != nil {
+				wd.Notify(response)
+			}
+		}
+	}
+}
+
+// 共享队列中某个等级的所有玩家，包括其他节点上的
+func (c *Cluster) Waiting(level int) (list []*clusterWaiting, err error) {
+	var (
+		raws []string
+	)
+
+	if raws, err = redis.Strings(c.do("ZRANGE", waitingKey(level), 0, -1)); err != nil {
+		return
+	}
+
+	for _, raw := range raws {
+		cw := &clusterWaiting{}
+		if json.Unmarshal([]byte(raw), cw) != nil {
+			continue
+		}
+		cw.raw = raw
+		list = append(list, cw)
+	}
+	return
+}
+
+// 从所有等级的共享队列中移除uid，通知所在节点，返回移除的数量
+func (c *Cluster) Kick(uid int, response *MatchResponse) (n int) {
+	for _, wl := range c.impl.getWaitingLists() {
+		list, err := c.Waiting(wl.level)
+		if err != nil {
+			log.Error("Cluster kick %d from level %d failed: %s", uid, wl.level, err)
+			continue
+		}
+		for _, cw := range list {
+			if cw.Uid == uid && c.remove(wl.level, cw) {
+				c.notify(cw, response)
+				n++
+			}
+		}
+	}
+	return
+}
+
+func (c *Cluster) isLeader() bool {
+	conn, err := c.ctx.GetRedisSession()
+	if err != nil {
+		log.Error("Cluster leader election failed: %s", err)
+		return false
+	}
+	defer conn.Close()
+
+	n, err := redis.Int(clusterLeaderScript.Do(conn, leaderKey(), c.nodeId, ClusterLeaderTTLMillis))
+	if err != nil {
+		log.Error("Cluster leader election failed: %s", err)
+		return false
+	}
+	return n == 1
+}
+
+// 由matcher节点执行，规则与WaitingList.match一致
+func (c *Cluster) match(now int64) {
+	if c.isStopped() || !c.isLeader() {
+		return
+	}
+
+	for _, wl := range c.impl.getWaitingLists() {
+		if wl.isPaused() {
+			continue
+		}
+		level := wl.level
+		if err := c.matchLevel(level, now); err != nil {
+			log.Error("Cluster match level %d failed: %s", level, err)
+		}
+	}
+}
+
+func (c *Cluster) matchLevel(level int, now int64) (err error) {
+	var (
+		raws []string
+	)
+
+	if raws, err = redis.Strings(c.do("ZRANGE", waitingKey(level), 0, -1)); err != nil {
+		return
+	}
+
+	list := []*clusterWaiting{}
+	for _, raw := range raws {
+		cw := &clusterWaiting{}
+		if err := json.Unmarshal([]byte(raw), cw); err != nil {
+			log.Error("Cluster bad waiting entry %q: %s", raw, err)
+			c.do("ZREM", waitingKey(level), raw)
+			continue
+		}
+		cw.raw = raw
+		list = append(list, cw)
+	}
+
+	// 失联节点上的玩家收不到结果，不参与匹配
+	if list, err = c.aliveWaiting(level, list); err != nil {
+		return
+	}
+
+	var skipped []*clusterWaiting
+	for len(list) >= 2 {
+		if !c.impl.fraud.pickOpponent(clusterQueue(list)) {
+			skipped = append(skipped, list[0])
+			list = list[1:]
+			continue
+		}
+
+		cw1 := list[0]
+		cw2 := list[1]
+		list = list[2:]
+
+		if cw1.Uid == cw2.Uid {
+			old, cur := cw1, cw2
+			if cw2.Ts < cw1.Ts {
+				old, cur = cw2, cw1
+			}
+			if c.remove(level, old) {
+				c.notify(old, &MatchResponse{Code: ResponseCodeKickOut})
+				log.Warn("Kick out: old=%#v new=%#v", old, cur)
+			}
+			list = append(list, cur)
+			continue
+		}
+
+		if !c.remove(level, cw1) {
+			list = append([]*clusterWaiting{cw2}, list...)
+			continue
+		}
+		if !c.remove(level, cw2) {
+			c.readd(level, cw1)
+			list = append([]*clusterWaiting{cw1}, list...)
+			continue
+		}
+
+		// Robot can not play with robot
+		if !c.isMan(cw1.Uid) && !c.isMan(cw2.Uid) {
+			response := &MatchResponse{Code: ResponseCodeKickOut}
+			c.notify(cw1, response)
+			c.notify(cw2, response)
+			continue
+		}
+
+		// 比赛放在第一个玩家所在的节点，有机器人时放在机器人所在的节点
+		if !c.isMan(cw2.Uid) {
+			cw1, cw2 = cw2, cw1
+		}
+		if err := c.publish(cw1.Node, &clusterMessage{Type: ClusterMessagePair, Level: level, A: cw1, B: cw2}); err != nil {
+			log.Error("Cluster pair %s & %s failed: %s", cw1.Ticket, cw2.Ticket, err)
+			c.readd(level, cw1)
+			c.readd(level, cw2)
+		}
+	}
+
+	list = append(skipped, list...)
+	// 与WaitingList.match相同，剩下的第一个玩家没有可以匹配的对手
+	if len(list) > 0 {
+		cw := list[0]
+		if now-cw.Ts > 30 {
+			if c.remove(level, cw) {
+				c.notify(cw, &MatchResponse{Code: ResponseCodeWaitMatchTimeout})
+			}
+		} else if c.isMan(cw.Uid) && now-cw.Ts > int64(c.impl.getMatchWaitSecond()) {
+			c.publish(cw.Node, &clusterMessage{Type: ClusterMessageAI, Level: level, Balance: cw.Balance})
+		}
+	}
+
+	return
+}
+
+// 去掉节点已经失联的玩家并从共享队列中移除
+func (c *Cluster) aliveWaiting(level int, list []*clusterWaiting) (alive []*clusterWaiting, err error) {
+	nodes := make(map[string]bool)
+	for _, cw := range list {
+		if _, ok := nodes[cw.Node]; ok {
+			continue
+		}
+		if nodes[cw.Node], err = redis.Bool(c.do("EXISTS", nodeKey(cw.Node))); err != nil {
+			return
+		}
+	}
+
+	for _, cw := range list {
+		if nodes[cw.Node] {
+			alive = append(alive, cw)
+		} else if c.remove(level, cw) {
+			log.Warn("Cluster drop waiting %s of uid %d: node %s is gone", cw.Ticket, cw.Uid, cw.Node)
+		}
+	}
+	return
+}
+
+func (c *Cluster) remove(level int, cw *clusterWaiting) bool {
+	n, err := redis.Int(c.do("ZREM", waitingKey(level), cw.raw))
+	if err != nil {
+		log.Error("Cluster remove waiting %s failed: %s", cw.Ticket, err)
+	}
+	return n == 1
+}
+
+// 经过pubsub的cw没有raw，重新序列化的结果与加入队列时相同
+func (c *Cluster) readd(level int, cw *clusterWaiting) {
+	if cw.raw == "" {
+		b, _ := json.Marshal(cw)
+		cw.raw = string(b)
+	}
+	if _, err := c.do("ZADD", waitingKey(level), cw.Ts, cw.raw); err != nil {
+		log.Error("Cluster readd waiting %s failed: %s", cw.Ticket, err)
+	}
+}
+
+func (c *Cluster) onPair(level int, cw1, cw2 *clusterWaiting) {
+	wd1 := c.takeTicket(cw1.Ticket)
+	if wd1 == nil {
+		// 本节点已经没有这个玩家了（例如重启过），对手重新排队
+		log.Warn("Cluster pair: ticket %s not found, requeue %s", cw1.Ticket, cw2.Ticket)
+		c.readd(level, cw2)
+		return
+	}
+
+	wd2 := c.takeTicket(cw2.Ticket)
+	if wd2 == nil && cw2.Node == c.nodeId {
+		// 对手已经在本节点超时返回，第一个玩家重新排队
+		log.Warn("Cluster pair: ticket %s not found, requeue %s", cw2.Ticket, cw1.Ticket)
+		c.mux.Lock()
+		c.tickets[cw1.Ticket] = &clusterTicket{wd: wd1, level: level, cw: cw1}
+		c.mux.Unlock()
+		c.readd(level, cw1)
+		return
+	}
+	if wd2 == nil {
+		wd2 = NewWaitingData(cw2.Uid, c.isMan(cw2.Uid), cw2.Balance, cw2.AccessToken, cw2.Nickname, cw2.FbOpenId, cw2.Ts)
+		wd2.ip = cw2.Ip
+		wd2.deviceId = cw2.DeviceId
+	}
+
+	response1, response2 := c.impl.createMatch(level, wd1, wd2)
+
+	wd1.Notify(response1)
+
+	if cw2.Node == c.nodeId {
+		wd2.Notify(response2)
+	} else {
+		c.notify(cw2, response2)
+	}
+}
+
+func (c *Cluster) SaveMatch(ms *MatchSession) {
+	cm := &clusterMatch{}
+	cm.Node = c.nodeId
+	cm.Level = ms.Level
+	cm.MatchId = ms.MatchId
+	cm.Round = ms.Round
+	for _, cp := range ms.Competitors {
+		cm.Competitors = append(cm.Competitors, &clusterCompetitor{
+			Uid:         cp.uid,
+			AccessToken: cp.accessToken,
+			Ticket:      cp.ticket,
+			Balance:     cp.Balance,
+			Nickname:    cp.Nickname,
+			Avatar:      cp.Avatar,
+		})
+	}
+
+	b, err := json.Marshal(cm)
+	if err == nil {
+		_, err = c.do("SET", matchKey(ms.MatchId), b, "EX", ClusterMatchTTLSecond)
+	}
+	if err != nil {
+		log.Error("Cluster save match %s failed: %s", ms.MatchId, err)
+	}
+}
+
+func (c *Cluster) DeleteMatch(matchId string) {
+	if _, err := c.do("DEL", matchKey(matchId)); err != nil {
+		log.Error("Cluster delete match %s failed: %s", matchId, err)
+	}
+}
+
+// 查找不在本地的比赛：所属节点存活时返回RemoteMatchError，
+// 所属节点失联时接管比赛，比赛不存在时返回nil, nil
+func (c *Cluster) Route(matchId string) (ms *MatchSession, err error) {
+	var (
+		b    []byte
+		addr string
+	)
+
+	if b, err = redis.Bytes(c.do("GET", matchKey(matchId))); err != nil {
+		if err == redis.ErrNil {
+			err = nil
+		}
+		return
+	}
+
+	cm := &clusterMatch{}
+	if err = json.Unmarshal(b, cm); err != nil {
+		return
+	}
+
+	if cm.Node != c.nodeId {
+		if addr, err = redis.String(c.do("GET", nodeKey(cm.Node))); err != nil && err != redis.ErrNil {
+			return
+		}
+		if addr != "" {
+			return nil, &RemoteMatchError{MatchId: matchId, Addr: addr}
+		}
+	}
+
+	var (
+		holder string
+	)
+
+	// 避免多个节点同时接管；本节点上同时到达的请求都继续，由adoptMatchSession去重
+	if _, err = redis.String(c.do("SET", adoptKey(matchId), c.nodeId, "NX", "EX", ClusterAdoptTTLSecond)); err == redis.ErrNil {
+		if holder, err = redis.String(c.do("GET", adoptKey(matchId))); err == redis.ErrNil || err == nil && holder != c.nodeId {
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return
+	}
+
+	competitors := []*Competitor{}
+	for _, _cp := range cm.Competitors {
+		cp := &Competitor{
+			readyCh:     make(chan *ReadyResponse, 1),
+			status:      CompetitorStatusIdle,
+			uid:         _cp.Uid,
+			man:         c.isMan(_cp.Uid),
+			accessToken: _cp.AccessToken,
+			ticket:      _cp.Ticket,
+			Balance:     _cp.Balance,
+			Nickname:    _cp.Nickname,
+			Avatar:      _cp.Avatar,
+		}
+		cp.KeepAlive(c.impl.clock.Now().Unix())
+		competitors = append(competitors, cp)
+	}
+
+	if len(competitors) != 2 {
+		return nil, ErrMatchId
+	}
+
+	ms = c.impl.adoptMatchSession(NewMatchSession(cm.Level, cm.MatchId, cm.Round, competitors[0], competitors[1], c.impl.clock.Now().Unix()))
+	log.Warn("Cluster adopt match %s from node %s at round %d", matchId, cm.Node, cm.Round)
+	return
+}
+
+// 通知所有节点吊销token，本节点也会收到
+func (c *Cluster) RevokeToken(accessToken string) {
+	if err := c.broadcast(&clusterMessage{Type: ClusterMessageRevoke, AccessToken: accessToken}); err != nil {
+		log.Error("Cluster revoke token %s failed: %s", redactToken(accessToken), err)
+	}
+}
+
+func (c *Cluster) FraudAlert(alert *FraudAlert) {
+	if err := c.broadcast(&clusterMessage{Type: ClusterMessageFraudAlert, Alert: alert}); err != nil {
+		log.Error("Cluster broadcast fraud alert %s failed: %s", alert.Id, err)
+	}
+}
+
+func (c *Cluster) FraudClear(uid1, uid2 int) {
+	if err := c.broadcast(&clusterMessage{Type: ClusterMessageFraudClear, Alert: &FraudAlert{Uids: fraudPairKey(uid1, uid2)}}); err != nil {
+		log.Error("Cluster broadcast fraud clear %d & %d failed: %s", uid1, uid2, err)
+	}
+}
+
+// 第一次使用nonce时返回true，所有节点共享
+func (c *Cluster) ClaimNonce(nonce string, ttlSecond int) (ok bool, err error) {
+	reply, err := redis.String(c.do("SET", nonceKey(nonce), c.nodeId, "NX", "EX", ttlSecond))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	return err == nil && reply == "OK", err
+}
+
+// 从所有节点共享的令牌桶中取一个令牌，返回需要等待的时间，0表示放行
+func (c *Cluster) TakeToken(key string, rate float64, burst int, now time.Time) (wait time.Duration, err error) {
+	conn, err := c.ctx.GetRedisSession()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ms, err := redis.Int64(clusterRateLimitScript.Do(conn, rateKey(key), rate, burst, now.UnixNano()/int64(time.Millisecond)))
+	return time.Duration(ms) * time.Millisecond, err
+}
+
+// 定期续期本节点上的比赛快照
+func (c *Cluster) RefreshMatches(sessions []*MatchSession) {
+	for _, ms := range sessions {
+		ms.mux.RLock()
+		c.SaveMatch(ms)
+		ms.mux.RUnlock()
+	}
+}
+
+// 未配置node_id时使用主机名和端口
+func getNodeId(conf *Config) string {
+	if conf.NodeId != "" {
+		return conf.NodeId
+	}
+	hostname, _ := os.Hostname()
+	_, port, _ := net.SplitHostPort(conf.HttpBindAddr)
+	return hostname + "-" + port
+}
+
+// 其他节点转发请求时使用的地址，例如http://10.0.0.1:8080
+func getAdvertiseAddr(conf *Config) string {
+	if conf.AdvertiseAddr != "" {
+		return strings.TrimRight(conf.AdvertiseAddr, "/")
+	}
+	host, port, _ := net.SplitHostPort(conf.HttpBindAddr)
+	if host == "" || host == "0.0.0.0" {
+		host, _ = os.Hostname()
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// 两个节点共用一个redis、一个账户服务和一个时钟，都监听本地的随机端口
+type testCluster struct {
+	redis *miniredis.Miniredis
+	clock *ManualClock
+	nodes []*Server
+}
+
+func freeAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func newTestCluster(t *testing.T) *testCluster {
+	tc := &testCluster{}
+	tc.redis = miniredis.RunT(t)
+	tc.clock = NewManualClock(time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local))
+
+	wallet := newTestConfig(t)
+	useTestWallet(t, wallet)
+
+	for _, nodeId := range []string{"node-a", "node-b"} {
+		cfg := newTestConfig(t)
+		cfg.EndpointDescribeUser = wallet.EndpointDescribeUser
+		cfg.EndpointTransfer = wallet.EndpointTransfer
+		cfg.RedisAddr = tc.redis.Addr()
+		cfg.NodeId = nodeId
+		cfg.HttpBindAddr = freeAddr(t)
+
+		s := newTestServer(t, cfg)
+		s.Clock = tc.clock
+		s.Robots = &countingRobots{}
+		tc.nodes = append(tc.nodes, startTestServer(t, s))
+	}
+
+	waitFor(t, "nodes subscribed", func() bool {
+		subs := tc.redis.PubSubNumSub(channelKey("node-a"), channelKey("node-b"))
+		return subs[channelKey("node-a")] == 1 && subs[channelKey("node-b")] == 1
+	})
+	return tc
+}
+
+// 两个玩家分别从两个节点排队，返回各自的匹配结果
+func (tc *testCluster) match(t *testing.T, tokens []string) []*MatchResponse {
+	responses := []*MatchResponse{{}, {}}
+	matched := make(chan bool, len(tokens))
+	for i := range tokens {
+		go func(i int) {
+			tc.nodes[i].Logic.Match(&MatchRequest{Level: 100, AccessToken: tokens[i]}, responses[i])
+			matched <- true
+		}(i)
+	}
+	waitFor(t, "both players in the shared queue", func() bool {
+		members, _ := tc.redis.ZMembers(waitingKey(100))
+		return len(members) == 2
+	})
+
+	stepClock(tc.clock, len(tc.nodes), 1)
+	for range tokens {
+		select {
+		case <-matched:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("players were not matched across nodes")
+		}
+	}
+	if responses[0].Code != ResponseCodeOK || responses[1].Code != ResponseCodeOK || responses[0].Data.MatchId != responses[1].Data.MatchId {
+		t.Fatalf("match responses = %d, %d, want one match", responses[0].Code, responses[1].Code)
+	}
+	return responses
+}
+
+// 比赛所在的节点和另一个节点
+func (tc *testCluster) owner(t *testing.T, matchId string) (owner, other *Server) {
+	for i, s := range tc.nodes {
+		if s.Logic.getMatchSession(matchId) != nil {
+			return s, tc.nodes[1-i]
+		}
+	}
+	t.Fatalf("match %s is on no node", matchId)
+	return
+}
+
+func TestClusterMatchesAcrossNodes(t *testing.T) {
+	tc := newTestCluster(t)
+	responses := tc.match(t, []string{"player-1", "player-2"})
+	matchId := responses[0].Data.MatchId
+
+	owner, other := tc.owner(t, matchId)
+	if other.Logic.getMatchSession(matchId) != nil {
+		t.Errorf("match %s is on both nodes", matchId)
+	}
+	if !tc.redis.Exists(matchKey(matchId)) {
+		t.Errorf("no snapshot of match %s", matchId)
+	}
+
+	_, err := other.Cluster.Route(matchId)
+	if e, ok := err.(*RemoteMatchError); !ok || e.Addr != "http://"+owner.conf.Get().HttpBindAddr {
+		t.Errorf("Route() on the other node = %v, want the owner's address", err)
+	}
+}
+
+func TestClusterForwardsToOwner(t *testing.T) {
+	tc := newTestCluster(t)
+	tokens := []string{"player-1", "player-2"}
+	responses := tc.match(t, tokens)
+	matchId := responses[0].Data.MatchId
+	owner, other := tc.owner(t, matchId)
+
+	// player-1在比赛所在的节点出拳
+	go owner.Logic.Ready(&ReadyRequest{Operate: Stone, MatchId: matchId, Round: responses[0].Data.Round,
+		AccessToken: tokens[0], SessionTicket: responses[0].Data.SessionTicket}, &ReadyResponse{})
+	waitFor(t, "player-1 ready", func() bool {
+		return owner.Logic.getMatchSession(matchId).getOpponentStatus(tokens[1]) == CompetitorStatusReady
+	})
+
+	// player-2的请求到了另一个节点，只有转发到所在节点才能看到对手已经出拳
+	body, _ := json.Marshal(&ReadyStatusRequest{AccessToken: tokens[1], MatchId: matchId, SessionTicket: responses[1].Data.SessionTicket})
+	req := &fasthttp.Request{}
+	req.Header.SetMethod("POST")
+	req.Header.SetContentType("application/json")
+	req.SetRequestURI(apiPath(ApiV2, "/ready/status"))
+	req.SetBody(body)
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(req, &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}, nil)
+	other.Http.fastHttpHandler(ctx)
+
+	response := &ReadyStatusResponse{}
+	if err := json.Unmarshal(ctx.Response.Body(), response); err != nil {
+		t.Fatalf("bad response %q: %s", ctx.Response.Body(), err)
+	}
+	if response.Code != ResponseCodeOK || response.Data.Status != CompetitorStatusReady {
+		t.Errorf("forwarded ready status = %d, %d, want the owner's answer", response.Code, response.Data.Status)
+	}
+}
+
+func TestClusterAdoptsMatchOfLostNode(t *testing.T) {
+	tc := newTestCluster(t)
+	tokens := []string{"player-1", "player-2"}
+	responses := tc.match(t, tokens)
+	matchId := responses[0].Data.MatchId
+	owner, other := tc.owner(t, matchId)
+
+	// 所在节点失联：不再续期，节点的记录消失，比赛快照还在
+	owner.Cluster.Close()
+	if !tc.redis.Exists(matchKey(matchId)) {
+		t.Fatalf("snapshot of match %s was removed", matchId)
+	}
+
+	ready := make(chan *ReadyResponse, 2)
+	for i := range tokens {
+		go func(i int) {
+			response := &ReadyResponse{}
+			other.Logic.Ready(&ReadyRequest{Operate: Stone, MatchId: matchId, Round: responses[i].Data.Round,
+				AccessToken: tokens[i], SessionTicket: responses[i].Data.SessionTicket}, response)
+			ready <- response
+		}(i)
+	}
+
+	for range tokens {
+		select {
+		case response := <-ready:
+			if response.Code != ResponseCodeOK {
+				t.Errorf("ready on the adopting node = %d, want %d", response.Code, ResponseCodeOK)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("round was not settled on the adopting node")
+		}
+	}
+	if other.Logic.getMatchSession(matchId) == nil {
+		t.Errorf("match %s was not adopted", matchId)
+	}
+}
+
+// 在node上排队，返回的channel收到匹配结果
+func (tc *testCluster) queue(t *testing.T, node *Server, token string) chan *MatchResponse {
+	before, _ := tc.redis.ZMembers(waitingKey(100))
+	result := make(chan *MatchResponse, 1)
+	go func() {
+		response := &MatchResponse{}
+		node.Logic.Match(&MatchRequest{Level: 100, AccessToken: token}, response)
+		result <- response
+	}()
+	waitFor(t, token+" in the shared queue", func() bool {
+		members, _ := tc.redis.ZMembers(waitingKey(100))
+		return len(members) == len(before)+1
+	})
+	return result
+}
+
+func expectMatchCode(t *testing.T, who string, result chan *MatchResponse, code int) {
+	select {
+	case response := <-result:
+		if response.Code != code {
+			t.Errorf("%s got code %d, want %d", who, response.Code, code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%s got no match response", who)
+	}
+}
+
+func expectNoMatch(t *testing.T, who string, result chan *MatchResponse) {
+	select {
+	case response := <-result:
+		t.Fatalf("%s got code %d, want it still waiting", who, response.Code)
+	default:
+	}
+}
+
+func TestClusterDropsWaitingOfLostNode(t *testing.T) {
+	tc := newTestCluster(t)
+	a, b := tc.nodes[0], tc.nodes[1]
+	resultA := tc.queue(t, a, "player-1")
+	resultB := tc.queue(t, b, "player-2")
+
+	// node-b停止，它的记录消失，排队的玩家还在共享队列中
+	b.Cluster.Close()
+	stepClock(tc.clock, len(tc.nodes), 1)
+
+	members, _ := tc.redis.ZMembers(waitingKey(100))
+	if len(members) != 1 {
+		t.Fatalf("shared queue = %v, want only the player of node-a", members)
+	}
+	expectNoMatch(t, "player-1", resultA)
+
+	// 共享队列的超时由matcher通知，node-b上的玩家由本地的超时返回
+	stepClock(tc.clock, len(tc.nodes), 30)
+	expectMatchCode(t, "player-1", resultA, ResponseCodeWaitMatchTimeout)
+	stepClock(tc.clock, len(tc.nodes), ClusterTicketTimeoutSecond-30)
+	expectMatchCode(t, "player-2", resultB, ResponseCodeWaitMatchTimeout)
+}
+
+func TestClusterRequeuesUndeliveredPair(t *testing.T) {
+	tc := newTestCluster(t)
+	a, b := tc.nodes[0], tc.nodes[1]
+
+	// node-b上的玩家先排队，比赛会放在node-b
+	resultB := tc.queue(t, b, "player-2")
+	stepClock(tc.clock, len(tc.nodes), 1)
+	resultA := tc.queue(t, a, "player-1")
+
+	// node-b已经停止订阅，但是记录还没有过期
+	b.Cluster.Close()
+	tc.redis.Set(nodeKey("node-b"), "http://"+b.conf.Get().HttpBindAddr)
+	stepClock(tc.clock, len(tc.nodes), 1)
+
+	members, _ := tc.redis.ZMembers(waitingKey(100))
+	if len(members) != 2 {
+		t.Fatalf("shared queue = %v, want both players requeued", members)
+	}
+	expectNoMatch(t, "player-1", resultA)
+
+	// 一直没有配对成功，两个节点各自的本地超时让玩家返回
+	stepClock(tc.clock, len(tc.nodes), ClusterTicketTimeoutSecond)
+	expectMatchCode(t, "player-1", resultA, ResponseCodeWaitMatchTimeout)
+	expectMatchCode(t, "player-2", resultB, ResponseCodeWaitMatchTimeout)
+	if members, _ := tc.redis.ZMembers(waitingKey(100)); len(members) != 0 {
+		t.Errorf("shared queue = %v, want the expired tickets removed", members)
+	}
+}
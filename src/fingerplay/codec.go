@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+
+	log "code.google.com/p/log4go"
+)
+
+// 低带宽的客户端可以选择更紧凑的编码：
+//
+//	Content-Type: application/msgpack  请求使用MessagePack，字段名与json相同
+//	Accept: application/msgpack        响应使用MessagePack
+//	Accept-Encoding: gzip              json响应超过GzipMinBytes时压缩
+//	X-Fingerplay-Delta: 1              比赛中的响应省略零值，缺少的字段按零值处理，见jsonValue
+const (
+	ContentTypeJSON    = "application/json"
+	ContentTypeMsgpack = "application/msgpack"
+	HeaderDelta        = "X-Fingerplay-Delta"
+
+	// 太小的响应压缩后反而更大
+	GzipMinBytes = 256
+)
+
+var (
+	msgpackEmptyMap = []byte{0x80}
+)
+
+// 一次请求协商的响应编码
+type Encoding struct {
+	Msgpack bool
+	Gzip    bool
+	Delta   bool
+}
+
+// 用于监控指标的标签
+func (enc Encoding) String() string {
+	s := "json"
+	if enc.Msgpack {
+		s = "msgpack"
+	}
+	if enc.Gzip {
+		s += "+gzip"
+	}
+	if enc.Delta {
+		s += "+delta"
+	}
+	return s
+}
+
+// 也接受application/x-msgpack和application/vnd.msgpack
+func isMsgpack(contentType []byte) bool {
+	return bytes.Contains(contentType, []byte("msgpack"))
+}
+
+// msgpack不压缩，已经足够紧凑；增量格式只用于比赛中的路由
+func negotiateEncoding(ctx *fasthttp.RequestCtx, route *versionedRoute) (enc Encoding) {
+	enc.Msgpack = isMsgpack(ctx.Request.Header.Peek("Accept"))
+	enc.Gzip = !enc.Msgpack && ctx.Request.Header.HasAcceptEncoding("gzip")
+	if delta := string(ctx.Request.Header.Peek(HeaderDelta)); route.Delta && delta != "" && delta != "0" {
+		enc.Delta = true
+	}
+	return
+}
+
+func encodeResponse(response interface{}, enc Encoding) (body []byte, err error) {
+	if !enc.Msgpack && !enc.Delta {
+		return json.Marshal(response)
+	}
+
+	v := jsonValue(reflect.ValueOf(response), enc.Delta)
+	if enc.Msgpack {
+		return msgpackMarshal(v)
+	}
+	return json.Marshal(v)
+}
+
+// 按协商的编码写回响应，编码失败时退回json
+func replyEncoded(ctx *fasthttp.RequestCtx, code int, response interface{}, enc Encoding) {
+	body, err := encodeResponse(response, enc)
+	if err != nil {
+		log.Error("encode %s failed: %s, response: %#v", enc, err, response)
+		enc = Encoding{}
+		body, _ = json.Marshal(response)
+	}
+
+	ctx.Response.Header.Set("Vary", "Accept, Accept-Encoding, "+HeaderDelta)
+	if enc.Msgpack {
+		ctx.SetContentType(ContentTypeMsgpack)
+	} else {
+		ctx.SetContentType(ContentTypeJSON)
+	}
+	if enc.Delta {
+		ctx.Response.Header.Set(HeaderDelta, "1")
+	}
+	if enc.Gzip && len(body) >= GzipMinBytes {
+		body = fasthttp.AppendGzipBytes(nil, body)
+		ctx.Response.Header.Set("Content-Encoding", "gzip")
+	} else {
+		enc.Gzip = false
+	}
+
+	observeResponseBytes(enc.String(), len(body))
+	reply(ctx, code, body)
+}
+
+// MessagePack的请求先转换为json的数据模型，再按json的规则填充请求
+func msgpackDecode(b []byte, request interface{}) (err error) {
+	v, err := msgpackUnmarshal(b)
+	if err != nil {
+		return
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	return json.Unmarshal(j, request)
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// 转换为json的数据模型，结果与json.Marshal相同。
+// delta时所有的零值都省略，带有delta:"omit"标签的字段也省略，这些字段在比赛的第一条消息（匹配结果）中已经给出。
+// 这不是相对上一条消息的差量，服务端不记录客户端收到过什么：缺少的字段就是零值，
+// 例如出拳Stone和结果Lost都是0，在增量格式中不出现
+func jsonValue(v reflect.Value, delta bool) interface{} {
+	if v.IsValid() && v.Type().Implements(jsonMarshalerType) {
+		return jsonRoundTrip(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return jsonValue(v.Elem(), delta)
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return jsonRoundTrip(v)
+		}
+		a := make([]interface{}, v.Len())
+		for i := range a {
+			a[i] = jsonValue(v.Index(i), delta)
+		}
+		return a
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[jsonMapKey(iter.Key())] = jsonValue(iter.Value(), delta)
+		}
+		return m
+	case reflect.Struct:
+		m := make(map[string]interface{})
+		eachJsonField(v.Type(), func(name string, f reflect.StructField, index []int) {
+			fv := v.FieldByIndex(index)
+			omitEmpty := strings.Contains(f.Tag.Get("json"), ",omitempty")
+			if (omitEmpty || delta) && isEmptyJsonValue(fv) {
+				return
+			}
+			if delta && f.Tag.Get("delta") == "omit" {
+				return
+			}
+			m[name] = jsonValue(fv, delta)
+		})
+		return m
+	default:
+		return jsonRoundTrip(v)
+	}
+}
+
+func jsonMapKey(k reflect.Value) string {
+	switch k.Kind() {
+	case reflect.String:
+		return k.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(k.Uint(), 10)
+	default:
+		b, _ := json.Marshal(k.Interface())
+		return strings.Trim(string(b), `"`)
+	}
+}
+
+// 与json的omitempty相同
+func isEmptyJsonValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// 自定义序列化的类型交给encoding/json
+func jsonRoundTrip(v reflect.Value) (x interface{}) {
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil
+	}
+	json.Unmarshal(b, &x)
+	return
+}
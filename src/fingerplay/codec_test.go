@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func newTestMatchResponse() *MatchResponse {
+	response := &MatchResponse{}
+	response.Data.ServerTimestamp = 1791979200000
+	response.Data.ExpireTimestamp = 1791979210000
+	response.Data.MatchId = "5881a0a6fb463cc46f00139bd9638cf7"
+	response.Data.TimeoutSecond = 10
+	response.Data.SessionTicket = "c2Vzc2lvbi10aWNrZXQ"
+	response.Data.Competitors = []*Competitor{
+		{AccessToken: "player-1", Balance: 1095.5, Nickname: "Alice", Avatar: "https://graph.facebook.com/100001/picture?type=large"},
+		{AccessToken: "player-2", Balance: 900, Nickname: "Bob", Avatar: "https://graph.facebook.com/100002/picture?type=large"},
+	}
+	return response
+}
+
+// 第一项是石头输，第二项是布赢，增量格式中石头和输都是零值
+func newTestReadyResponse() *ReadyResponse {
+	response := &ReadyResponse{}
+	response.Data.ServerTimestamp = 1791979201000
+	response.Data.ExpireTimestamp = 1791979211000
+	response.Data.Round = 1
+	response.Data.Results = []*Result{
+		{AccessToken: "player-1", Operate: Stone, Status: Lost, Balance: 995, Win: -100},
+		{Operate: Paper, Status: Won, Balance: 995, Win: 95},
+	}
+	return response
+}
+
+func decodeEncoded(t *testing.T, body []byte, enc Encoding, response interface{}) {
+	var err error
+	if enc.Msgpack {
+		err = msgpackDecode(body, response)
+	} else {
+		err = json.Unmarshal(body, response)
+	}
+	if err != nil {
+		t.Fatalf("%s: decode %q failed: %s", enc, body, err)
+	}
+}
+
+func TestEncodeResponseRoundTrip(t *testing.T) {
+	for _, enc := range []Encoding{{}, {Msgpack: true}, {Delta: true}, {Msgpack: true, Delta: true}} {
+		body, err := encodeResponse(newTestMatchResponse(), enc)
+		if err != nil {
+			t.Fatalf("%s: encode failed: %s", enc, err)
+		}
+		match := &MatchResponse{}
+		decodeEncoded(t, body, enc, match)
+		// 匹配结果没有零值以外的省略，缺少的字段解码为零值
+		if want := newTestMatchResponse(); !reflect.DeepEqual(match, want) {
+			t.Errorf("%s: match response = %+v, want %+v", enc, match.Data, want.Data)
+		}
+
+		if body, err = encodeResponse(newTestReadyResponse(), enc); err != nil {
+			t.Fatalf("%s: encode failed: %s", enc, err)
+		}
+		ready := &ReadyResponse{}
+		decodeEncoded(t, body, enc, ready)
+		want := newTestReadyResponse()
+		if enc.Delta {
+			want.Data.Results[0].AccessToken = ""
+		}
+		if !reflect.DeepEqual(ready, want) {
+			t.Errorf("%s: ready response = %+v, want %+v", enc, ready.Data, want.Data)
+		}
+	}
+}
+
+func TestEncodeDeltaOmitsZeroValues(t *testing.T) {
+	full, _ := encodeResponse(newTestReadyResponse(), Encoding{})
+	delta, err := encodeResponse(newTestReadyResponse(), Encoding{Delta: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v struct {
+		Code *int `json:"code"`
+		Data struct {
+			Results []map[string]interface{} `json:"results"`
+		} `json:"data"`
+	}
+	if err = json.Unmarshal(delta, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Code != nil || len(v.Data.Results) != 2 {
+		t.Fatalf("delta = %s, want no code and two results", delta)
+	}
+	if first := v.Data.Results[0]; len(first) != 2 || first["balance"] != 995.0 || first["win"] != -100.0 {
+		t.Errorf("first result = %v, want only balance and win", first)
+	}
+	if second := v.Data.Results[1]; second["operate"] != float64(Paper) || second["status"] != float64(Won) {
+		t.Errorf("second result = %v, want operate and status", second)
+	}
+	if len(delta) >= len(full) {
+		t.Errorf("delta is %d bytes, full json %d", len(delta), len(full))
+	}
+}
+
+func TestReplyEncodedGzip(t *testing.T) {
+	for _, enc := range []Encoding{{Gzip: true}, {Gzip: true, Delta: true}} {
+		ctx := &fasthttp.RequestCtx{}
+		replyEncoded(ctx, ResponseCodeOK, newTestMatchResponse(), enc)
+		if ce := string(ctx.Response.Header.Peek("Content-Encoding")); ce != "gzip" {
+			t.Fatalf("%s: Content-Encoding = %q, want gzip", enc, ce)
+		}
+		if delta := string(ctx.Response.Header.Peek(HeaderDelta)); (delta == "1") != enc.Delta {
+			t.Errorf("%s: %s = %q", enc, HeaderDelta, delta)
+		}
+		body, err := fasthttp.AppendGunzipBytes(nil, ctx.Response.Body())
+		if err != nil {
+			t.Fatalf("%s: gunzip failed: %s", enc, err)
+		}
+		match := &MatchResponse{}
+		decodeEncoded(t, body, enc, match)
+		if want := newTestMatchResponse(); !reflect.DeepEqual(match, want) {
+			t.Errorf("%s: match response = %+v, want %+v", enc, match.Data, want.Data)
+		}
+	}
+
+	// 小的响应不压缩
+	ctx := &fasthttp.RequestCtx{}
+	replyEncoded(ctx, ResponseCodeOK, &ReadyStatusResponse{}, Encoding{Gzip: true})
+	if ce := ctx.Response.Header.Peek("Content-Encoding"); len(ce) != 0 || !bytes.HasPrefix(ctx.Response.Body(), []byte("{")) {
+		t.Errorf("small response encoded as %q: %q", ce, ctx.Response.Body())
+	}
+}
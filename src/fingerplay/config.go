@@ -3,7 +3,11 @@ package main
 import (
 	"encoding/json"
 	"flag"
+	"fmt"
 	"io/ioutil"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/BurntSushi/toml"
@@ -12,41 +16,134 @@ import (
 )
 
 var (
-	Conf     = &Config{}
-	confFile string
+	confValue   = NewConfigValue(newConfig())
+	confMux     sync.Mutex
+	confFile    string
+	checkConfig bool
+	printConfig bool
+
+	// 已经删除的配置项，老的配置文件中仍然可能有，只打印警告，不影响启动
+	deprecatedConfigKeys = map[string]string{
+		"fake_ranking": "the ranking is read from the store",
+	}
 )
 
 func init() {
-	Conf.ServerName = "fingerplay"
-	flag.StringVar(&confFile, "c", "conf/fingerplay.toml", "config file path")
+	flag.StringVar(&confFile, "c", "conf/fingerplay.toml", "config file path, empty to use only env and flags")
+	flag.BoolVar(&checkConfig, "check-config", false, "validate the config file and exit")
+	flag.BoolVar(&printConfig, "print-config", false, "print the effective config with secrets redacted and exit")
+	registerConfigFlags()
 }
 
+// 带reload:"live"标签的字段可以通过SIGHUP热更新，其他字段修改后需要重启；
+// 带secret:"true"标签的字段在日志和输出中隐藏。
+// fraud_*的阈值按单个节点内存中的计数判断，集群模式下只有告警会广播到其他节点
 type Config struct {
-	Debug                bool           `toml:"debug"`
-	ServerName           string         `toml:"-"`
-	HttpBindAddr         string         `toml:"http_bind_addr"`
-	Levels               []int          `toml:"levels"`
-	OperateTimeoutSecond int            `toml:"operate_timeout_second"`
-	MatchWaitSecond      int            `toml:"match_wait_second"`
-	EndpointDescribeUser string         `toml:"endpoint_describe_user"`
-	EndpointTransfer     string         `toml:"endpoint_transfer"`
-	EndpointLoginAI      string         `toml:"endpoint_login_ai"`
-	RobotUid             int            `toml:"robot_uid"`
-	RobotFbOpenId        string         `toml:"robot_fb_open_id"`
-	RobotLifetimeSecond  int64          `toml:"robot_lifetime_second"`
-	MongoServerAddrs     string         `toml:"mongo_server_addrs"`
-	MongoDb              string         `toml:"mongo_db"`
-	FakeRanking          []*ResultLog   `toml:"fake_ranking"`
-	AvatarNum            int            `toml:"avatar_num"`
-	AvatarUrlTemplate    string         `toml:"avatar_url_template"`
-	Nicknames            []string       `toml:"nicknames"`
-	MaxRobotUid          int            `toml:"max_robot_uid"`
-	BaseOnlineNumbers    []int          `toml:"base_online_numbers"`
-	Robots               []*RobotAvatar `toml:"robot"`
+	Debug                 bool                  `toml:"debug" reload:"live"`
+	LogLevel              string                `toml:"log_level" reload:"live"`
+	LogFormat             string                `toml:"log_format"`
+	ServerName            string                `toml:"-"`
+	HttpBindAddr          string                `toml:"http_bind_addr"`
+	Levels                []int                 `toml:"levels" reload:"live"`
+	LevelCosts            map[string]float64    `toml:"level_costs" reload:"live"`
+	OperateTimeoutSecond  int                   `toml:"operate_timeout_second" reload:"live"`
+	MatchWaitSecond       int                   `toml:"match_wait_second" reload:"live"`
+	EndpointDescribeUser  string                `toml:"endpoint_describe_user" secret:"true"`
+	EndpointTransfer      string                `toml:"endpoint_transfer" secret:"true"`
+	EndpointLoginAI       string                `toml:"endpoint_login_ai" secret:"true"`
+	RobotUid              int                   `toml:"robot_uid"`
+	RobotFbOpenId         string                `toml:"robot_fb_open_id"`
+	RobotLifetimeSecond   int64                 `toml:"robot_lifetime_second"`
+	MongoServerAddrs      string                `toml:"mongo_server_addrs" secret:"true"`
+	MongoDb               string                `toml:"mongo_db"`
+	AvatarNum             int                   `toml:"avatar_num" reload:"live"`
+	AvatarUrlTemplate     string                `toml:"avatar_url_template" reload:"live"`
+	Nicknames             []string              `toml:"nicknames" reload:"live"`
+	MaxRobotUid           int                   `toml:"max_robot_uid"`
+	BaseOnlineNumbers     []int                 `toml:"base_online_numbers" reload:"live"`
+	Robots                []*RobotAvatar        `toml:"robot" reload:"live"`
+	StatisticsSpoolDir    string                `toml:"statistics_spool_dir"`
+	HistorySpoolDir       string                `toml:"history_spool_dir"`
+	StoreDriver           string                `toml:"store_driver"`
+	StoreDsn              string                `toml:"store_dsn" secret:"true"`
+	RedisAddr             string                `toml:"redis_addr"`
+	NodeId                string                `toml:"node_id"`
+	AdvertiseAddr         string                `toml:"advertise_addr"`
+	ShutdownTimeoutSecond int                   `toml:"shutdown_timeout_second" reload:"live"`
+	TraceExporter         string                `toml:"trace_exporter"`
+	TraceEndpoint         string                `toml:"trace_endpoint"`
+	TraceSampleRatio      float64               `toml:"trace_sample_ratio"`
+	AdminBindAddr         string                `toml:"admin_bind_addr"`
+	AdminToken            string                `toml:"admin_token" secret:"true" reload:"live"`
+	GrpcBindAddr          string                `toml:"grpc_bind_addr"`
+	SignSecret            string                `toml:"sign_secret" secret:"true" reload:"live"`
+	SignWindowSecond      int                   `toml:"sign_window_second" reload:"live"`
+	TokenCacheSecond      int                   `toml:"token_cache_second" reload:"live"`
+	RequireSessionTicket  bool                  `toml:"require_session_ticket" reload:"live"`
+	RateLimits            map[string]*RateLimit `toml:"rate_limit" reload:"live"`
+	RateLimitStore        string                `toml:"rate_limit_store" reload:"live"`
+	ClientIpHeader        string                `toml:"client_ip_header" reload:"live"`
+	FraudWindowSecond     int                   `toml:"fraud_window_second" reload:"live"`
+	FraudPairMatches      int                   `toml:"fraud_pair_matches" reload:"live"`
+	FraudMinRounds        int                   `toml:"fraud_min_rounds" reload:"live"`
+	FraudLossRatio        float64               `toml:"fraud_loss_ratio" reload:"live"`
+	FraudFastMoveMillis   int                   `toml:"fraud_fast_move_millis" reload:"live"`
+	FraudFastMoves        int                   `toml:"fraud_fast_moves" reload:"live"`
+	FraudBlockKinds       []string              `toml:"fraud_block_kinds" reload:"live"`
+}
+
+func newConfig() *Config {
+	cfg := &Config{}
+	cfg.ServerName = "fingerplay"
+	cfg.LogLevel = "debug"
+	cfg.LogFormat = LogFormatJSON
+	cfg.StatisticsSpoolDir = "data/statistics"
+	cfg.HistorySpoolDir = "data/history"
+	cfg.StoreDriver = StoreDriverMongo
+	cfg.ShutdownTimeoutSecond = 30
+	cfg.TraceExporter = TraceExporterNone
+	cfg.TraceSampleRatio = 1
+	cfg.SignWindowSecond = 300
+	cfg.TokenCacheSecond = 60
+	cfg.RequireSessionTicket = true
+	cfg.RateLimitStore = RateLimitStoreLocal
+	cfg.FraudWindowSecond = 86400
+	cfg.FraudPairMatches = 20
+	cfg.FraudMinRounds = 20
+	cfg.FraudLossRatio = 0.8
+	cfg.FraudFastMoveMillis = 250
+	cfg.FraudFastMoves = 10
+	return cfg
+}
+
+// 一份生效的配置，热更新时整体替换。每个Server持有自己的一份，
+// 单独创建的组件使用进程级别的一份
+type ConfigValue struct {
+	v atomic.Value
+}
+
+func NewConfigValue(cfg *Config) *ConfigValue {
+	cv := &ConfigValue{}
+	cv.v.Store(cfg)
+	return cv
+}
+
+// 调用者不要修改返回值
+func (cv *ConfigValue) Get() *Config {
+	return cv.v.Load().(*Config)
+}
+
+func (cv *ConfigValue) Set(cfg *Config) {
+	cv.v.Store(cfg)
+}
+
+// 进程级别的配置，由-c指定的文件加载，日志、tracing和main使用
+func GetConf() *Config {
+	return confValue.Get()
 }
 
 func (cfg *Config) JSON() []byte {
-	v, _ := json.Marshal(cfg)
+	v, _ := json.Marshal(cfg.Redacted())
 	return v
 }
 
@@ -60,18 +157,140 @@ func (d *duration) UnmarshalText(text []byte) error {
 	return err
 }
 
+// 依次使用默认值、配置文件、环境变量和命令行参数，file为空时不读取配置文件
+func loadConfig(file string) (cfg *Config, err error) {
+	var (
+		v  []byte
+		md toml.MetaData
+	)
+
+	cfg = newConfig()
+	ce := &ConfigError{File: file}
+
+	if file != "" {
+		if v, err = ioutil.ReadFile(file); err != nil {
+			return
+		}
+
+		if md, err = toml.Decode(string(v), cfg); err != nil {
+			return nil, &ConfigError{File: file, Errors: []*ConfigFieldError{{Reason: err.Error()}}}
+		}
+
+		warned := make(map[string]bool)
+		for _, key := range md.Undecoded() {
+			if reason, ok := deprecatedConfigKeys[key[0]]; ok {
+				if !warned[key[0]] {
+					warned[key[0]] = true
+					log.Warn("Config %s: %s is deprecated and ignored, %s", file, key[0], reason)
+				}
+				continue
+			}
+			ce.add(key.String(), "unknown key")
+		}
+	}
+
+	cfg.applyOverrides(ce)
+	cfg.validate(ce)
+
+	if len(ce.Errors) > 0 {
+		return nil, ce
+	}
+	return
+}
+
 func InitConfig() (err error) {
 	var (
-		v []byte
+		cfg *Config
 	)
 
-	if v, err = ioutil.ReadFile(confFile); err != nil {
+	if cfg, err = loadConfig(confFile); err != nil {
 		return
 	}
 
-	_, err = toml.Decode(string(v), Conf)
+	confValue.Set(cfg)
+
+	if err = InitLog(cfg.LogFormat, cfg.LogLevel); err != nil {
+		return
+	}
+
+	Debug = cfg.Debug
+	log.Info("Conf: %s", cfg.JSON())
+	return
+}
+
+// 一个配置项的变化
+type ConfigChange struct {
+	Field string
+	Live  bool
+	Old   interface{}
+	New   interface{}
+
+	index int
+}
+
+func (ch *ConfigChange) String() string {
+	o, _ := json.Marshal(ch.Old)
+	n, _ := json.Marshal(ch.New)
+	return fmt.Sprintf("%s: %s -> %s", ch.Field, o, n)
+}
+
+func diffConfig(old, cur *Config) (changes []*ConfigChange) {
+	ov := reflect.ValueOf(old).Elem()
+	cv := reflect.ValueOf(cur).Elem()
+	rov := reflect.ValueOf(old.Redacted()).Elem()
+	rcv := reflect.ValueOf(cur.Redacted()).Elem()
+	t := ov.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("toml")
+		if name == "-" {
+			continue
+		}
+		if reflect.DeepEqual(ov.Field(i).Interface(), cv.Field(i).Interface()) {
+			continue
+		}
+		changes = append(changes, &ConfigChange{
+			Field: name,
+			Live:  f.Tag.Get("reload") == "live",
+			Old:   rov.Field(i).Interface(),
+			New:   rcv.Field(i).Interface(),
+			index: i,
+		})
+	}
+	return
+}
+
+// 重新读取配置文件并替换当前配置，不能热更新的字段保留原值。
+// 返回生效的新配置，没有变化时返回nil，由调用方通知各个Server。
+func ReloadConfig() (cfg *Config, err error) {
+	confMux.Lock()
+	defer confMux.Unlock()
+
+	if cfg, err = loadConfig(confFile); err != nil {
+		return nil, err
+	}
+
+	old := GetConf()
+	changes := diffConfig(old, cfg)
+	if len(changes) == 0 {
+		log.Info("Reload: nothing changed")
+		return nil, nil
+	}
+
+	cv := reflect.ValueOf(cfg).Elem()
+	ov := reflect.ValueOf(old).Elem()
+	for _, ch := range changes {
+		if ch.Live {
+			log.Info("Reload: %s", ch)
+			continue
+		}
+		log.Warn("Reload: %s requires restart, ignored", ch)
+		cv.Field(ch.index).Set(ov.Field(ch.index))
+	}
 
-	Debug = Conf.Debug
-	log.Info("Conf: %s", Conf.JSON())
+	confValue.Set(cfg)
+	Debug = cfg.Debug
+	SetLogLevel(cfg.LogLevel)
 	return
 }
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// 环境变量名为前缀加上大写的toml键名，例如FINGERPLAY_HTTP_BIND_ADDR；
+	// 加上_FILE后缀时从文件读取，用于容器中挂载的密钥
+	ConfigEnvPrefix = "FINGERPLAY_"
+	ConfigEnvFile   = "_FILE"
+
+	ConfigRedacted = "******"
+)
+
+var (
+	// 命令行中出现的配置项，键为toml键名
+	confFlags = make(map[string]string)
+)
+
+type confFlag struct {
+	name  string
+	value string
+}
+
+func (f *confFlag) String() string { return f.value }
+
+func (f *confFlag) Set(v string) error {
+	f.value = v
+	confFlags[f.name] = v
+	return nil
+}
+
+// 每个配置项都可以用同名的命令行参数覆盖，例如 -http_bind_addr=:8080 -levels=100,1000
+func registerConfigFlags() {
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("toml")
+		if name == "-" {
+			continue
+		}
+		flag.Var(&confFlag{name: name}, name, fmt.Sprintf("override %s in the config file (env %s)", name, configEnvName(name)))
+	}
+}
+
+func configEnvName(name string) string {
+	return ConfigEnvPrefix + strings.ToUpper(name)
+}
+
+// 依次用环境变量和命令行参数覆盖配置文件中的值
+func (cfg *Config) applyOverrides(ce *ConfigError) {
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("toml")
+		if name == "-" {
+			continue
+		}
+
+		if s, source, ok, err := lookupConfigEnv(name); err != nil {
+			ce.add(name, "%s: %s", source, err)
+		} else if ok {
+			if err := setConfigField(v.Field(i), s); err != nil {
+				ce.add(name, "bad value from %s: %s", source, err)
+			}
+		}
+
+		if s, ok := confFlags[name]; ok {
+			if err := setConfigField(v.Field(i), s); err != nil {
+				ce.add(name, "bad value from -%s: %s", name, err)
+			}
+		}
+	}
+}
+
+func lookupConfigEnv(name string) (value, source string, ok bool, err error) {
+	env := configEnvName(name)
+
+	if path := os.Getenv(env + ConfigEnvFile); path != "" {
+		var (
+			b []byte
+		)
+
+		source = env + ConfigEnvFile
+		if b, err = ioutil.ReadFile(path); err != nil {
+			return
+		}
+		return strings.TrimSpace(string(b)), source, true, nil
+	}
+
+	value, ok = os.LookupEnv(env)
+	return value, env, ok, nil
+}
+
+// 列表用逗号分隔，map用k=v,k=v，以[或{开头时按json解析
+func setConfigField(fv reflect.Value, s string) (err error) {
+	if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Map {
+		if s = strings.TrimSpace(s); strings.HasPrefix(s, "[") || strings.HasPrefix(s, "{") {
+			return json.Unmarshal([]byte(s), fv.Addr().Interface())
+		}
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			fv.SetBool(b)
+		}
+	case reflect.Int, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(s, 10, 64); err == nil {
+			fv.SetInt(n)
+		}
+	case reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(s, 64); err == nil {
+			fv.SetFloat(f)
+		}
+	case reflect.Slice:
+		items := []string{}
+		if s != "" {
+			items = strings.Split(s, ",")
+		}
+		slice := reflect.MakeSlice(fv.Type(), len(items), len(items))
+		for i, item := range items {
+			if err = setConfigField(slice.Index(i), strings.TrimSpace(item)); err != nil {
+				return
+			}
+		}
+		fv.Set(slice)
+	case reflect.Map:
+		m := reflect.MakeMap(fv.Type())
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			kv := strings.SplitN(item, "=", 2)
+			if len(kv) != 2 {
+				return fmt.Errorf("expect key=value, got %q", item)
+			}
+			val := reflect.New(fv.Type().Elem()).Elem()
+			if err = setConfigField(val, strings.TrimSpace(kv[1])); err != nil {
+				return
+			}
+			m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(kv[0])), val)
+		}
+		fv.Set(m)
+	default:
+		err = fmt.Errorf("unsupported type %s, use json", fv.Type())
+	}
+	return
+}
+
+// 返回隐藏了密钥的副本，用于日志和-print-config
+func (cfg *Config) Redacted() *Config {
+	_cfg := *cfg
+	v := reflect.ValueOf(&_cfg).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("secret") == "true" && v.Field(i).String() != "" {
+			v.Field(i).SetString(ConfigRedacted)
+		}
+	}
+	return &_cfg
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 与最早的部署相同的配置文件，当时必须配置10条fake_ranking
+const baselineConfig = `
+debug = false
+http_bind_addr = ":8080"
+levels = [100, 1000, 10000]
+operate_timeout_second = 10
+match_wait_second = 5
+endpoint_describe_user = "http://wallet.local/describe_user"
+endpoint_transfer = "http://wallet.local/transfer"
+endpoint_login_ai = "http://wallet.local/login_ai"
+robot_uid = 1000
+robot_fb_open_id = "robot"
+robot_lifetime_second = 300
+mongo_server_addrs = "127.0.0.1:27017"
+mongo_db = "fingerplay"
+avatar_num = 10
+avatar_url_template = "https://cdn.local/avatar/%d.png"
+nicknames = ["Alice", "Bob"]
+max_robot_uid = 2000
+base_online_numbers = [10, 10, 10, 10, 10, 10, 20, 20, 20, 20, 20, 20, 30, 30, 30, 30, 30, 30, 40, 40, 40, 40, 40, 40]
+
+[[robot]]
+nickname = "Robot"
+avatar = "https://cdn.local/robot.png"
+`
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "fingerplay.toml")
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadBaselineConfig(t *testing.T) {
+	content := baselineConfig
+	for i := 0; i < 10; i++ {
+		content += "\n[[fake_ranking]]\navatar = \"a\"\nwin_amount = 100.0\nnickname = \"n\"\ntime_updated = 0\n"
+	}
+
+	cfg, err := loadConfig(writeConfig(t, content))
+	if err != nil {
+		t.Fatalf("loadConfig() failed: %s", err)
+	}
+	if cfg.MongoDb != "fingerplay" || len(cfg.Levels) != 3 || len(cfg.Robots) != 1 {
+		t.Errorf("loadConfig() = %s", cfg.JSON())
+	}
+	if !cfg.RequireSessionTicket {
+		t.Errorf("require_session_ticket should be on unless turned off")
+	}
+}
+
+func TestLoadConfigUnknownKey(t *testing.T) {
+	_, err := loadConfig(writeConfig(t, "match_wait_secnd = 3\n"+baselineConfig))
+	if err == nil || !strings.Contains(err.Error(), "match_wait_secnd: unknown key") {
+		t.Errorf("loadConfig() err = %v, want unknown key", err)
+	}
+}
+
+func TestRedisAddrEmbeddedRejected(t *testing.T) {
+	_, err := loadConfig(writeConfig(t, "redis_addr = \"embedded\"\n"+baselineConfig))
+	if err == nil || !strings.Contains(err.Error(), "redis_addr: bad address") {
+		t.Errorf("loadConfig() err = %v, want redis_addr rejected", err)
+	}
+}
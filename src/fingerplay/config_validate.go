@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// 单个配置项的错误，Field为toml中的键名
+type ConfigFieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ConfigFieldError) Error() string {
+	if e.Field == "" {
+		return e.Reason
+	}
+	return e.Field + ": " + e.Reason
+}
+
+// 配置文件的所有错误，一次列出，避免改一个错再发现下一个
+type ConfigError struct {
+	File   string
+	Errors []*ConfigFieldError
+}
+
+func (e *ConfigError) Error() string {
+	lines := []string{fmt.Sprintf("bad config %s (%d errors):", e.File, len(e.Errors))}
+	for _, fe := range e.Errors {
+		lines = append(lines, "  "+fe.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *ConfigError) add(field, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, &ConfigFieldError{Field: field, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (cfg *Config) Validate() (err error) {
+	ce := &ConfigError{File: confFile}
+	cfg.validate(ce)
+	if len(ce.Errors) > 0 {
+		return ce
+	}
+	return
+}
+
+func (cfg *Config) validate(ce *ConfigError) {
+	if _, ok := logLevels[cfg.LogLevel]; !ok {
+		ce.add("log_level", "must be one of debug, info, warn, error, got %q", cfg.LogLevel)
+	}
+	if cfg.LogFormat != LogFormatJSON && cfg.LogFormat != LogFormatText {
+		ce.add("log_format", "must be json or text, got %q", cfg.LogFormat)
+	}
+
+	levels := make(map[int]bool)
+	if len(cfg.Levels) == 0 {
+		ce.add("levels", "must not be empty")
+	}
+	for _, lv := range cfg.Levels {
+		if lv <= 0 {
+			ce.add("levels", "level %d must be positive", lv)
+		} else if levels[lv] {
+			ce.add("levels", "level %d is duplicated", lv)
+		}
+		levels[lv] = true
+	}
+
+	for k, cost := range cfg.LevelCosts {
+		lv, err := strconv.Atoi(k)
+		if err != nil || !levels[lv] {
+			ce.add("level_costs."+k, "not one of levels")
+		} else if cost < 0 || cost >= float64(lv) {
+			ce.add("level_costs."+k, "cost %v must be in [0, %d)", cost, lv)
+		}
+	}
+
+	if cfg.OperateTimeoutSecond <= 0 {
+		ce.add("operate_timeout_second", "must be positive, got %d", cfg.OperateTimeoutSecond)
+	}
+	if cfg.MatchWaitSecond < 0 {
+		ce.add("match_wait_second", "must not be negative, got %d", cfg.MatchWaitSecond)
+	}
+	if cfg.ShutdownTimeoutSecond <= 0 {
+		ce.add("shutdown_timeout_second", "must be positive, got %d", cfg.ShutdownTimeoutSecond)
+	}
+
+	validateAddr(ce, "http_bind_addr", cfg.HttpBindAddr)
+	validateUrl(ce, "endpoint_describe_user", cfg.EndpointDescribeUser)
+	validateUrl(ce, "endpoint_transfer", cfg.EndpointTransfer)
+	validateUrl(ce, "endpoint_login_ai", cfg.EndpointLoginAI)
+
+	if cfg.RobotLifetimeSecond <= 0 {
+		ce.add("robot_lifetime_second", "must be positive, got %d", cfg.RobotLifetimeSecond)
+	}
+	if cfg.MaxRobotUid < 0 {
+		ce.add("max_robot_uid", "must not be negative, got %d", cfg.MaxRobotUid)
+	}
+
+	// robot.nextRobotAvatar按数量取模
+	if len(cfg.Robots) == 0 {
+		ce.add("robot", "at least one [[robot]] is required")
+	}
+	for i, r := range cfg.Robots {
+		if r.Nickname == "" {
+			ce.add(fmt.Sprintf("robot[%d].nickname", i), "must not be empty")
+		}
+	}
+
+	// getAvatarByOpenId按数量取模
+	if cfg.AvatarNum <= 0 {
+		ce.add("avatar_num", "must be positive, got %d", cfg.AvatarNum)
+	}
+	if !strings.Contains(cfg.AvatarUrlTemplate, "%d") {
+		ce.add("avatar_url_template", "must contain %%d, got %q", cfg.AvatarUrlTemplate)
+	}
+
+	// onlineNumber按小时取下标
+	if len(cfg.BaseOnlineNumbers) != 24 {
+		ce.add("base_online_numbers", "need 24 entries (one per hour), got %d", len(cfg.BaseOnlineNumbers))
+	}
+
+	switch cfg.StoreDriver {
+	case "", StoreDriverMongo:
+		if cfg.MongoServerAddrs == "" {
+			ce.add("mongo_server_addrs", "required by store_driver %q", cfg.StoreDriver)
+		}
+		if cfg.MongoDb == "" {
+			ce.add("mongo_db", "required by store_driver %q", cfg.StoreDriver)
+		}
+	case StoreDriverSqlite, StoreDriverMysql:
+		if cfg.StoreDsn == "" {
+			ce.add("store_dsn", "required by store_driver %q", cfg.StoreDriver)
+		}
+	case StoreDriverMemory:
+	default:
+		ce.add("store_driver", "unknown driver %q", cfg.StoreDriver)
+	}
+
+	if cfg.StatisticsSpoolDir == "" {
+		ce.add("statistics_spool_dir", "must not be empty")
+	}
+	if cfg.HistorySpoolDir == "" {
+		ce.add("history_spool_dir", "must not be empty")
+	}
+
+	// 所有节点共享同一个redis，不支持进程内的替代品
+	if cfg.RedisAddr != "" {
+		validateAddr(ce, "redis_addr", cfg.RedisAddr)
+	}
+	if cfg.AdvertiseAddr != "" {
+		validateUrl(ce, "advertise_addr", cfg.AdvertiseAddr)
+	}
+
+	switch cfg.TraceExporter {
+	case "", TraceExporterNone:
+	case TraceExporterOTLP:
+		if cfg.TraceEndpoint != "" {
+			validateUrl(ce, "trace_endpoint", cfg.TraceEndpoint)
+		}
+	default:
+		ce.add("trace_exporter", "must be none or otlp, got %q", cfg.TraceExporter)
+	}
+	if cfg.TraceSampleRatio < 0 || cfg.TraceSampleRatio > 1 {
+		ce.add("trace_sample_ratio", "must be between 0 and 1, got %g", cfg.TraceSampleRatio)
+	}
+
+	if cfg.AdminBindAddr != "" {
+		validateAddr(ce, "admin_bind_addr", cfg.AdminBindAddr)
+		if len(cfg.AdminToken) < 16 {
+			ce.add("admin_token", "required by admin_bind_addr, at least 16 characters")
+		}
+		if cfg.AdminBindAddr == cfg.HttpBindAddr {
+			ce.add("admin_bind_addr", "must differ from http_bind_addr")
+		}
+	}
+
+	// 管理接口和事件流使用admin_token认证
+	if cfg.GrpcBindAddr != "" {
+		validateAddr(ce, "grpc_bind_addr", cfg.GrpcBindAddr)
+		if len(cfg.AdminToken) < 16 {
+			ce.add("admin_token", "required by grpc_bind_addr, at least 16 characters")
+		}
+		if cfg.GrpcBindAddr == cfg.HttpBindAddr || cfg.GrpcBindAddr == cfg.AdminBindAddr {
+			ce.add("grpc_bind_addr", "must differ from http_bind_addr and admin_bind_addr")
+		}
+	}
+
+	// 客户端和服务端共用的密钥，太短容易被穷举
+	if cfg.SignSecret != "" && len(cfg.SignSecret) < 16 {
+		ce.add("sign_secret", "at least 16 characters")
+	}
+	if cfg.SignWindowSecond <= 0 {
+		ce.add("sign_window_second", "must be positive, got %d", cfg.SignWindowSecond)
+	}
+	if cfg.TokenCacheSecond < 0 {
+		ce.add("token_cache_second", "must not be negative, got %d", cfg.TokenCacheSecond)
+	}
+
+	for name, rl := range cfg.RateLimits {
+		if name != RateLimitDefault && routeNames[name] == nil {
+			ce.add("rate_limit."+name, "not a route name or %s", RateLimitDefault)
+			continue
+		}
+		if rl == nil {
+			ce.add("rate_limit."+name, "must not be empty")
+			continue
+		}
+		if rl.Rate < 0 || rl.IpRate < 0 {
+			ce.add("rate_limit."+name, "rate and ip_rate must not be negative")
+		}
+		// 桶的容量至少是1，否则一个请求都不能通过
+		if (rl.Rate > 0 && rl.Burst < 1) || (rl.IpRate > 0 && rl.IpBurst < 1) {
+			ce.add("rate_limit."+name, "burst and ip_burst must be at least 1 when the rate is set")
+		}
+	}
+	// 阈值为0时不检查对应的项，fraud_window_second为0时全部关闭。
+	// 阈值是单个节点上的计数，集群模式下对局分散在多个节点时要相应调低
+	if cfg.FraudWindowSecond < 0 {
+		ce.add("fraud_window_second", "must not be negative, got %d", cfg.FraudWindowSecond)
+	}
+	if cfg.FraudPairMatches < 0 {
+		ce.add("fraud_pair_matches", "must not be negative, got %d", cfg.FraudPairMatches)
+	}
+	if cfg.FraudMinRounds < 0 {
+		ce.add("fraud_min_rounds", "must not be negative, got %d", cfg.FraudMinRounds)
+	}
+	// 随机出拳时双方各输一半
+	if cfg.FraudLossRatio <= 0.5 || cfg.FraudLossRatio > 1 {
+		ce.add("fraud_loss_ratio", "must be in (0.5, 1], got %g", cfg.FraudLossRatio)
+	}
+	if cfg.FraudFastMoveMillis < 0 {
+		ce.add("fraud_fast_move_millis", "must not be negative, got %d", cfg.FraudFastMoveMillis)
+	}
+	if cfg.FraudFastMoves < 0 {
+		ce.add("fraud_fast_moves", "must not be negative, got %d", cfg.FraudFastMoves)
+	}
+	for _, kind := range cfg.FraudBlockKinds {
+		if !isFraudKind(kind) {
+			ce.add("fraud_block_kinds", "unknown kind %q, must be one of %s", kind, strings.Join(FraudKinds, ", "))
+		}
+	}
+
+	switch cfg.RateLimitStore {
+	case RateLimitStoreLocal:
+	case RateLimitStoreRedis:
+		if cfg.RedisAddr == "" {
+			ce.add("rate_limit_store", "redis requires redis_addr")
+		}
+	default:
+		ce.add("rate_limit_store", "must be local or redis, got %q", cfg.RateLimitStore)
+	}
+}
+
+func validateAddr(ce *ConfigError, field, addr string) {
+	if _, port, err := net.SplitHostPort(addr); err != nil {
+		ce.add(field, "bad address %q: %s", addr, err)
+	} else if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+		ce.add(field, "bad port in %q", addr)
+	}
+}
+
+func validateUrl(ce *ConfigError, field, s string) {
+	if u, err := url.Parse(s); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		// 不输出原值，其中可能带有密钥
+		ce.add(field, "must be an http(s) url")
+	}
+}
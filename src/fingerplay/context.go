@@ -9,31 +9,33 @@ import (
 )
 
 type Context struct {
-	//mm  *MysqlManager
-	//rm  *RedisManager
+	sqm *SqlManager
+	rm  *RedisManager
 	mgm *MongoManager
 }
 
-func NewContext(mgm *MongoManager) *Context {
+func NewContext() *Context {
 	ctx := &Context{}
-	ctx.mgm = mgm
 	return ctx
 }
 
 func (ctx *Context) GetMysqlSession() (db *sql.DB, err error) {
-	//return ctx.mm.GetSession()
-	return nil, nil
+	if ctx.sqm == nil {
+		return nil, ErrSqlNotConnected
+	}
+	return ctx.sqm.GetSession()
 }
 
 func (ctx *Context) GetRedisSession() (conn redis.Conn, err error) {
-	//return ctx.rm.GetSession()
-	return nil, nil
+	if ctx.rm == nil {
+		return nil, ErrRedisNotConnected
+	}
+	return ctx.rm.GetSession()
 }
 
 func (ctx *Context) GetMongoSession() (session *mgo.Session, err error) {
+	if ctx.mgm == nil {
+		return nil, ErrMongoNotConnected
+	}
 	return ctx.mgm.GetSession()
 }
-
-var (
-	DefaultContext *Context
-)
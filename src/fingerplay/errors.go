@@ -13,4 +13,24 @@ var (
 	ErrAccountStatus       = errors.New("bad account status")
 	ErrInsufficientBalance = errors.New("insufficient balance")
 	ErrResponseCodeNotOK   = errors.New("response code not ok")
+	ErrMongoNotConnected   = errors.New("mongodb not connected")
+	ErrLeaderboardWindow   = errors.New("bad leaderboard window")
+	ErrSqlNotConnected     = errors.New("sql database not connected")
+	ErrStoreDriver         = errors.New("bad store driver")
+	ErrRedisNotConnected   = errors.New("redis not connected")
+	ErrShutdownTimeout     = errors.New("shutdown timeout")
+	ErrNotInitialized      = errors.New("not initialized")
+	ErrCheckTimeout        = errors.New("check timeout")
+	ErrDraining            = errors.New("draining")
+	ErrLoopStale           = errors.New("match loop stalled")
+	ErrTraceExporter       = errors.New("bad trace exporter")
+	ErrUid                 = errors.New("bad uid")
+	ErrTokenRevoked        = errors.New("access token revoked")
+	ErrSessionTicket       = errors.New("bad session ticket")
+	ErrSignatureMissing    = errors.New("signature headers missing")
+	ErrSignatureExpired    = errors.New("signature timestamp out of window")
+	ErrSignatureMismatch   = errors.New("signature mismatch")
+	ErrNonceReplayed       = errors.New("nonce already used")
+	ErrFraudKind           = errors.New("bad fraud kind")
+	ErrNoSubscriber        = errors.New("no node subscribed")
 )
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 比赛事件的类型，与pb.MatchEvent_Type的取值一致
+const (
+	MatchEventCreated = iota + 1
+	MatchEventRoundSettled
+	MatchEventClosed
+)
+
+const (
+	// 每个订阅者的缓冲，处理不及时写满后丢弃新的事件，不会阻塞结算
+	EventSubscriberBuffer = 256
+)
+
+type MatchEventCompetitor struct {
+	Uid      int
+	Nickname string
+	Robot    bool
+	Operate  int
+	Win      float64
+	Balance  float64
+}
+
+// 本节点上比赛的创建、每回合的结算和结束。
+// RoundSettled的Code为结算结果，Result为第一个选手的胜负；Closed的Code为结束原因
+type MatchEvent struct {
+	Type        int
+	MatchId     string
+	Level       int
+	Round       int
+	Ts          int64
+	Code        int
+	Result      int
+	Competitors []*MatchEventCompetitor
+}
+
+// 零值的条件不过滤
+type EventFilter struct {
+	Level   int
+	Uid     int
+	MatchId string
+}
+
+func (f *EventFilter) match(ev *MatchEvent) bool {
+	if f.Level != 0 && f.Level != ev.Level {
+		return false
+	}
+	if f.MatchId != "" && f.MatchId != ev.MatchId {
+		return false
+	}
+	if f.Uid != 0 {
+		for _, cp := range ev.Competitors {
+			if cp.Uid == f.Uid {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+type EventSubscriber struct {
+	C       chan *MatchEvent
+	filter  EventFilter
+	dropped int64
+}
+
+// 因为缓冲已满被丢弃的事件数
+func (sub *EventSubscriber) Dropped() int64 {
+	return atomic.LoadInt64(&sub.dropped)
+}
+
+// 把比赛事件分发给订阅者，Publish不阻塞
+type EventHub struct {
+	mux         sync.RWMutex
+	subscribers map[*EventSubscriber]struct{}
+	dropped     int64
+}
+
+func NewEventHub() *EventHub {
+	hub := &EventHub{}
+	hub.subscribers = make(map[*EventSubscriber]struct{})
+	return hub
+}
+
+func (hub *EventHub) Subscribe(filter EventFilter, buffer int) *EventSubscriber {
+	sub := &EventSubscriber{C: make(chan *MatchEvent, buffer), filter: filter}
+	hub.mux.Lock()
+	hub.subscribers[sub] = struct{}{}
+	hub.mux.Unlock()
+	return sub
+}
+
+// 取消订阅并关闭sub.C
+func (hub *EventHub) Unsubscribe(sub *EventSubscriber) {
+	hub.mux.Lock()
+	if _, ok := hub.subscribers[sub]; ok {
+		delete(hub.subscribers, sub)
+		close(sub.C)
+	}
+	hub.mux.Unlock()
+}
+
+func (hub *EventHub) Len() (n int) {
+	hub.mux.RLock()
+	n = len(hub.subscribers)
+	hub.mux.RUnlock()
+	return
+}
+
+// 所有订阅者被丢弃的事件数
+func (hub *EventHub) Dropped() int64 {
+	return atomic.LoadInt64(&hub.dropped)
+}
+
+// hub为nil时忽略，没有配置gRPC时不需要创建
+func (hub *EventHub) Publish(ev *MatchEvent) {
+	if hub == nil {
+		return
+	}
+
+	hub.mux.RLock()
+	defer hub.mux.RUnlock()
+
+	for sub := range hub.subscribers {
+		if !sub.filter.match(ev) {
+			continue
+		}
+		select {
+		case sub.C <- ev:
+		default:
+			atomic.AddInt64(&sub.dropped, 1)
+			atomic.AddInt64(&hub.dropped, 1)
+		}
+	}
+}
+
+// 调用者需要持有ms.mux
+func (ms *MatchSession) event(typ int, code int, now time.Time) *MatchEvent {
+	ev := &MatchEvent{
+		Type:    typ,
+		MatchId: ms.MatchId,
+		Level:   ms.Level,
+		Round:   ms.Round,
+		Ts:      now.UnixNano() / 1000000,
+		Code:    code,
+	}
+	for _, cp := range ms.Competitors {
+		ev.Competitors = append(ev.Competitors, &MatchEventCompetitor{
+			Uid:      cp.uid,
+			Nickname: cp.Nickname,
+			Robot:    !cp.IsMan(),
+			Balance:  cp.Balance,
+		})
+	}
+	return ev
+}
+
+// 结束比赛并通知订阅者，已经结束的比赛不会重复通知
+func (impl *LogicImpl) closeMatch(ms *MatchSession, code int) {
+	if !ms.disposeWith(code) {
+		return
+	}
+
+	ms.mux.RLock()
+	ev := ms.event(MatchEventClosed, code, impl.clock.Now())
+	ms.mux.RUnlock()
+	impl.events.Publish(ev)
+}
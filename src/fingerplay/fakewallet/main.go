@@ -0,0 +1,68 @@
+// fakewallet在本地启动一个假的账户服务，实现见wallettest。
+//
+//	go build -o fakewallet ./fakewallet
+//	fakewallet -addr 127.0.0.1:8092 -balance 1000 -latency 20ms -jitter 30ms -error-rate 0.01
+//
+// fingerplay的配置：
+//
+//	endpoint_describe_user = "http://127.0.0.1:8092/describe_user"
+//	endpoint_transfer      = "http://127.0.0.1:8092/transfer"
+//
+// 查看余额：GET /users、GET /users?uid=；查看转账：GET /transfers?uid=；
+// 运行中修改故障注入：POST /faults {"latency_ms":100,"error_rate":0.1}
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"fingerplay/wallettest"
+)
+
+func main() {
+	var (
+		addr       = flag.String("addr", "127.0.0.1:8092", "listen address")
+		usersFile  = flag.String("users", "", "json file with seeded users: [{\"uid\":1,\"access_token\":\"t\",\"balance\":100}]")
+		balance    = flag.Float64("balance", wallettest.DefaultBalance, "balance of auto registered users")
+		noRegister = flag.Bool("no-auto-register", false, "reject access tokens that are not seeded")
+		latency    = flag.Duration("latency", 0, "latency added to every describe-user and transfer call")
+		jitter     = flag.Duration("jitter", 0, "random extra latency, up to this much")
+		errorRate  = flag.Float64("error-rate", 0, "probability of replying with an internal error code")
+		failRate   = flag.Float64("fail-rate", 0, "probability of replying with http 500")
+		seed       = flag.Int64("seed", time.Now().UnixNano(), "random seed for latency and errors")
+	)
+	flag.Parse()
+
+	opts := wallettest.Options{}
+	opts.Balance = *balance
+	opts.NoAutoRegister = *noRegister
+	opts.Seed = *seed
+	opts.Faults = wallettest.Faults{
+		LatencyMs: int(*latency / time.Millisecond),
+		JitterMs:  int(*jitter / time.Millisecond),
+		ErrorRate: *errorRate,
+		FailRate:  *failRate,
+	}
+
+	if *usersFile != "" {
+		v, err := ioutil.ReadFile(*usersFile)
+		if err == nil {
+			err = json.Unmarshal(v, &opts.Users)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "load users %s failed: %s\n", *usersFile, err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "fakewallet listen at %s, %d seeded users\n", *addr, len(opts.Users))
+	if err := http.ListenAndServe(*addr, wallettest.New(opts)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
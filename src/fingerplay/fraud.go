@@ -0,0 +1,511 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	log "code.google.com/p/log4go"
+)
+
+const (
+	// 同一对玩家在时间窗口内多次匹配到一起
+	FraudRepeatedPairing = "repeated_pairing"
+	// 有输赢的回合中一方输得太多，可能是在转移余额
+	FraudOneSidedLosses = "one_sided_losses"
+	// 同一场比赛的双方来自同一个ip或设备
+	FraudSharedIp     = "shared_ip"
+	FraudSharedDevice = "shared_device"
+	// 出拳快得不像是人在操作
+	FraudFastMoves = "fast_moves"
+
+	// 内存中保留的告警数，更早的只在日志中
+	FraudAlertKeep = 1000
+	// 清理不再对局的玩家的间隔
+	FraudSweepIntervalSecond = 60
+)
+
+var (
+	FraudKinds = []string{FraudRepeatedPairing, FraudOneSidedLosses, FraudSharedIp, FraudSharedDevice, FraudFastMoves}
+)
+
+type FraudAlert struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	// 小的uid在前
+	Uids    [2]int `json:"uids"`
+	Level   int    `json:"level"`
+	MatchId string `json:"match_id"`
+	Detail  string `json:"detail"`
+	// 发现告警的节点
+	Node string `json:"node,omitempty"`
+	Ts   int64  `json:"ts"`
+}
+
+// 一对玩家之间的对局汇总，从Uid的角度统计
+type FraudPairStats struct {
+	Uid             int      `json:"uid"`
+	CounterpartyUid int      `json:"counterparty_uid"`
+	Matches         int      `json:"matches"`
+	Rounds          int      `json:"rounds"`
+	Losses          int      `json:"losses"`
+	Net             float64  `json:"net"`
+	FirstTs         int64    `json:"first_ts"`
+	LastTs          int64    `json:"last_ts"`
+	Flags           []string `json:"flags"`
+}
+
+type fraudMatch struct {
+	matchId string
+	ts      int64
+}
+
+// 输的一方付出lost，赢的一方扣除抽成后得到won
+type fraudRound struct {
+	loser int
+	lost  float64
+	won   float64
+	ts    int64
+}
+
+type fraudMove struct {
+	uid int
+	ts  int64
+}
+
+// 时间窗口内一对玩家的比赛、有输赢的回合和过快的出拳
+type fraudPair struct {
+	uids    [2]int
+	matches []fraudMatch
+	rounds  []fraudRound
+	fast    []fraudMove
+	// 每种告警在时间窗口内只报一次，也用于拦截匹配
+	alerted map[string]int64
+	lastTs  int64
+}
+
+func (p *fraudPair) prune(since int64) {
+	i := 0
+	for i < len(p.matches) && p.matches[i].ts < since {
+		i++
+	}
+	p.matches = p.matches[i:]
+
+	i = 0
+	for i < len(p.rounds) && p.rounds[i].ts < since {
+		i++
+	}
+	p.rounds = p.rounds[i:]
+
+	i = 0
+	for i < len(p.fast) && p.fast[i].ts < since {
+		i++
+	}
+	p.fast = p.fast[i:]
+
+	for kind, ts := range p.alerted {
+		if ts < since {
+			delete(p.alerted, kind)
+		}
+	}
+}
+
+// FraudDetector根据本节点结算的对局发现串通和转移余额的玩家，只统计真人之间的比赛。
+// 统计在内存中，按fraud_window_second滑动；集群模式下告警广播到所有节点，
+// 所以每个节点都能拦截和查看所有的告警，但计数只包括本节点上的比赛。
+type FraudDetector struct {
+	cluster *Cluster
+	conf    *ConfigValue
+	clock   Clock
+	mux     sync.Mutex
+	pairs   map[[2]int]*fraudPair
+	alerts  []*FraudAlert
+	sweepTs int64
+}
+
+func NewFraudDetector() *FraudDetector {
+	fd := &FraudDetector{}
+	fd.conf = confValue
+	fd.clock = DefaultClock
+	fd.pairs = make(map[[2]int]*fraudPair)
+	return fd
+}
+
+func isFraudKind(kind string) bool {
+	for _, k := range FraudKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func fraudPairKey(uid1, uid2 int) [2]int {
+	if uid1 > uid2 {
+		uid1, uid2 = uid2, uid1
+	}
+	return [2]int{uid1, uid2}
+}
+
+// fraud_window_second为0时不检测
+func (fd *FraudDetector) enabled() bool {
+	return fd != nil && fd.conf.Get().FraudWindowSecond > 0
+}
+
+// 调用者需要持有fd.mux
+func (fd *FraudDetector) pair(uid1, uid2 int, now int64) *fraudPair {
+	if now-fd.sweepTs >= FraudSweepIntervalSecond {
+		fd.sweep(now)
+	}
+
+	key := fraudPairKey(uid1, uid2)
+	p := fd.pairs[key]
+	if p == nil {
+		p = &fraudPair{uids: key, alerted: make(map[string]int64)}
+		fd.pairs[key] = p
+	}
+	p.prune(now - int64(fd.conf.Get().FraudWindowSecond))
+	p.lastTs = now
+	return p
+}
+
+// 删除时间窗口内没有对局的玩家，调用者需要持有fd.mux
+func (fd *FraudDetector) sweep(now int64) {
+	fd.sweepTs = now
+	since := now - int64(fd.conf.Get().FraudWindowSecond)
+	for key, p := range fd.pairs {
+		if p.lastTs < since {
+			delete(fd.pairs, key)
+		}
+	}
+}
+
+// 比赛创建后调用，检查共用的ip、设备和重复的配对
+func (fd *FraudDetector) OnMatch(level int, matchId string, wd1, wd2 *WaitingData, now int64) {
+	if !fd.enabled() || !wd1.IsMan() || !wd2.IsMan() {
+		return
+	}
+
+	var (
+		alerts []*FraudAlert
+		conf   = fd.conf.Get()
+	)
+
+	fd.mux.Lock()
+	p := fd.pair(wd1.uid, wd2.uid, now)
+	p.matches = append(p.matches, fraudMatch{matchId, now})
+
+	if wd1.ip != "" && wd1.ip == wd2.ip {
+		alerts = fd.alert(alerts, p, FraudSharedIp, level, matchId, now, "both from ip %s", wd1.ip)
+	}
+	if wd1.deviceId != "" && wd1.deviceId == wd2.deviceId {
+		alerts = fd.alert(alerts, p, FraudSharedDevice, level, matchId, now, "both on device %s", wd1.deviceId)
+	}
+	if conf.FraudPairMatches > 0 && len(p.matches) >= conf.FraudPairMatches {
+		alerts = fd.alert(alerts, p, FraudRepeatedPairing, level, matchId, now, "%d matches in %ds", len(p.matches), conf.FraudWindowSecond)
+	}
+	fd.mux.Unlock()
+
+	fd.publish(alerts)
+}
+
+// 每个回合结算后调用，results为双方的结果，latencies为双方从回合开始到出拳的毫秒数，未知时为-1
+func (fd *FraudDetector) OnRound(level int, matchId string, uids [2]int, results [2]int, wins [2]float64, latencies [2]int64, now int64) {
+	var (
+		alerts []*FraudAlert
+		conf   = fd.conf.Get()
+	)
+
+	if !fd.enabled() || !conf.isMan(uids[0]) || !conf.isMan(uids[1]) {
+		return
+	}
+
+	fd.mux.Lock()
+	p := fd.pair(uids[0], uids[1], now)
+
+	for i, result := range results {
+		if result == Lost {
+			p.rounds = append(p.rounds, fraudRound{loser: uids[i], lost: -wins[i], won: wins[1-i], ts: now})
+		}
+	}
+	for i, latency := range latencies {
+		if latency >= 0 && latency < int64(conf.FraudFastMoveMillis) {
+			p.fast = append(p.fast, fraudMove{uid: uids[i], ts: now})
+		}
+	}
+
+	stats := p.stats(uids[0])
+	if stats.oneSided(conf) {
+		loser, losses := uids[0], stats.Losses
+		if stats.Losses*2 < stats.Rounds {
+			loser, losses = uids[1], stats.Rounds-stats.Losses
+		}
+		alerts = fd.alert(alerts, p, FraudOneSidedLosses, level, matchId, now, "uid %d lost %d of %d rounds, net %.2f", loser, losses, stats.Rounds, p.net(loser))
+	}
+
+	if conf.FraudFastMoves > 0 {
+		for _, uid := range uids {
+			n := 0
+			for _, m := range p.fast {
+				if m.uid == uid {
+					n++
+				}
+			}
+			if n >= conf.FraudFastMoves {
+				alerts = fd.alert(alerts, p, FraudFastMoves, level, matchId, now, "uid %d moved within %dms %d times", uid, conf.FraudFastMoveMillis, n)
+				break
+			}
+		}
+	}
+	fd.mux.Unlock()
+
+	fd.publish(alerts)
+}
+
+// 时间窗口内第一次出现时生成告警，调用者需要持有fd.mux
+func (fd *FraudDetector) alert(alerts []*FraudAlert, p *fraudPair, kind string, level int, matchId string, now int64, format string, args ...interface{}) []*FraudAlert {
+	if _, ok := p.alerted[kind]; ok {
+		return alerts
+	}
+	p.alerted[kind] = now
+
+	alert := &FraudAlert{}
+	alert.Id = GetGUID()
+	alert.Kind = kind
+	alert.Uids = p.uids
+	alert.Level = level
+	alert.MatchId = matchId
+	alert.Detail = fmt.Sprintf(format, args...)
+	alert.Ts = now
+	if fd.cluster.Enabled() {
+		alert.Node = fd.cluster.NodeId()
+	}
+	fd.keep(alert)
+	return append(alerts, alert)
+}
+
+// 调用者需要持有fd.mux
+func (fd *FraudDetector) keep(alert *FraudAlert) {
+	fd.alerts = append(fd.alerts, alert)
+	if len(fd.alerts) > FraudAlertKeep {
+		fd.alerts = fd.alerts[len(fd.alerts)-FraudAlertKeep:]
+	}
+}
+
+func (fd *FraudDetector) publish(alerts []*FraudAlert) {
+	for _, alert := range alerts {
+		observeFraudAlert(alert.Kind)
+		log.Warn("Fraud alert [%s] %d & %d at level %d, match %s: %s", alert.Kind, alert.Uids[0], alert.Uids[1], alert.Level, alert.MatchId, alert.Detail)
+		if fd.cluster.Enabled() {
+			fd.cluster.FraudAlert(alert)
+		}
+	}
+}
+
+// 其他节点发现的告警，本节点发出的已经记录过
+func (fd *FraudDetector) OnRemoteAlert(alert *FraudAlert) {
+	if !fd.enabled() || (fd.cluster.Enabled() && alert.Node == fd.cluster.NodeId()) {
+		return
+	}
+
+	fd.mux.Lock()
+	p := fd.pair(alert.Uids[0], alert.Uids[1], fd.clock.Now().Unix())
+	if _, ok := p.alerted[alert.Kind]; !ok {
+		p.alerted[alert.Kind] = alert.Ts
+	}
+	fd.keep(alert)
+	fd.mux.Unlock()
+}
+
+// 配置了fraud_block_kinds时，时间窗口内有这些告警的两个玩家不会被匹配到一起
+func (fd *FraudDetector) Blocked(uid1, uid2 int) (blocked bool) {
+	conf := fd.conf.Get()
+	if !fd.enabled() || len(conf.FraudBlockKinds) == 0 || uid1 == uid2 {
+		return
+	}
+
+	since := fd.clock.Now().Unix() - int64(conf.FraudWindowSecond)
+
+	fd.mux.Lock()
+	if p := fd.pairs[fraudPairKey(uid1, uid2)]; p != nil {
+		for _, kind := range conf.FraudBlockKinds {
+			if ts, ok := p.alerted[kind]; ok && ts >= since {
+				blocked = true
+				break
+			}
+		}
+	}
+	fd.mux.Unlock()
+
+	if blocked {
+		observeFraudBlocked()
+	}
+	return
+}
+
+// 排队的玩家，本节点的WaitingList和集群的共享队列都用pickOpponent选对手
+type fraudQueue interface {
+	Len() int
+	Uid(i int) int
+	Swap(i, j int)
+}
+
+// 把第一个玩家可以匹配的对手移到第二位，跳过被拦截的玩家，其他玩家保持顺序，没有时返回false
+func (fd *FraudDetector) pickOpponent(q fraudQueue) bool {
+	for j := 1; j < q.Len(); j++ {
+		if fd.Blocked(q.Uid(0), q.Uid(j)) {
+			continue
+		}
+		for ; j > 1; j-- {
+			q.Swap(j-1, j)
+		}
+		return true
+	}
+	return false
+}
+
+// 误报时清除两个玩家之间的统计和拦截，告警记录保留，返回是否有记录
+func (fd *FraudDetector) Clear(uid1, uid2 int) (cleared bool) {
+	if fd == nil {
+		return
+	}
+
+	key := fraudPairKey(uid1, uid2)
+	fd.mux.Lock()
+	_, cleared = fd.pairs[key]
+	delete(fd.pairs, key)
+	fd.mux.Unlock()
+	return
+}
+
+// 按时间倒序，uid和kind为空时不过滤
+func (fd *FraudDetector) Alerts(uid int, kind string, limit int) (alerts []*FraudAlert) {
+	alerts = []*FraudAlert{}
+	if fd == nil {
+		return
+	}
+
+	fd.mux.Lock()
+	defer fd.mux.Unlock()
+
+	for i := len(fd.alerts) - 1; i >= 0 && len(alerts) < limit; i-- {
+		alert := fd.alerts[i]
+		if uid > 0 && alert.Uids[0] != uid && alert.Uids[1] != uid {
+			continue
+		}
+		if kind != "" && alert.Kind != kind {
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	return
+}
+
+// 正在统计的玩家对数，用于监控
+func (fd *FraudDetector) Len() (n int) {
+	fd.mux.Lock()
+	n = len(fd.pairs)
+	fd.mux.Unlock()
+	return
+}
+
+// 调用者需要持有fd.mux
+func (p *fraudPair) stats(uid int) *FraudPairStats {
+	stats := &FraudPairStats{Uid: uid, CounterpartyUid: p.uids[0] + p.uids[1] - uid}
+	stats.Matches = len(p.matches)
+	stats.Rounds = len(p.rounds)
+	for _, r := range p.rounds {
+		if r.loser == uid {
+			stats.Losses++
+		}
+	}
+	stats.Net = p.net(uid)
+	return stats
+}
+
+// uid在时间窗口内的输赢，赢的一方扣除了抽成，所以双方的和不为0
+func (p *fraudPair) net(uid int) (net float64) {
+	for _, r := range p.rounds {
+		if r.loser == uid {
+			net -= r.lost
+		} else {
+			net += r.won
+		}
+	}
+	return
+}
+
+// 有足够的回合并且一方输掉的比例不低于fraud_loss_ratio
+func (stats *FraudPairStats) oneSided(conf *Config) bool {
+	if conf.FraudMinRounds <= 0 || stats.Rounds < conf.FraudMinRounds {
+		return false
+	}
+	losses := stats.Losses
+	if wins := stats.Rounds - losses; wins > losses {
+		losses = wins
+	}
+	return float64(losses) >= conf.FraudLossRatio*float64(stats.Rounds)
+}
+
+func (stats *FraudPairStats) flag(conf *Config) {
+	stats.Flags = []string{}
+	if conf.FraudPairMatches > 0 && stats.Matches >= conf.FraudPairMatches {
+		stats.Flags = append(stats.Flags, FraudRepeatedPairing)
+	}
+	if stats.oneSided(conf) {
+		stats.Flags = append(stats.Flags, FraudOneSidedLosses)
+	}
+}
+
+// 按uid的流水统计与每个真人对手的对局，用于检查内存中的时间窗口之外的历史。
+// 流水中没有平局、ip和出拳时间，所以只能发现重复配对和一边倒的输赢。
+func AnalyzeLedger(conf *Config, uid int, entries []*LedgerEntry) (pairs []*FraudPairStats) {
+	var (
+		index   = make(map[int]*FraudPairStats)
+		matches = make(map[int]map[string]bool)
+	)
+
+	pairs = []*FraudPairStats{}
+	for _, e := range entries {
+		if e.Status != LedgerStatusOK || !conf.isMan(e.CounterpartyUid) {
+			continue
+		}
+
+		stats := index[e.CounterpartyUid]
+		if stats == nil {
+			stats = &FraudPairStats{Uid: uid, CounterpartyUid: e.CounterpartyUid, FirstTs: e.Ts, LastTs: e.Ts}
+			index[e.CounterpartyUid] = stats
+			matches[e.CounterpartyUid] = make(map[string]bool)
+			pairs = append(pairs, stats)
+		}
+
+		if !matches[e.CounterpartyUid][e.MatchId] {
+			matches[e.CounterpartyUid][e.MatchId] = true
+			stats.Matches++
+		}
+		stats.Rounds++
+		if e.Amount < 0 {
+			stats.Losses++
+		}
+		stats.Net += e.Amount
+		if e.Ts < stats.FirstTs {
+			stats.FirstTs = e.Ts
+		}
+		if e.Ts > stats.LastTs {
+			stats.LastTs = e.Ts
+		}
+	}
+
+	for _, stats := range pairs {
+		stats.flag(conf)
+	}
+
+	// 有标记的在前，其次按回合数
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if len(pairs[i].Flags) != len(pairs[j].Flags) {
+			return len(pairs[i].Flags) > len(pairs[j].Flags)
+		}
+		return pairs[i].Rounds > pairs[j].Rounds
+	})
+	return
+}
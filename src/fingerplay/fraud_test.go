@@ -0,0 +1,167 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestFraudDetector(cfg *Config) (*FraudDetector, *ManualClock) {
+	fd := NewFraudDetector()
+	fd.conf = NewConfigValue(cfg)
+	clock := NewManualClock(time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local))
+	fd.clock = clock
+	return fd, clock
+}
+
+func newFraudWaiting(uid int, ip string) *WaitingData {
+	wd := NewWaitingData(uid, true, 1000, "", "", "", 0)
+	wd.ip = ip
+	return wd
+}
+
+func fraudKinds(alerts []*FraudAlert) (kinds []string) {
+	for _, alert := range alerts {
+		kinds = append(kinds, alert.Kind)
+	}
+	return
+}
+
+func TestFraudPairWindowExpires(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.FraudWindowSecond = 60
+	cfg.FraudPairMatches = 2
+	cfg.FraudBlockKinds = []string{FraudRepeatedPairing}
+	fd, clock := newTestFraudDetector(cfg)
+
+	match := func(matchId string) {
+		fd.OnMatch(100, matchId, newFraudWaiting(100001, ""), newFraudWaiting(100002, ""), clock.Now().Unix())
+	}
+
+	// 两场比赛相隔超过fraud_window_second，第一场已经移出窗口
+	match("m1")
+	clock.Advance(61 * time.Second)
+	match("m2")
+	if alerts := fd.Alerts(0, "", 10); len(alerts) != 0 {
+		t.Fatalf("alerts = %v, want none across windows", fraudKinds(alerts))
+	}
+
+	clock.Advance(30 * time.Second)
+	match("m3")
+	if alerts := fd.Alerts(0, FraudRepeatedPairing, 10); len(alerts) != 1 || alerts[0].Uids != [2]int{100001, 100002} {
+		t.Fatalf("alerts = %+v, want one repeated pairing within the window", alerts)
+	}
+	if !fd.Blocked(100002, 100001) {
+		t.Errorf("pair should be blocked within the window")
+	}
+
+	// 告警移出窗口后不再拦截，清理循环删除不再对局的玩家
+	clock.Advance(61 * time.Second)
+	if fd.Blocked(100001, 100002) {
+		t.Errorf("pair should not be blocked after the window")
+	}
+	clock.Advance(FraudSweepIntervalSecond * time.Second)
+	fd.OnMatch(100, "m4", newFraudWaiting(100003, ""), newFraudWaiting(100004, ""), clock.Now().Unix())
+	if n := fd.Len(); n != 1 {
+		t.Errorf("Len() = %d, want the expired pair swept", n)
+	}
+}
+
+func TestFraudOneSidedLossRatio(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.FraudMinRounds = 4
+	cfg.FraudLossRatio = 0.75
+	fd, clock := newTestFraudDetector(cfg)
+
+	// uids[0]的结果，平局不计入回合
+	round := func(uids [2]int, result int) {
+		results := [2]int{result, Draw}
+		wins := [2]float64{}
+		switch result {
+		case Won:
+			results[1], wins = Lost, [2]float64{90, -100}
+		case Lost:
+			results[1], wins = Won, [2]float64{-100, 90}
+		}
+		fd.OnRound(100, "m1", uids, results, wins, [2]int64{-1, -1}, clock.Now().Unix())
+		clock.Advance(time.Second)
+	}
+
+	// 100001输掉4个回合中的3个，第4个回合时达到fraud_min_rounds和fraud_loss_ratio
+	pair := [2]int{100001, 100002}
+	for _, result := range []int{Lost, Draw, Draw, Won, Lost} {
+		round(pair, result)
+	}
+	if alerts := fd.Alerts(0, "", 10); len(alerts) != 0 {
+		t.Fatalf("alerts = %v, want none below fraud_min_rounds", fraudKinds(alerts))
+	}
+	round(pair, Lost)
+	alerts := fd.Alerts(0, FraudOneSidedLosses, 10)
+	if len(alerts) != 1 || !strings.HasPrefix(alerts[0].Detail, "uid 100001 lost 3 of 4 rounds, net -210.00") {
+		t.Fatalf("alerts = %+v, want uid 100001 losing 3 of 4", alerts)
+	}
+
+	// 输赢各半的一对不告警；从另一方看是赢得多，同样算一边倒
+	for _, result := range []int{Won, Lost, Won, Lost} {
+		round([2]int{100003, 100004}, result)
+	}
+	for _, result := range []int{Won, Won, Won, Lost} {
+		round([2]int{100005, 100006}, result)
+	}
+	alerts = fd.Alerts(0, FraudOneSidedLosses, 10)
+	if len(alerts) != 2 || alerts[0].Uids != [2]int{100005, 100006} || !strings.HasPrefix(alerts[0].Detail, "uid 100006 lost 3 of 4") {
+		t.Errorf("alerts = %+v, want only the pair where 100006 lost 3 of 4", alerts)
+	}
+
+	// 机器人的回合不统计
+	for i := 0; i < 4; i++ {
+		round([2]int{100001, 1500}, Lost)
+	}
+	if n := len(fd.Alerts(1500, "", 10)); n != 0 {
+		t.Errorf("%d alerts with a robot, want none", n)
+	}
+}
+
+func TestFraudBlocksMatching(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.FraudBlockKinds = []string{FraudSharedIp}
+	fd, clock := newTestFraudDetector(cfg)
+
+	fd.OnMatch(100, "m1", newFraudWaiting(100001, "10.0.0.1"), newFraudWaiting(100002, "10.0.0.1"), clock.Now().Unix())
+	fd.OnMatch(100, "m2", newFraudWaiting(100001, "10.0.0.1"), newFraudWaiting(100003, "10.0.0.3"), clock.Now().Unix())
+
+	// 被拦截的100002排到100004之后，其他玩家的顺序不变
+	queue := waitingQueue{
+		newFraudWaiting(100001, ""), newFraudWaiting(100002, ""), newFraudWaiting(100004, ""), newFraudWaiting(100005, ""),
+	}
+	if !fd.pickOpponent(queue) {
+		t.Fatalf("pickOpponent() = false, want 100004")
+	}
+	var uids []int
+	for i := range queue {
+		uids = append(uids, queue.Uid(i))
+	}
+	if want := []int{100001, 100004, 100002, 100005}; !reflect.DeepEqual(uids, want) {
+		t.Errorf("queue = %v, want %v", uids, want)
+	}
+
+	// 只有被拦截的对手时没有人可以匹配
+	if fd.pickOpponent(waitingQueue{newFraudWaiting(100002, ""), newFraudWaiting(100001, "")}) {
+		t.Errorf("pickOpponent() = true with only a blocked opponent")
+	}
+
+	// 只拦截配置的告警类型
+	cfg.FraudBlockKinds = []string{FraudRepeatedPairing}
+	if fd.Blocked(100001, 100002) {
+		t.Errorf("pair blocked by a kind that is not in fraud_block_kinds")
+	}
+	cfg.FraudBlockKinds = []string{FraudSharedIp}
+
+	if !fd.Clear(100002, 100001) || fd.Blocked(100001, 100002) {
+		t.Errorf("pair still blocked after Clear()")
+	}
+	if n := len(fd.Alerts(100002, "", 10)); n != 1 {
+		t.Errorf("%d alerts after Clear(), want them kept", n)
+	}
+}
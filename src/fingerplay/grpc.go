@@ -0,0 +1,661 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"fingerplay/pb"
+
+	log "code.google.com/p/log4go"
+)
+
+const (
+	GrpcAdminServicePrefix = "/fingerplay.v1.FingerPlayAdmin/"
+	// 与http一样，调用方可以通过x-request-id带上关联id
+	GrpcMetadataRequestId     = "x-request-id"
+	GrpcMetadataAuthorization = "authorization"
+	GrpcMetadataRetryAfter    = "retry-after"
+)
+
+type grpcContextKey int
+
+const (
+	grpcKeyRequestId grpcContextKey = iota
+)
+
+// gRPC接口，与HttpApi调用同一个Logic。玩家接口的业务结果放在code里，
+// gRPC状态只用于请求不合法、比赛在其他节点上和认证失败；
+// 管理接口和事件流需要admin_token
+type GrpcApi struct {
+	bindAddr string
+	srv      *Server
+	server   *grpc.Server
+	// 关闭时结束所有的事件流，否则GracefulStop会一直等待
+	quit chan struct{}
+}
+
+func NewGrpcApi(bindAddr string, srv *Server) *GrpcApi {
+	api := &GrpcApi{}
+	api.bindAddr = bindAddr
+	api.srv = srv
+	api.quit = make(chan struct{})
+	api.server = grpc.NewServer(grpc.ChainUnaryInterceptor(api.unaryInterceptor), grpc.ChainStreamInterceptor(api.streamInterceptor))
+	pb.RegisterFingerPlayServer(api.server, &grpcFingerPlay{api: api})
+	pb.RegisterFingerPlayAdminServer(api.server, &grpcFingerPlayAdmin{api: api})
+	return api
+}
+
+// 监听失败时直接返回错误
+func (api *GrpcApi) Start() (err error) {
+	var l net.Listener
+	if l, err = net.Listen("tcp", api.bindAddr); err != nil {
+		return
+	}
+
+	go func() {
+		log.Info("grpc listen at %s", api.bindAddr)
+		if err := api.server.Serve(l); err != nil {
+			log.Error("grpc Serve(%q) failed: %s", api.bindAddr, err)
+		}
+	}()
+	return
+}
+
+func (api *GrpcApi) Shutdown(deadline time.Time) (err error) {
+	close(api.quit)
+
+	done := make(chan struct{})
+	go func() {
+		api.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Until(deadline)):
+		api.server.Stop()
+		err = ErrShutdownTimeout
+	}
+	return
+}
+
+func (api *GrpcApi) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	begin := time.Now()
+	ctx, requestId := withGrpcRequestId(ctx)
+
+	// 上游带有traceparent时接在上游的trace下
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	ctx, span := startSpan(ctx, info.FullMethod, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("rpc.system", "grpc"), attribute.String("fingerplay.request_id", requestId)))
+
+	admin := strings.HasPrefix(info.FullMethod, GrpcAdminServicePrefix)
+	if admin && !api.authorized(ctx) {
+		log.Warn("admin unauthorized %s from %s", info.FullMethod, grpcPeer(ctx))
+		err = status.Error(codes.Unauthenticated, "unauthorized")
+	} else {
+		resp, err = handler(ctx, req)
+		if admin {
+			log.Info("admin %s from %s: %v, err=%v", info.FullMethod, grpcPeer(ctx), req, err)
+		}
+	}
+
+	code := ResponseCodeOK
+	if r, ok := resp.(interface{ GetCode() int32 }); ok {
+		code = int(r.GetCode())
+	}
+	endSpan(span, code, err)
+	observeGrpc(info.FullMethod, status.Code(err).String(), begin)
+
+	NewLog().With("request_id", requestId).With("method", info.FullMethod).With("code", code).With("status", status.Code(err).String()).
+		Debug("%2fs %s", time.Now().Sub(begin).Seconds(), info.FullMethod)
+	return
+}
+
+// 目前只有事件流，只给后台服务使用
+func (api *GrpcApi) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	begin := time.Now()
+	if !api.authorized(ss.Context()) {
+		log.Warn("stream unauthorized %s from %s", info.FullMethod, grpcPeer(ss.Context()))
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+
+	log.Info("stream %s opened from %s", info.FullMethod, grpcPeer(ss.Context()))
+	err = handler(srv, ss)
+	log.Info("stream %s from %s closed after %s: %v", info.FullMethod, grpcPeer(ss.Context()), time.Since(begin), err)
+	return
+}
+
+func withGrpcRequestId(ctx context.Context) (context.Context, string) {
+	var requestId string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(GrpcMetadataRequestId); len(v) > 0 {
+			requestId = v[0]
+		}
+	}
+	if requestId == "" {
+		requestId = GetGUID()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(GrpcMetadataRequestId, requestId))
+	return context.WithValue(ctx, grpcKeyRequestId, requestId), requestId
+}
+
+func (api *GrpcApi) authorized(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	v := md.Get(GrpcMetadataAuthorization)
+	return len(v) > 0 && checkAdminToken(api.srv.conf.Get().AdminToken, v[0])
+}
+
+func grpcPeer(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return "-"
+}
+
+// 与http一样，配置了client_ip_header时从metadata中取
+func (api *GrpcApi) clientIp(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return clientIp(api.srv.conf.Get().ClientIpHeader, grpcPeer(ctx), metadataCarrier(md).Get)
+}
+
+// gRPC metadata的TextMapCarrier
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() (keys []string) {
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return
+}
+
+// 按路由表调用Logic，校验规则与v2的http接口相同
+func (api *GrpcApi) serve(ctx context.Context, name string, request, response interface{}) (err error) {
+	route := routeNames[name]
+
+	if t, ok := request.(interface{ SetRequestId(string) }); ok {
+		id, _ := ctx.Value(grpcKeyRequestId).(string)
+		t.SetRequestId(id)
+	}
+	if t, ok := request.(interface{ SetContext(context.Context) }); ok {
+		t.SetContext(ctx)
+	}
+
+	if err = validateRequest(request); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ip := api.clientIp(ctx)
+	if t, ok := request.(interface{ SetClientIp(string) }); ok {
+		t.SetClientIp(ip)
+	}
+
+	code, msg := responseFields(response)
+	if wait := api.srv.Limiter.Allow(route.Name, ip, requestAccessToken(request)); wait > 0 {
+		*code = ResponseCodeRateLimited
+		grpc.SetHeader(ctx, metadata.Pairs(GrpcMetadataRetryAfter, retryAfterSecond(wait)))
+	} else if err = route.call(api.srv.Logic, request, response); err != nil {
+		// gRPC调用方不经过http转发，需要到比赛所在的节点调用
+		if e, ok := err.(*RemoteMatchError); ok {
+			return status.Error(codes.FailedPrecondition, e.Error())
+		}
+		log.Error("Logic.%s failed: %s, request: %#v", route.Name, err, request)
+		err = nil
+	}
+
+	if msg != nil && *msg == "" && *code != ResponseCodeOK {
+		*msg = getCodeDescription(*code)
+	}
+	return
+}
+
+type grpcFingerPlay struct {
+	pb.UnimplementedFingerPlayServer
+	api *GrpcApi
+}
+
+func (g *grpcFingerPlay) Match(ctx context.Context, in *pb.MatchRequest) (out *pb.MatchResponse, err error) {
+	request := &MatchRequest{Level: int(in.Level), AccessToken: in.AccessToken, DeviceId: in.DeviceId}
+	response := &MatchResponse{}
+	if err = g.api.serve(ctx, "Match", request, response); err != nil {
+		return
+	}
+
+	data := &pb.MatchResponseData{
+		ServerTimestamp: response.Data.ServerTimestamp,
+		ExpireTimestamp: response.Data.ExpireTimestamp,
+		MatchId:         response.Data.MatchId,
+		Round:           int32(response.Data.Round),
+		TimeoutSecond:   int32(response.Data.TimeoutSecond),
+		SessionTicket:   response.Data.SessionTicket,
+	}
+	for _, cp := range response.Data.Competitors {
+		data.Competitors = append(data.Competitors, &pb.Competitor{AccessToken: cp.AccessToken, Balance: cp.Balance, Nickname: cp.Nickname, Avatar: cp.Avatar})
+	}
+	return &pb.MatchResponse{Code: int32(response.Code), Msg: response.Msg, Data: data}, nil
+}
+
+func (g *grpcFingerPlay) Ready(ctx context.Context, in *pb.ReadyRequest) (out *pb.ReadyResponse, err error) {
+	request := &ReadyRequest{Operate: int(in.Operate), MatchId: in.MatchId, Round: int(in.Round), AccessToken: in.AccessToken, SessionTicket: in.SessionTicket}
+	response := &ReadyResponse{}
+	if err = g.api.serve(ctx, "Ready", request, response); err != nil {
+		return
+	}
+
+	data := &pb.ReadyResponseData{
+		ServerTimestamp: response.Data.ServerTimestamp,
+		ExpireTimestamp: response.Data.ExpireTimestamp,
+		Round:           int32(response.Data.Round),
+	}
+	for _, r := range response.Data.Results {
+		data.Results = append(data.Results, &pb.Result{AccessToken: r.AccessToken, Operate: int32(r.Operate), Status: int32(r.Status), Balance: r.Balance, Win: r.Win})
+	}
+	return &pb.ReadyResponse{Code: int32(response.Code), Msg: response.Msg, Data: data}, nil
+}
+
+func (g *grpcFingerPlay) ReadyStatus(ctx context.Context, in *pb.ReadyStatusRequest) (out *pb.ReadyStatusResponse, err error) {
+	request := &ReadyStatusRequest{AccessToken: in.AccessToken, MatchId: in.MatchId, SessionTicket: in.SessionTicket}
+	response := &ReadyStatusResponse{}
+	if err = g.api.serve(ctx, "ReadyStatus", request, response); err != nil {
+		return
+	}
+	return &pb.ReadyStatusResponse{Code: int32(response.Code), Msg: response.Msg,
+		Data: &pb.ReadyStatusResponseData{Status: int32(response.Data.Status)}}, nil
+}
+
+func (g *grpcFingerPlay) Leave(ctx context.Context, in *pb.LeaveRequest) (out *pb.LeaveResponse, err error) {
+	request := &LeaveRequest{AccessToken: in.AccessToken, MatchId: in.MatchId, SessionTicket: in.SessionTicket}
+	response := &LeaveResponse{}
+	if err = g.api.serve(ctx, "Leave", request, response); err != nil {
+		return
+	}
+	return &pb.LeaveResponse{Code: int32(response.Code), Msg: response.Msg}, nil
+}
+
+func (g *grpcFingerPlay) Ranking(ctx context.Context, in *pb.RankingRequest) (out *pb.RankingResponse, err error) {
+	request := &RankingRequest{AccessToken: in.AccessToken}
+	response := &RankingResponse{}
+	if err = g.api.serve(ctx, "Ranking", request, response); err != nil {
+		return
+	}
+
+	data := &pb.RankingResponseData{}
+	for _, r := range response.Data.Results {
+		data.Results = append(data.Results, &pb.ResultLog{Avatar: r.Avatar, WinAmount: r.WinAmount, Nickname: r.Nickname, TimeUpdated: r.TimeUpdated})
+	}
+	return &pb.RankingResponse{Code: int32(response.Code), Msg: response.Msg, Data: data}, nil
+}
+
+func (g *grpcFingerPlay) OnlineNumber(ctx context.Context, in *pb.OnlineNumberRequest) (out *pb.OnlineNumberResponse, err error) {
+	request := &OnlineNumberRequest{}
+	response := &OnlineNumberResponse{}
+	if err = g.api.serve(ctx, "OnlineNumber", request, response); err != nil {
+		return
+	}
+
+	data := &pb.OnlineNumberResponseData{Number: int32(response.Data.Number)}
+	for _, room := range response.Data.Rooms {
+		data.Rooms = append(data.Rooms, &pb.Room{Level: int32(room.Level), Number: int32(room.Number)})
+	}
+	return &pb.OnlineNumberResponse{Code: int32(response.Code), Msg: response.Msg, Data: data}, nil
+}
+
+func (g *grpcFingerPlay) Stats(ctx context.Context, in *pb.StatsRequest) (out *pb.StatsResponse, err error) {
+	request := &StatsRequest{AccessToken: in.AccessToken, Uid: int(in.Uid)}
+	response := &StatsResponse{}
+	if err = g.api.serve(ctx, "Stats", request, response); err != nil {
+		return
+	}
+
+	d := &response.Data
+	data := &pb.StatsResponseData{
+		Uid:               int64(d.Uid),
+		Rounds:            int32(d.Rounds),
+		Wins:              int32(d.Wins),
+		Losses:            int32(d.Losses),
+		Draws:             int32(d.Draws),
+		WinRate:           d.WinRate,
+		Moves:             &pb.PlayerMoves{Stone: int32(d.Moves.Stone), Paper: int32(d.Moves.Paper), Scissors: int32(d.Moves.Scissors)},
+		FavouriteMove:     int32(d.FavouriteMove),
+		LongestWinStreak:  int32(d.LongestWinStreak),
+		LongestLossStreak: int32(d.LongestLossStreak),
+		Net:               d.Net,
+		LevelNet:          make(map[int32]float64, len(d.LevelNet)),
+		FirstPlayed:       d.FirstPlayed,
+		LastPlayed:        d.LastPlayed,
+	}
+	for lv, net := range d.LevelNet {
+		data.LevelNet[int32(lv)] = net
+	}
+	return &pb.StatsResponse{Code: int32(response.Code), Msg: response.Msg, Data: data}, nil
+}
+
+func (g *grpcFingerPlay) Leaderboard(ctx context.Context, in *pb.LeaderboardRequest) (out *pb.LeaderboardResponse, err error) {
+	request := &LeaderboardRequest{AccessToken: in.AccessToken, Window: in.Window, Level: int(in.Level), Period: in.Period,
+		Offset: int(in.Offset), Limit: int(in.Limit), Neighbours: int(in.Neighbours)}
+	response := &LeaderboardResponse{}
+	if err = g.api.serve(ctx, "Leaderboard", request, response); err != nil {
+		return
+	}
+
+	d := &response.Data
+	data := &pb.LeaderboardResponseData{
+		Window:     d.Window,
+		Period:     d.Period,
+		Level:      int32(d.Level),
+		Total:      int32(d.Total),
+		Entries:    pbLeaderboardEntries(d.Entries),
+		Neighbours: pbLeaderboardEntries(d.Neighbours),
+	}
+	if d.Me != nil {
+		data.Me = pbLeaderboardEntry(d.Me)
+	}
+	return &pb.LeaderboardResponse{Code: int32(response.Code), Msg: response.Msg, Data: data}, nil
+}
+
+func (g *grpcFingerPlay) WatchMatchEvents(in *pb.WatchMatchEventsRequest, stream pb.FingerPlay_WatchMatchEventsServer) error {
+	hub := g.api.srv.Events
+	sub := hub.Subscribe(EventFilter{Level: int(in.Level), Uid: int(in.Uid), MatchId: in.MatchId}, EventSubscriberBuffer)
+	defer hub.Unsubscribe(sub)
+
+	for {
+		select {
+		case ev := <-sub.C:
+			out := pbMatchEvent(ev)
+			out.Dropped = sub.Dropped()
+			if err := stream.Send(out); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-g.api.quit:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		}
+	}
+}
+
+func pbLeaderboardEntry(e *LeaderboardEntry) *pb.LeaderboardEntry {
+	return &pb.LeaderboardEntry{Rank: int32(e.Rank), Uid: int64(e.Uid), Avatar: e.Avatar, Nickname: e.Nickname, WinAmount: e.WinAmount, TimeUpdated: e.TimeUpdated}
+}
+
+func pbLeaderboardEntries(entries []*LeaderboardEntry) (out []*pb.LeaderboardEntry) {
+	for _, e := range entries {
+		out = append(out, pbLeaderboardEntry(e))
+	}
+	return
+}
+
+func pbMatchEvent(ev *MatchEvent) *pb.MatchEvent {
+	out := &pb.MatchEvent{
+		Type:    pb.MatchEvent_Type(ev.Type),
+		MatchId: ev.MatchId,
+		Level:   int32(ev.Level),
+		Round:   int32(ev.Round),
+		Ts:      ev.Ts,
+		Code:    int32(ev.Code),
+		Result:  int32(ev.Result),
+	}
+	for _, cp := range ev.Competitors {
+		out.Competitors = append(out.Competitors, &pb.MatchEventCompetitor{Uid: int64(cp.Uid), Nickname: cp.Nickname, Robot: cp.Robot,
+			Operate: int32(cp.Operate), Win: cp.Win, Balance: cp.Balance})
+	}
+	return out
+}
+
+type grpcFingerPlayAdmin struct {
+	pb.UnimplementedFingerPlayAdminServer
+	api *GrpcApi
+}
+
+// 与http管理接口的错误对应
+func adminStatus(err error) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *RemoteMatchError:
+		return status.Error(codes.FailedPrecondition, e.Error())
+	}
+	switch err {
+	case ErrUid, ErrAccessToken, ErrFraudKind:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case ErrMatchId, ErrLevel:
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func (g *grpcFingerPlayAdmin) ListWaiting(ctx context.Context, in *pb.AdminEmpty) (*pb.AdminWaitingLists, error) {
+	lists, err := g.api.srv.Logic.adminWaiting()
+	if err != nil {
+		return nil, adminStatus(err)
+	}
+
+	out := &pb.AdminWaitingLists{}
+	for _, l := range lists {
+		awl := &pb.AdminWaitingList{Level: int32(l.Level), Paused: l.Paused}
+		for _, w := range l.Waiting {
+			awl.Waiting = append(awl.Waiting, &pb.AdminWaiting{Uid: int64(w.Uid), Nickname: w.Nickname, Robot: w.Robot, Node: w.Node, Ts: w.Ts})
+		}
+		out.Lists = append(out.Lists, awl)
+	}
+	return out, nil
+}
+
+func (g *grpcFingerPlayAdmin) ListMatches(ctx context.Context, in *pb.AdminEmpty) (*pb.AdminMatches, error) {
+	out := &pb.AdminMatches{}
+	for _, m := range g.api.srv.Logic.adminMatches() {
+		out.Matches = append(out.Matches, pbAdminMatch(m))
+	}
+	return out, nil
+}
+
+func (g *grpcFingerPlayAdmin) GetMatch(ctx context.Context, in *pb.AdminMatchRequest) (*pb.AdminMatch, error) {
+	match, err := g.api.srv.Logic.adminMatch(in.MatchId)
+	if err != nil {
+		return nil, adminStatus(err)
+	}
+	return pbAdminMatch(match), nil
+}
+
+func (g *grpcFingerPlayAdmin) DisposeMatch(ctx context.Context, in *pb.AdminMatchRequest) (*pb.AdminDisposeResult, error) {
+	result, err := g.api.srv.Logic.ForceDispose(in.MatchId, in.Refund)
+	if err != nil {
+		return nil, adminStatus(err)
+	}
+
+	out := &pb.AdminDisposeResult{MatchId: result.MatchId}
+	for _, r := range result.Refunds {
+		out.Refunds = append(out.Refunds, &pb.AdminRefund{Round: int32(r.Round), FromUid: int64(r.FromUid), ToUid: int64(r.ToUid), Amount: r.Amount, Code: int32(r.Code)})
+	}
+	return out, nil
+}
+
+func (g *grpcFingerPlayAdmin) Kick(ctx context.Context, in *pb.AdminKickRequest) (*pb.AdminKickResult, error) {
+	return &pb.AdminKickResult{Kicked: int32(g.api.srv.Logic.Kick(int(in.Uid)))}, nil
+}
+
+func (g *grpcFingerPlayAdmin) RevokeToken(ctx context.Context, in *pb.AdminRevokeTokenRequest) (*pb.AdminKickResult, error) {
+	kicked, err := g.api.srv.Logic.RevokeToken(in.AccessToken)
+	if err != nil {
+		return nil, adminStatus(err)
+	}
+	return &pb.AdminKickResult{Kicked: int32(kicked)}, nil
+}
+
+func (g *grpcFingerPlayAdmin) PauseLevel(ctx context.Context, in *pb.AdminLevelRequest) (*pb.AdminEmpty, error) {
+	if err := g.api.srv.Logic.PauseLevel(int(in.Level), true); err != nil {
+		return nil, adminStatus(err)
+	}
+	return &pb.AdminEmpty{}, nil
+}
+
+func (g *grpcFingerPlayAdmin) ResumeLevel(ctx context.Context, in *pb.AdminLevelRequest) (*pb.AdminEmpty, error) {
+	if err := g.api.srv.Logic.PauseLevel(int(in.Level), false); err != nil {
+		return nil, adminStatus(err)
+	}
+	return &pb.AdminEmpty{}, nil
+}
+
+func (g *grpcFingerPlayAdmin) Robots(ctx context.Context, in *pb.AdminEmpty) (*pb.AdminRobotUsage, error) {
+	usage := g.api.srv.adminRobots()
+	out := &pb.AdminRobotUsage{
+		Idle:            int32(usage.Idle),
+		Playing:         make(map[int32]int32, len(usage.Playing)),
+		SessionsInUse:   int32(usage.SessionsInUse),
+		SessionsIdle:    int32(usage.SessionsIdle),
+		MaxRobotUid:     int64(usage.MaxRobotUid),
+		LifetimeSeconds: usage.LifetimeSeconds,
+	}
+	for lv, n := range usage.Playing {
+		out.Playing[int32(lv)] = int32(n)
+	}
+	return out, nil
+}
+
+func (g *grpcFingerPlayAdmin) Ledger(ctx context.Context, in *pb.AdminLedgerRequest) (*pb.AdminLedger, error) {
+	entries, err := g.api.srv.adminLedger(int(in.Uid), int(in.Offset), int(in.Limit))
+	if err != nil {
+		return nil, adminStatus(err)
+	}
+
+	out := &pb.AdminLedger{}
+	for _, e := range entries {
+		out.Entries = append(out.Entries, &pb.LedgerEntry{
+			MatchId:         e.MatchId,
+			Round:           int32(e.Round),
+			Level:           int32(e.Level),
+			Uid:             int64(e.Uid),
+			CounterpartyUid: int64(e.CounterpartyUid),
+			Amount:          e.Amount,
+			Cost:            e.Cost,
+			Balance:         e.Balance,
+			Status:          e.Status,
+			Ts:              e.Ts,
+		})
+	}
+	return out, nil
+}
+
+func (g *grpcFingerPlayAdmin) Drain(ctx context.Context, in *pb.AdminDrainRequest) (*pb.AdminDrainResult, error) {
+	result := g.api.srv.Logic.adminDrain(int(in.TimeoutSecond))
+	return &pb.AdminDrainResult{Draining: result.Draining, Matches: int32(result.Matches)}, nil
+}
+
+func (g *grpcFingerPlayAdmin) ConfigValidate(ctx context.Context, in *pb.AdminEmpty) (*pb.AdminConfigCheck, error) {
+	check := adminConfigCheck()
+	return &pb.AdminConfigCheck{File: check.File, Valid: check.Valid, Errors: check.Errors}, nil
+}
+
+func (g *grpcFingerPlayAdmin) LeaderboardRebuild(ctx context.Context, in *pb.AdminEmpty) (*pb.AdminLeaderboardRebuild, error) {
+	rebuild, err := g.api.srv.adminLeaderboardRebuild()
+	if err != nil {
+		return nil, adminStatus(err)
+	}
+	return &pb.AdminLeaderboardRebuild{Replayed: rebuild.Replayed, Archived: int32(rebuild.Archived), SpoolBytes: rebuild.SpoolBytes,
+		Entries: int32(rebuild.Entries), Rebuilt: int32(rebuild.Rebuilt)}, nil
+}
+
+func (g *grpcFingerPlayAdmin) FraudAlerts(ctx context.Context, in *pb.AdminFraudAlertsRequest) (*pb.AdminFraudAlerts, error) {
+	alerts, err := g.api.srv.adminFraudAlerts(int(in.Uid), in.Kind, int(in.Limit))
+	if err != nil {
+		return nil, adminStatus(err)
+	}
+
+	out := &pb.AdminFraudAlerts{}
+	for _, a := range alerts {
+		out.Alerts = append(out.Alerts, &pb.FraudAlert{
+			Id:      a.Id,
+			Kind:    a.Kind,
+			Uids:    []int64{int64(a.Uids[0]), int64(a.Uids[1])},
+			Level:   int32(a.Level),
+			MatchId: a.MatchId,
+			Detail:  a.Detail,
+			Node:    a.Node,
+			Ts:      a.Ts,
+		})
+	}
+	return out, nil
+}
+
+func (g *grpcFingerPlayAdmin) FraudPairs(ctx context.Context, in *pb.AdminFraudPairsRequest) (*pb.AdminFraudPairs, error) {
+	pairs, err := g.api.srv.adminFraudPairs(int(in.Uid), int(in.Limit))
+	if err != nil {
+		return nil, adminStatus(err)
+	}
+
+	out := &pb.AdminFraudPairs{}
+	for _, p := range pairs {
+		out.Pairs = append(out.Pairs, &pb.FraudPairStats{
+			Uid:             int64(p.Uid),
+			CounterpartyUid: int64(p.CounterpartyUid),
+			Matches:         int32(p.Matches),
+			Rounds:          int32(p.Rounds),
+			Losses:          int32(p.Losses),
+			Net:             p.Net,
+			FirstTs:         p.FirstTs,
+			LastTs:          p.LastTs,
+			Flags:           p.Flags,
+		})
+	}
+	return out, nil
+}
+
+func (g *grpcFingerPlayAdmin) FraudClear(ctx context.Context, in *pb.AdminFraudClearRequest) (*pb.AdminFraudClearResult, error) {
+	if len(in.Uids) != 2 {
+		return nil, adminStatus(ErrUid)
+	}
+	cleared, err := g.api.srv.ClearFraud(int(in.Uids[0]), int(in.Uids[1]))
+	if err != nil {
+		return nil, adminStatus(err)
+	}
+	return &pb.AdminFraudClearResult{Cleared: cleared}, nil
+}
+
+func pbAdminMatch(m *AdminMatch) *pb.AdminMatch {
+	out := &pb.AdminMatch{
+		MatchId:   m.MatchId,
+		Level:     int32(m.Level),
+		Round:     int32(m.Round),
+		Disposed:  m.Disposed,
+		CreatedTs: m.CreatedTs,
+	}
+	for _, cp := range m.Competitors {
+		out.Competitors = append(out.Competitors, &pb.AdminCompetitor{Uid: int64(cp.Uid), Nickname: cp.Nickname, Robot: cp.Robot,
+			Balance: cp.Balance, Status: int32(cp.Status), KeepAliveTs: cp.KeepAliveTs})
+	}
+	for _, r := range m.Rounds {
+		out.Rounds = append(out.Rounds, &pb.AdminSettledRound{
+			Round:    int32(r.Round),
+			Result:   int32(r.Result),
+			Operates: []int32{int32(r.Operates[0]), int32(r.Operates[1])},
+			Wins:     []float64{r.Wins[0], r.Wins[1]},
+			Code:     int32(r.Code),
+			Refunded: r.Refunded,
+			Ts:       r.Ts,
+		})
+	}
+	return out
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	HealthPath  = "/healthz"
+	ReadyPath   = "/readyz"
+	HealthOK    = "ok"
+	HealthNotOK = "unavailable"
+
+	// 单项检查的超时时间
+	ReadyCheckTimeout = 2 * time.Second
+	// 匹配和清理循环超过这个时间没有运行视为卡住
+	LoopStaleAfter = 5 * time.Second
+)
+
+type HealthCheck struct {
+	OK        bool    `json:"ok"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type HealthResponse struct {
+	Status string                  `json:"status"`
+	Checks map[string]*HealthCheck `json:"checks,omitempty"`
+}
+
+func (response *HealthResponse) JSON() []byte {
+	v, _ := json.Marshal(response)
+	return v
+}
+
+// 进程存活即可
+func handleHealthz(ctx *fasthttp.RequestCtx) {
+	response := &HealthResponse{Status: HealthOK}
+	ctx.SetContentType("application/json")
+	ctx.Write(response.JSON())
+}
+
+// 所有依赖可用时返回200，否则返回503，负载均衡据此摘除节点
+func (s *Server) handleReadyz(ctx *fasthttp.RequestCtx) {
+	response := &HealthResponse{Status: HealthOK}
+	response.Checks = runReadyChecks(s.readyChecks())
+
+	for _, check := range response.Checks {
+		if !check.OK {
+			response.Status = HealthNotOK
+			ctx.SetStatusCode(fasthttp.StatusServiceUnavailable)
+			break
+		}
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.Write(response.JSON())
+}
+
+func (s *Server) readyChecks() map[string]func() error {
+	checks := map[string]func() error{
+		"draining": s.checkDraining,
+		"loops":    s.checkLoops,
+		"store": func() error {
+			if s.Store == nil {
+				return ErrNotInitialized
+			}
+			return s.Store.Ping()
+		},
+		"wallet": func() error {
+			if s.Accounts == nil {
+				return ErrNotInitialized
+			}
+			return s.Accounts.Ping(ReadyCheckTimeout)
+		},
+	}
+
+	if s.Cluster.Enabled() {
+		checks["redis"] = func() (err error) {
+			_, err = s.Cluster.do("PING")
+			return
+		}
+	}
+	return checks
+}
+
+// 并发执行所有检查，超时的检查记为失败
+func runReadyChecks(checks map[string]func() error) map[string]*HealthCheck {
+	var (
+		mux     sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]*HealthCheck)
+	)
+
+	for name, fn := range checks {
+		wg.Add(1)
+		go func(name string, fn func() error) {
+			defer wg.Done()
+
+			begin := time.Now()
+			done := make(chan error, 1)
+			go func() { done <- fn() }()
+
+			var err error
+			select {
+			case err = <-done:
+			case <-time.After(ReadyCheckTimeout):
+				err = ErrCheckTimeout
+			}
+
+			check := &HealthCheck{OK: err == nil, LatencyMs: float64(time.Since(begin)) / float64(time.Millisecond)}
+			if err != nil {
+				check.Error = err.Error()
+			}
+
+			mux.Lock()
+			results[name] = check
+			mux.Unlock()
+		}(name, fn)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func (s *Server) checkDraining() error {
+	if s.Logic.isDraining() {
+		return ErrDraining
+	}
+	return nil
+}
+
+func (s *Server) checkLoops() error {
+	if !s.Logic.loopsAlive(s.Logic.clock.Now(), LoopStaleAfter) {
+		return ErrLoopStale
+	}
+	return nil
+}
+
+// 检查账户服务是否可以连接，只建立tcp连接，不发送请求
+func (am *AccountManager) Ping(timeout time.Duration) (err error) {
+	for _, endpoint := range []string{am.endpointDescribeUser, am.endpointTransfer} {
+		var (
+			u    *url.URL
+			conn net.Conn
+		)
+
+		if u, err = url.Parse(endpoint); err != nil {
+			return
+		}
+
+		host := u.Host
+		if u.Port() == "" {
+			if u.Scheme == "https" {
+				host = net.JoinHostPort(u.Hostname(), "443")
+			} else {
+				host = net.JoinHostPort(u.Hostname(), "80")
+			}
+		}
+
+		if conn, err = net.DialTimeout("tcp", host, timeout); err != nil {
+			return
+		}
+		conn.Close()
+	}
+	return
+}
@@ -49,6 +49,14 @@ func getCodeDescription(code int) string {
 		return "Insufficient balance"
 	case ResponseCodeKickOut:
 		return "Kick out"
+	case ResponseCodeBadSession:
+		return "Bad session"
+	case ResponseCodeMaintenance:
+		return "Maintenance"
+	case ResponseCodeBadSignature:
+		return "Bad signature"
+	case ResponseCodeRateLimited:
+		return "Too many requests"
 	default:
 		return "Undefined"
 	}
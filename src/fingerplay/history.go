@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+)
+
+const (
+	LedgerStatusOK     = "ok"
+	LedgerStatusFailed = "failed"
+	// 管理员结束比赛时的退款，不计入排行榜
+	LedgerStatusRefund       = "refund"
+	LedgerStatusRefundFailed = "refund_failed"
+)
+
+// 每次转账为双方各记一条流水，Amount为该玩家的余额变化
+type LedgerEntry struct {
+	MatchId         string  `bson:"match_id" json:"match_id"`
+	Round           int     `bson:"round" json:"round"`
+	Level           int     `bson:"level" json:"level"`
+	Uid             int     `bson:"uid" json:"uid"`
+	CounterpartyUid int     `bson:"counterparty_uid" json:"counterparty_uid"`
+	Amount          float64 `bson:"amount" json:"amount"`
+	Cost            float64 `bson:"cost" json:"cost"`
+	Balance         float64 `bson:"balance" json:"balance"`
+	Status          string  `bson:"status" json:"status"`
+	Ts              int64   `bson:"ts" json:"ts"`
+}
+
+// HistoryManager异步写入对局记录和转账流水，写入失败的批次经磁盘队列回放
+type HistoryManager struct {
+	matchStore  MatchHistoryStore
+	ledgerStore LedgerStore
+	rounds      *SpoolQueue
+	ledger      *SpoolQueue
+}
+
+func NewHistoryManager(matchStore MatchHistoryStore, ledgerStore LedgerStore, spoolDir string) *HistoryManager {
+	var (
+		err error
+	)
+
+	hm := &HistoryManager{}
+	hm.matchStore = matchStore
+	hm.ledgerStore = ledgerStore
+	if hm.rounds, err = NewSpoolQueue("rounds", filepath.Join(spoolDir, "rounds"), hm.appendRounds, decodeRoundLog); err != nil {
+		panic(err)
+	}
+	if hm.ledger, err = NewSpoolQueue("ledger entries", filepath.Join(spoolDir, "ledger"), hm.appendLedger, decodeLedgerEntry); err != nil {
+		panic(err)
+	}
+	hm.rounds.Start()
+	hm.ledger.Start()
+	return hm
+}
+
+func (hm *HistoryManager) appendRounds(items []interface{}) (n int, err error) {
+	rounds := make([]*RoundLog, len(items))
+	for i, item := range items {
+		rounds[i] = item.(*RoundLog)
+	}
+	if err = hm.matchStore.AppendRounds(rounds); err != nil {
+		return
+	}
+	return len(items), nil
+}
+
+func (hm *HistoryManager) appendLedger(items []interface{}) (n int, err error) {
+	entries := make([]*LedgerEntry, len(items))
+	for i, item := range items {
+		entries[i] = item.(*LedgerEntry)
+	}
+	if err = hm.ledgerStore.AppendLedger(entries); err != nil {
+		return
+	}
+	return len(items), nil
+}
+
+func decodeRoundLog(b []byte) (interface{}, error) {
+	rl := &RoundLog{}
+	return rl, json.Unmarshal(b, rl)
+}
+
+func decodeLedgerEntry(b []byte) (interface{}, error) {
+	le := &LedgerEntry{}
+	return le, json.Unmarshal(b, le)
+}
+
+// 立即写入所有待写的记录并回放磁盘队列，用于重建排行榜
+func (hm *HistoryManager) Flush() {
+	hm.rounds.Flush()
+	hm.ledger.Flush()
+}
+
+// 写入剩余的记录，用于停机，写不进存储的留在磁盘队列中下次启动时回放
+func (hm *HistoryManager) Close() {
+	hm.rounds.Close()
+	hm.ledger.Close()
+}
+
+func (hm *HistoryManager) OnRound(rl *RoundLog) (err error) {
+	hm.rounds.Push(rl)
+	return
+}
+
+func (hm *HistoryManager) OnLedger(le *LedgerEntry) (err error) {
+	hm.ledger.Push(le)
+	return
+}
+
+func (hm *HistoryManager) Metrics() map[string]*SpoolQueueMetrics {
+	return map[string]*SpoolQueueMetrics{
+		"rounds": hm.rounds.Metrics(),
+		"ledger": hm.ledger.Metrics(),
+	}
+}
+
+func (hm *HistoryManager) Rounds(uid, offset, limit int) ([]*RoundLog, error) {
+	return hm.matchStore.Rounds(uid, offset, limit)
+}
+
+func (hm *HistoryManager) Ledger(uid, offset, limit int) ([]*LedgerEntry, error) {
+	return hm.ledgerStore.Ledger(uid, offset, limit)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+// down为1时写入失败，模拟存储不可用
+type flakyHistoryStore struct {
+	*MemoryStore
+	down int32
+}
+
+func (s *flakyHistoryStore) AppendRounds(rounds []*RoundLog) error {
+	if atomic.LoadInt32(&s.down) == 1 {
+		return errors.New("store down")
+	}
+	return s.MemoryStore.AppendRounds(rounds)
+}
+
+func (s *flakyHistoryStore) AppendLedger(entries []*LedgerEntry) error {
+	if atomic.LoadInt32(&s.down) == 1 {
+		return errors.New("store down")
+	}
+	return s.MemoryStore.AppendLedger(entries)
+}
+
+func TestHistorySpillsAndReplays(t *testing.T) {
+	store := &flakyHistoryStore{MemoryStore: NewMemoryStore(), down: 1}
+	dir := t.TempDir()
+	hm := NewHistoryManager(store, store, dir)
+
+	hm.OnRound(&RoundLog{Uid: 100001, Level: 100, MatchId: "m1", Round: 1, Result: Lost, WinAmount: -100, Ts: 1000})
+	hm.OnLedger(&LedgerEntry{MatchId: "m1", Round: 1, Level: 100, Uid: 100001, Amount: -100, Status: LedgerStatusOK, Ts: 1000})
+
+	// 重试后仍然失败，写入磁盘队列，回放也失败时保留
+	hm.Flush()
+	for name, m := range hm.Metrics() {
+		if m.Spilled != 1 || m.Dropped != 0 || m.Retried != SpoolQueueMaxRetries-1 || m.SpoolBytes == 0 {
+			t.Errorf("%s metrics = %+v, want one record spilled after retries", name, m)
+		}
+	}
+
+	// 停机时存储仍然不可用，记录留在磁盘上，下次启动时回放
+	hm.Close()
+	atomic.StoreInt32(&store.down, 0)
+	hm = NewHistoryManager(store, store, dir)
+	defer hm.Close()
+	hm.Flush()
+
+	if rounds, _ := hm.Rounds(100001, 0, 10); len(rounds) != 1 || rounds[0].MatchId != "m1" || rounds[0].WinAmount != -100 {
+		t.Errorf("rounds = %+v, want the spilled round replayed", rounds)
+	}
+	if entries, _ := hm.Ledger(100001, 0, 10); len(entries) != 1 || entries[0].Amount != -100 || entries[0].Status != LedgerStatusOK {
+		t.Errorf("ledger = %+v, want the spilled entry replayed", entries)
+	}
+	for name, m := range hm.Metrics() {
+		if m.Replayed != 1 || m.SpoolBytes != 0 {
+			t.Errorf("%s metrics = %+v, want one record replayed and an empty spool", name, m)
+		}
+	}
+}
+
+// 回放到一半失败时，已经写入的批次不再重复写入
+type failAfterStore struct {
+	*MemoryStore
+	left int32
+}
+
+func (s *failAfterStore) AppendRounds(rounds []*RoundLog) error {
+	if atomic.AddInt32(&s.left, -1) < 0 {
+		return errors.New("store down")
+	}
+	return s.MemoryStore.AppendRounds(rounds)
+}
+
+func TestSpoolQueueReplayKeepsRemainder(t *testing.T) {
+	dir := t.TempDir()
+	spool, err := NewSpool(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 3; i++ {
+		if err = spool.Append([]*RoundLog{{Uid: 100001, MatchId: "m1", Round: i}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	spool.Close()
+
+	store := &failAfterStore{MemoryStore: NewMemoryStore(), left: 1}
+	hm := NewHistoryManager(store, store, t.TempDir())
+	defer hm.Close()
+	sq, err := NewSpoolQueue("rounds", dir, hm.appendRounds, decodeRoundLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sq.replay()
+	if rounds, _ := store.Rounds(100001, 0, 10); len(rounds) != 1 || rounds[0].Round != 1 {
+		t.Fatalf("rounds = %+v, want only round 1 written", rounds)
+	}
+
+	atomic.StoreInt32(&store.left, 10)
+	sq.replay()
+	rounds, _ := store.Rounds(100001, 0, 10)
+	if len(rounds) != 3 || rounds[0].Round != 3 || rounds[2].Round != 1 {
+		t.Errorf("rounds = %+v, want rounds 1-3 written once", rounds)
+	}
+	if m := sq.Metrics(); m.Replayed != 3 || m.SpoolBytes != 0 {
+		t.Errorf("metrics = %+v, want 3 replayed and an empty spool", m)
+	}
+}
@@ -2,14 +2,23 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	log "code.google.com/p/log4go"
 )
 
+const (
+	UserValueCode = "code"
+)
+
 var (
 	POST    = []byte("POST")
 	GET     = []byte("GET")
@@ -18,192 +27,210 @@ var (
 
 type HttpApi struct {
 	bindAddr string
+	logic    Logic
+	cluster  *Cluster
+	conf     *ConfigValue
+	clock    Clock
+	// 由Server提供，读取的是所属Server的状态
+	metrics fasthttp.RequestHandler
+	readyz  fasthttp.RequestHandler
+	client  *fasthttp.Client
+	server  *fasthttp.Server
+	// 单机模式下用过的nonce，集群模式下放在redis中
+	nonces  *NonceCache
+	limiter *RateLimiter
 }
 
-func NewHttpApi(bindAddr string) *HttpApi {
+func NewHttpApi(bindAddr string, logic Logic) *HttpApi {
 	api := &HttpApi{}
 	api.bindAddr = bindAddr
+	api.logic = logic
+	api.conf = confValue
+	api.clock = DefaultClock
+	api.metrics = func(ctx *fasthttp.RequestCtx) { ctx.SetStatusCode(fasthttp.StatusNotFound) }
+	api.readyz = handleHealthz
+	api.client = &fasthttp.Client{}
+	api.nonces = NewNonceCache()
+	api.limiter = NewRateLimiter(nil)
+	api.server = &fasthttp.Server{Handler: api.fastHttpHandler}
 	return api
 }
 
 func (api *HttpApi) Start() (err error) {
 	go func() {
 		log.Info("fasthttp listen at %s", api.bindAddr)
-		if err := fasthttp.ListenAndServe(api.bindAddr, api.fastHttpHandler); err != nil {
+		if err := api.server.ListenAndServe(api.bindAddr); err != nil {
 			log.Error("fasthttp.ListenAndServe(%q) failed: %s", api.bindAddr, err)
 		}
 	}()
 	return
 }
 
+func (api *HttpApi) Shutdown(deadline time.Time) (err error) {
+	return shutdownServer(api.server, deadline)
+}
+
+// 停止接受新连接，等待处理中的请求完成，最多等到deadline
+func shutdownServer(server *fasthttp.Server, deadline time.Time) (err error) {
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Shutdown()
+	}()
+
+	select {
+	case err = <-done:
+	case <-time.After(time.Until(deadline)):
+		err = ErrShutdownTimeout
+	}
+	return
+}
+
 func (api *HttpApi) fastHttpHandler(ctx *fasthttp.RequestCtx) {
 
 	begin := time.Now()
 
-	ctx.Response.Header.Set("Access-Control-Allow-Headers", "Origin, Cookie, Accept, multipart/form-data, application/json, Content-Type")
+	ctx.Response.Header.Set("Access-Control-Allow-Headers", "Origin, Cookie, Accept, multipart/form-data, application/json, Content-Type, "+
+		HeaderDelta+", "+HeaderTimestamp+", "+HeaderNonce+", "+HeaderSignature)
 	ctx.Response.Header.Set("Access-Control-Allow-Origin", "*")
 	ctx.Response.Header.Set("Access-Control-Allow-Methods", "POST,GET,OPTIONS")
 
+	requestId := string(ctx.Request.Header.Peek(HeaderRequestId))
+	if requestId == "" {
+		requestId = GetGUID()
+	}
+	ctx.SetUserValue(UserValueRequestId, requestId)
+	ctx.Response.Header.Set(HeaderRequestId, requestId)
+
 	defer func() {
-		log.Debug("%2fs %s %s %s", time.Now().Sub(begin).Seconds(), string(ctx.Method()), string(ctx.Path()), string(ctx.PostBody()))
+		NewLog().With("request_id", requestId).With("method", string(ctx.Method())).With("path", string(ctx.Path())).
+			With("code", ctx.UserValue(UserValueCode)).With("bytes", len(ctx.PostBody())).
+			Debug("%2fs %s %s", time.Now().Sub(begin).Seconds(), string(ctx.Method()), string(ctx.Path()))
 	}()
 
 	if bytes.Equal(ctx.Method(), OPTIONS) {
 		return
 	}
 
-	if bytes.Equal(ctx.Method(), GET) {
-		ctx.Write([]byte("Method Not Allowed"))
-		return
-	}
-	ctx.Response.Header.Set("Content-Type", "application/json")
-
 	switch string(ctx.Path()) {
-	case "/fingerplay/v1/match":
-		api.handleMatch(ctx)
-		break
-	case "/fingerplay/v1/ready":
-		api.handleReady(ctx)
-		break
-	case "/fingerplay/v1/ready/status":
-		api.handleReadyStatus(ctx)
-		break
-	case "/fingerplay/v1/ranking":
-		api.handleRanking(ctx)
-		break
-	case "/fingerplay/v1/leave":
-		api.handleLeave(ctx)
-		break
-	case "/fingerplay/v1/online/number":
-		api.handleOnlineNumber(ctx)
-		break
-	default:
-		log.Error("unknown url: %s", ctx.Path())
-	}
-}
-
-func parse(request interface{}, ctx *fasthttp.RequestCtx) (err error) {
-	if err = json.Unmarshal(ctx.PostBody(), request); err != nil {
-		log.Error("json.Unmarshal(%q) failed: %s", ctx.PostBody(), err)
+	case MetricsPath:
+		api.metrics(ctx)
+		return
+	case HealthPath:
+		handleHealthz(ctx)
+		return
+	case ReadyPath:
+		api.readyz(ctx)
+		return
+	case OpenApiPath:
+		api.handleOpenApi(ctx)
+		return
 	}
 
-	return
-}
-
-func (api *HttpApi) handleMatch(ctx *fasthttp.RequestCtx) {
-	var (
-		request  = &MatchRequest{}
-		response = &MatchResponse{}
-	)
+	path := MetricsPathOther
+	defer func() {
+		code := "-"
+		if v := ctx.UserValue(UserValueCode); v != nil {
+			code = fmt.Sprint(v)
+		}
+		observeHttp(path, code, begin)
+	}()
 
-	if err := parse(request, ctx); err != nil {
-		response.Code = ResponseCodeBadRequestFormat
-		goto out
+	// 只有已知的路由作为监控指标的标签
+	route := lookupRoute(string(ctx.Path()))
+	if rejectRequest(ctx, route) {
+		return
 	}
+	path = string(ctx.Path())
+	ctx.Response.Header.Set("Content-Type", "application/json")
 
-	if err := DefaultLogicImpl.Match(request, response); err != nil {
-		log.Error("DefaultLogicImpl.Match failed: %s, request: %#v", err, request)
-	}
+	// 上游带有traceparent时接在上游的trace下
+	traceCtx := otel.GetTextMapPropagator().Extract(context.Background(), fasthttpCarrier{&ctx.Request.Header})
+	traceCtx, span := startSpan(traceCtx, "POST "+path, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("http.route", path), attribute.String("fingerplay.request_id", requestId)))
+	ctx.SetUserValue(UserValueTraceContext, traceCtx)
+	defer func() {
+		code, _ := ctx.UserValue(UserValueCode).(int)
+		endSpan(span, code, nil)
+	}()
 
-out:
-	ctx.Write(response.JSON())
+	api.serveRoute(ctx, route)
 }
 
-func (api *HttpApi) handleReady(ctx *fasthttp.RequestCtx) {
-	var (
-		request  = &ReadyRequest{}
-		response = &ReadyResponse{}
-	)
-
-	if err := parse(request, ctx); err != nil {
-		response.Code = ResponseCodeBadRequestFormat
-		goto out
-	}
-
-	if err := DefaultLogicImpl.Ready(request, response); err != nil {
-		log.Error("DefaultLogicImpl.Ready failed: %s, request: %#v", err, request)
-	}
-
-out:
-	ctx.Write(response.JSON())
+func getRequestId(ctx *fasthttp.RequestCtx) string {
+	id, _ := ctx.UserValue(UserValueRequestId).(string)
+	return id
 }
 
-func (api *HttpApi) handleReadyStatus(ctx *fasthttp.RequestCtx) {
-	var (
-		request  = &ReadyStatusRequest{}
-		response = &ReadyStatusResponse{}
-	)
-
-	if err := parse(request, ctx); err != nil {
-		response.Code = ResponseCodeBadRequestFormat
-		goto out
-	}
-
-	if err := DefaultLogicImpl.ReadyStatus(request, response); err != nil {
-		log.Error("DefaultLogicImpl.ReadyStatus failed: %s, request: %#v", err, request)
-	}
-
-out:
-	ctx.Write(response.JSON())
+// 写回响应，返回码记录在请求上用于监控
+func reply(ctx *fasthttp.RequestCtx, code int, body []byte) {
+	ctx.SetUserValue(UserValueCode, code)
+	ctx.Write(body)
 }
 
-func (api *HttpApi) handleRanking(ctx *fasthttp.RequestCtx) {
-	var (
-		request  = &RankingRequest{}
-		response = &RankingResponse{}
-	)
+func parse(request interface{}, ctx *fasthttp.RequestCtx) (err error) {
+	if isMsgpack(ctx.Request.Header.ContentType()) {
+		if err = msgpackDecode(ctx.PostBody(), request); err != nil {
+			log.Error("msgpackDecode(%x) failed: %s", ctx.PostBody(), err)
+		}
+	} else if err = json.Unmarshal(ctx.PostBody(), request); err != nil {
+		log.Error("json.Unmarshal(%q) failed: %s", ctx.PostBody(), err)
+	}
 
-	if err := parse(request, ctx); err != nil {
-		response.Code = ResponseCodeBadRequestFormat
-		goto out
+	if t, ok := request.(interface{ SetRequestId(string) }); ok {
+		t.SetRequestId(getRequestId(ctx))
 	}
 
-	if err := DefaultLogicImpl.Ranking(request, response); err != nil {
-		log.Error("DefaultLogicImpl.Ranking failed: %s, request: %#v", err, request)
+	if t, ok := request.(interface{ SetContext(context.Context) }); ok {
+		t.SetContext(getTraceContext(ctx))
 	}
 
-out:
-	ctx.Write(response.JSON())
+	return
 }
 
-func (api *HttpApi) handleLeave(ctx *fasthttp.RequestCtx) {
-	var (
-		request  = &LeaveRequest{}
-		response = &LeaveResponse{}
-	)
-
-	if err := parse(request, ctx); err != nil {
-		response.Code = ResponseCodeBadRequestFormat
-		goto out
+// 比赛在其他节点上时把请求原样转发过去，返回true表示已经写回了响应
+func (api *HttpApi) forward(ctx *fasthttp.RequestCtx, err error, code *int) bool {
+	e, ok := err.(*RemoteMatchError)
+	if !ok {
+		return false
 	}
 
-	if err := DefaultLogicImpl.Leave(request, response); err != nil {
-		log.Error("DefaultLogicImpl.Leave failed: %s, request: %#v", err, request)
+	// 已经转发过一次，不再转发，避免节点之间互相转发
+	if by := ctx.Request.Header.Peek(HeaderForwardedBy); len(by) > 0 {
+		log.Error("forward loop: match %s from %s to %s", e.MatchId, by, e.Addr)
+		*code = ResponseCodeBadMatchId
+		return false
 	}
 
-out:
-	ctx.Write(response.JSON())
-}
-
-func (api *HttpApi) handleOnlineNumber(ctx *fasthttp.RequestCtx) {
-	var (
-		request  = &OnlineNumberRequest{}
-		response = &OnlineNumberResponse{}
-	)
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
 
-	if err := parse(request, ctx); err != nil {
-		response.Code = ResponseCodeBadRequestFormat
-		goto out
+	ctx.Request.CopyTo(req)
+	req.SetRequestURI(e.Addr + string(ctx.Path()))
+	req.Header.Set(HeaderForwardedBy, api.cluster.NodeId())
+	if secret := api.conf.Get().SignSecret; secret != "" {
+		req.Header.Set(HeaderForwardSignature, signForward(secret, api.cluster.NodeId(), string(ctx.Request.Header.Peek(HeaderSignature))))
 	}
+	req.Header.Set(HeaderRequestId, getRequestId(ctx))
+	otel.GetTextMapPropagator().Inject(getTraceContext(ctx), fasthttpCarrier{&req.Header})
 
-	if err := DefaultLogicImpl.OnlineNumber(request, response); err != nil {
-		log.Error("DefaultLogicImpl.OnlineNumber failed: %s, request: %#v", err, request)
+	timeout := time.Duration(api.conf.Get().OperateTimeoutSecond+10) * time.Second
+	if err := api.client.DoTimeout(req, resp, timeout); err != nil {
+		log.Error("forward match %s to %s failed: %s", e.MatchId, e.Addr, err)
+		*code = ResponseCodeInternalError
+		return false
 	}
 
-out:
-	ctx.Write(response.JSON())
-}
-
-func InitHttp(bindAddr string) (err error) {
-	return NewHttpApi(bindAddr).Start()
+	ctx.SetStatusCode(resp.StatusCode())
+	ctx.SetContentTypeBytes(resp.Header.ContentType())
+	// 编码已经由对方节点按原请求协商好
+	for _, h := range []string{"Content-Encoding", "Vary", HeaderDelta} {
+		if v := resp.Header.Peek(h); len(v) > 0 {
+			ctx.Response.Header.SetBytesV(h, v)
+		}
+	}
+	ctx.SetUserValue(UserValueCode, MetricsCodeForwarded)
+	ctx.Write(resp.Body())
+	return true
 }
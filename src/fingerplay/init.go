@@ -1,18 +1,20 @@
 package main
 
-func Init() (err error) {
-	DefaultAccountManager = NewAccountManager(Conf.EndpointDescribeUser, Conf.EndpointTransfer, Conf.EndpointLoginAI)
-	DefaultLogicImpl = NewLogicImpl(DefaultAccountManager, Conf.Levels, Conf.OperateTimeoutSecond, Conf.MatchWaitSecond)
-	DefaultRobotManager = NewRobotManager(Conf.RobotUid, Conf.RobotLifetimeSecond)
-
-	mongoConfig := MongoConfig{}
-	mongoConfig.serverAddr = Conf.MongoServerAddrs
+var (
+	// 进程默认的Server，由main创建，收到信号时停止或热更新
+	DefaultServer *Server
+)
 
-	DefaultContext = NewContext(NewMongoManager(mongoConfig))
+func Init() (err error) {
+	conf := GetConf()
 
-	DefaultStatisticsManager = NewStatisticsManager(DefaultContext)
+	if err = InitTracing(conf); err != nil {
+		return
+	}
 
-	DefaultRiskController = NewRiskController(DefaultContext)
+	if DefaultServer, err = NewServer(conf); err != nil {
+		return
+	}
 
-	return InitHttp(Conf.HttpBindAddr)
+	return DefaultServer.Start()
 }
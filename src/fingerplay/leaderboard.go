@@ -0,0 +1,293 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "code.google.com/p/log4go"
+)
+
+const (
+	LeaderboardWindowDaily   = "daily"
+	LeaderboardWindowWeekly  = "weekly"
+	LeaderboardWindowMonthly = "monthly"
+	LeaderboardWindowAll     = "all"
+
+	LeaderboardMaxLimit      = 100
+	LeaderboardMaxNeighbours = 10
+	LeaderboardArchiveLimit  = 100
+	// 刚结束的周期延迟归档，等待队列中的结果写完
+	LeaderboardRollDelay = 1 * time.Minute
+	// 重建时每次读取的流水数
+	LeaderboardRebuildPageSize = 1000
+)
+
+var (
+	LeaderboardWindows = []string{LeaderboardWindowDaily, LeaderboardWindowWeekly, LeaderboardWindowMonthly, LeaderboardWindowAll}
+)
+
+type LeaderboardEntry struct {
+	Window      string  `bson:"window" json:"-"`
+	Period      string  `bson:"period" json:"-"`
+	Level       int     `bson:"level" json:"-"`
+	Rank        int     `bson:"rank,omitempty" json:"rank"`
+	Uid         int     `bson:"uid" json:"uid"`
+	Avatar      string  `bson:"avatar" json:"avatar"`
+	Nickname    string  `bson:"nickname" json:"nickname"`
+	WinAmount   float64 `bson:"win_amount" json:"win_amount"`
+	TimeUpdated int64   `bson:"time_updated" json:"time_updated"`
+}
+
+type LeaderboardArchive struct {
+	Window     string              `bson:"window"`
+	Period     string              `bson:"period"`
+	Level      int                 `bson:"level"`
+	Total      int                 `bson:"total"`
+	Entries    []*LeaderboardEntry `bson:"entries"`
+	ArchivedAt int64               `bson:"archived_at"`
+}
+
+func isLeaderboardWindow(window string) bool {
+	for _, w := range LeaderboardWindows {
+		if w == window {
+			return true
+		}
+	}
+	return false
+}
+
+// 返回t所在的统计周期，周榜按ISO周计算（周一开始）
+func getLeaderboardPeriod(window string, t time.Time) string {
+	switch window {
+	case LeaderboardWindowDaily:
+		return t.Format("2006-01-02")
+	case LeaderboardWindowWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case LeaderboardWindowMonthly:
+		return t.Format("2006-01")
+	default:
+		return LeaderboardWindowAll
+	}
+}
+
+// 结果计入的周期。结果所在的周期还没有归档时计入该周期，已经归档的计入now所在的周期；
+// 与roll的规则一致，当前周期和刚结束LeaderboardRollDelay内的周期不会被归档
+func getLeaderboardResultPeriod(window string, ts int64, now time.Time) string {
+	current := getLeaderboardPeriod(window, now)
+	period := getLeaderboardPeriod(window, time.Unix(ts, 0).In(now.Location()))
+	if period == current || period == getLeaderboardPeriod(window, now.Add(-LeaderboardRollDelay)) {
+		return period
+	}
+	return current
+}
+
+type Leaderboard struct {
+	store RankingStore
+	clock Clock
+	// 定时归档和管理接口触发的归档互斥
+	mux sync.Mutex
+}
+
+func NewLeaderboard(store RankingStore) *Leaderboard {
+	lb := &Leaderboard{}
+	lb.store = store
+	lb.clock = DefaultClock
+	return lb
+}
+
+func (lb *Leaderboard) Start() {
+	go lb.rollLoop()
+}
+
+// 已归档的周期从归档读取，尚未归档的周期继续从实时榜单读取
+func (lb *Leaderboard) Top(window, period string, level, offset, limit int) (entries []*LeaderboardEntry, total int, err error) {
+	if period != getLeaderboardPeriod(window, lb.clock.Now()) {
+		var (
+			archive *LeaderboardArchive
+		)
+
+		if archive, err = lb.store.LeaderboardArchive(window, period, level); err != nil {
+			return
+		}
+
+		if archive != nil {
+			entries = []*LeaderboardEntry{}
+			total = archive.Total
+			if offset < len(archive.Entries) {
+				end := offset + limit
+				if end > len(archive.Entries) {
+					end = len(archive.Entries)
+				}
+				entries = archive.Entries[offset:end]
+			}
+			return
+		}
+	}
+
+	return lb.store.LeaderboardTop(window, period, level, offset, limit)
+}
+
+// 查询uid在当前周期的排名以及前后各n名，没有上榜时me为nil
+func (lb *Leaderboard) Rank(window string, level, uid, n int) (me *LeaderboardEntry, above, below []*LeaderboardEntry, err error) {
+	return lb.store.LeaderboardRank(window, getLeaderboardPeriod(window, lb.clock.Now()), level, uid, n)
+}
+
+func (lb *Leaderboard) rollLoop() {
+	for {
+		if _, err := lb.roll(lb.clock.Now()); err != nil {
+			log.Error("Leaderboard roll failed: %s", err)
+		}
+		lb.clock.Sleep(1 * time.Minute)
+	}
+}
+
+// 将已结束周期的榜单归档，然后从实时榜单中删除。
+// 重启后也会补归档停机期间结束的周期。返回归档的周期数。
+func (lb *Leaderboard) roll(now time.Time) (n int, err error) {
+	lb.mux.Lock()
+	defer lb.mux.Unlock()
+
+	for _, window := range LeaderboardWindows {
+		if window == LeaderboardWindowAll {
+			continue
+		}
+
+		var (
+			periods []string
+		)
+
+		if periods, err = lb.store.LeaderboardPeriods(window); err != nil {
+			return
+		}
+
+		current := getLeaderboardPeriod(window, now)
+		previous := getLeaderboardPeriod(window, now.Add(-LeaderboardRollDelay))
+
+		for _, period := range periods {
+			if period == current || period == previous {
+				continue
+			}
+
+			if err = lb.store.ArchiveLeaderboard(window, period, now); err != nil {
+				return
+			}
+
+			n++
+			log.Info("Leaderboard %s %s archived", window, period)
+		}
+	}
+	return
+}
+
+// 用转账流水重新计算未归档周期的实时榜单。流水与写入榜单的结果一一对应，
+// 包括机器人和转账失败的回合；昵称和头像沿用总榜上已有的。
+// 只重建第一条流水所在周期之后的周期，更早的结果没有流水；总榜和已经归档的周期不变。
+// 与结果写入和归档互斥，重建期间新的结果在队列中等待。返回读取的流水数和重建的榜单数。
+func (lb *Leaderboard) rebuild(history LedgerStore, now time.Time) (entries, boards int, err error) {
+	lb.mux.Lock()
+	defer lb.mux.Unlock()
+
+	type board struct {
+		window, period string
+	}
+
+	var (
+		periods []string
+		top     []*LeaderboardEntry
+		page    []*LedgerEntry
+	)
+
+	until := now.Unix() + 1
+	if page, err = history.LedgerUntil(until, 0, 1); err != nil || len(page) == 0 {
+		return
+	}
+	first := time.Unix(page[0].Ts, 0).In(now.Location())
+
+	// 实时榜单上已有的周期和当前周期，周期字符串的顺序与时间顺序一致
+	live := make(map[board]bool)
+	for _, window := range LeaderboardWindows {
+		if window == LeaderboardWindowAll {
+			continue
+		}
+		if periods, err = lb.store.LeaderboardPeriods(window); err != nil {
+			return
+		}
+		periods = append(periods, getLeaderboardPeriod(window, now))
+		for _, period := range periods {
+			if period > getLeaderboardPeriod(window, first) {
+				live[board{window, period}] = true
+			}
+		}
+	}
+
+	profiles := make(map[int]*LeaderboardEntry)
+	for offset := 0; ; offset += LeaderboardRebuildPageSize {
+		if top, _, err = lb.store.LeaderboardTop(LeaderboardWindowAll, LeaderboardWindowAll, 0, offset, LeaderboardRebuildPageSize); err != nil {
+			return
+		}
+		for _, e := range top {
+			profiles[e.Uid] = e
+		}
+		if len(top) < LeaderboardRebuildPageSize {
+			break
+		}
+	}
+
+	boardEntries := make(map[board][]*LeaderboardEntry)
+	totals := make(map[leaderboardKey]*LeaderboardEntry)
+	for {
+		if page, err = history.LedgerUntil(until, entries, LeaderboardRebuildPageSize); err != nil {
+			return
+		}
+		entries += len(page)
+
+		for _, le := range page {
+			// 退款不回滚榜单
+			if le.Status == LedgerStatusRefund || le.Status == LedgerStatusRefundFailed {
+				continue
+			}
+
+			t := time.Unix(le.Ts, 0).In(now.Location())
+			for _, window := range LeaderboardWindows {
+				b := board{window, getLeaderboardPeriod(window, t)}
+				if !live[b] {
+					continue
+				}
+
+				for _, level := range []int{le.Level, 0} {
+					k := leaderboardKey{b.window, b.period, level, le.Uid}
+					e := totals[k]
+					if e == nil {
+						e = &LeaderboardEntry{Window: b.window, Period: b.period, Level: level, Uid: le.Uid}
+						if p := profiles[le.Uid]; p != nil {
+							e.Avatar = p.Avatar
+							e.Nickname = p.Nickname
+						}
+						totals[k] = e
+						boardEntries[b] = append(boardEntries[b], e)
+					}
+					e.WinAmount += le.Amount
+					if le.Ts > e.TimeUpdated {
+						e.TimeUpdated = le.Ts
+					}
+				}
+			}
+		}
+
+		if len(page) < LeaderboardRebuildPageSize {
+			break
+		}
+	}
+
+	// 没有流水的周期也要替换，清掉错误的数据
+	for b := range live {
+		if err = lb.store.ReplaceLeaderboard(b.window, b.period, boardEntries[b]); err != nil {
+			return
+		}
+		boards++
+		log.Info("Leaderboard %s %s rebuilt: %d entries", b.window, b.period, len(boardEntries[b]))
+	}
+	return
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLeaderboardTotalsByMatchTime(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	batch := &ResultBatch{Id: "b1", Results: []*SpooledResult{
+		// 前一天结束的比赛，在零点后30秒写入，前一天还没有归档
+		{Uid: 100001, Level: 100, WinAmount: 90, TimeUpdated: time.Date(2026, 10, 18, 23, 59, 50, 0, loc).Unix()},
+	}}
+
+	now := time.Date(2026, 10, 19, 0, 0, 30, 0, loc)
+	for _, total := range batch.leaderboardTotals(now) {
+		if total.Key.Window == LeaderboardWindowDaily && total.Key.Period != "2026-10-18" {
+			t.Errorf("daily period = %s, want 2026-10-18", total.Key.Period)
+		}
+	}
+
+	// 磁盘队列在归档之后才回放，前一天已经归档，计入当天
+	now = time.Date(2026, 10, 19, 0, 10, 0, 0, loc)
+	for _, total := range batch.leaderboardTotals(now) {
+		if total.Key.Window == LeaderboardWindowDaily && total.Key.Period != "2026-10-19" {
+			t.Errorf("daily period = %s, want 2026-10-19", total.Key.Period)
+		}
+		// 周榜和月榜的周期没有结束
+		if total.Key.Window == LeaderboardWindowMonthly && total.Key.Period != "2026-10" {
+			t.Errorf("monthly period = %s, want 2026-10", total.Key.Period)
+		}
+	}
+}
+
+func TestLeaderboardReplayAfterRoll(t *testing.T) {
+	store := NewMemoryStore()
+	lb := &Leaderboard{store: store}
+	day1 := time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local)
+	day2 := day1.Add(24 * time.Hour)
+
+	apply := func(id string, uid int, ts, now time.Time) {
+		batch := &ResultBatch{Id: id, Results: []*SpooledResult{{Uid: uid, Level: 100, WinAmount: 10, TimeUpdated: ts.Unix()}}}
+		if err := store.ApplyResults(batch, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	apply("b1", 100001, day1, day1)
+	if n, err := lb.roll(day2); err != nil || n != 1 {
+		t.Fatalf("roll() = %d, %v, want 1 archived period", n, err)
+	}
+
+	// 第一天的结果在归档之后才从磁盘队列回放
+	apply("b2", 100002, day1, day2)
+
+	archive, err := store.LeaderboardArchive(LeaderboardWindowDaily, getLeaderboardPeriod(LeaderboardWindowDaily, day1), 100)
+	if err != nil || archive == nil || archive.Total != 1 {
+		t.Fatalf("archive = %+v, %v, want only the result applied before the roll", archive, err)
+	}
+	entries, _, err := store.LeaderboardTop(LeaderboardWindowDaily, getLeaderboardPeriod(LeaderboardWindowDaily, day2), 100, 0, 10)
+	if err != nil || len(entries) != 1 || entries[0].Uid != 100002 {
+		t.Errorf("current period = %+v, %v, want the replayed result", entries, err)
+	}
+}
+
+func TestLeaderboardRollsOverWithClock(t *testing.T) {
+	store := NewMemoryStore()
+	clock := NewManualClock(time.Date(2026, 10, 14, 23, 59, 0, 0, time.Local))
+	lb := NewLeaderboard(store)
+	lb.clock = clock
+
+	day1 := getLeaderboardPeriod(LeaderboardWindowDaily, clock.Now())
+	batch := &ResultBatch{Id: "b1", Results: []*SpooledResult{{Uid: 100001, Level: 100, WinAmount: 10, TimeUpdated: clock.Now().Unix()}}}
+	if err := store.ApplyResults(batch, clock.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	lb.Start()
+	clock.BlockUntil(1)
+
+	// 零点时前一天还在LeaderboardRollDelay内，不归档
+	clock.Advance(time.Minute)
+	clock.BlockUntil(1)
+	if archive, err := store.LeaderboardArchive(LeaderboardWindowDaily, day1, 100); err != nil || archive != nil {
+		t.Fatalf("archive at midnight = %+v, %v, want none", archive, err)
+	}
+
+	clock.Advance(time.Minute)
+	clock.BlockUntil(1)
+	entries, total, err := lb.Top(LeaderboardWindowDaily, day1, 100, 0, 10)
+	if err != nil || total != 1 || len(entries) != 1 || entries[0].Uid != 100001 {
+		t.Fatalf("Top(%s) = %+v, %d, %v, want the archived entry", day1, entries, total, err)
+	}
+	day2 := getLeaderboardPeriod(LeaderboardWindowDaily, clock.Now())
+	if entries, _, err := lb.Top(LeaderboardWindowDaily, day2, 100, 0, 10); err != nil || len(entries) != 0 {
+		t.Errorf("Top(%s) = %+v, %v, want an empty new period", day2, entries, err)
+	}
+}
+
+func applyTestResults(t *testing.T, store *MemoryStore, id string, ts time.Time, results ...*SpooledResult) {
+	for _, r := range results {
+		r.Level = 100
+		r.TimeUpdated = ts.Unix()
+	}
+	if err := store.ApplyResults(&ResultBatch{Id: id, Results: results}, ts); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLeaderboardRebuildFromLedger(t *testing.T) {
+	store := NewMemoryStore()
+	lb := &Leaderboard{store: store}
+	day1 := time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local)
+	day2 := day1.Add(24 * time.Hour)
+
+	ledger := func(ts time.Time, status string, uid int, amount float64) *LedgerEntry {
+		return &LedgerEntry{Level: 100, Uid: uid, Amount: amount, Status: status, Ts: ts.Unix()}
+	}
+	store.AppendLedger([]*LedgerEntry{
+		// 上个月的流水只说明流水从那时开始
+		ledger(time.Date(2026, 9, 30, 12, 0, 0, 0, time.Local), LedgerStatusOK, 100001, 90),
+		ledger(day1, LedgerStatusOK, 100001, 90),
+		ledger(day1, LedgerStatusOK, 100002, -100),
+		// 转账失败的回合也计入了榜单，机器人也有流水
+		ledger(day1.Add(5*time.Second), LedgerStatusFailed, 100001, -100),
+		ledger(day1.Add(5*time.Second), LedgerStatusFailed, 1500, 90),
+		ledger(day2, LedgerStatusOK, 100001, 90),
+		ledger(day2, LedgerStatusOK, 1500, -100),
+		// 退款不回滚榜单
+		ledger(day2, LedgerStatusRefund, 1500, 90),
+		ledger(day2, LedgerStatusRefund, 100001, -90),
+	})
+
+	// 第一天已经归档；当天的实时榜单和流水不一致
+	applyTestResults(t, store, "b1", day1, &SpooledResult{Uid: 100001, Nickname: "Alice", WinAmount: 90})
+	if _, err := lb.roll(day2); err != nil {
+		t.Fatal(err)
+	}
+	applyTestResults(t, store, "b2", day2, &SpooledResult{Uid: 100001, Nickname: "Alice", WinAmount: 5}, &SpooledResult{Uid: 1500, Nickname: "Robot", WinAmount: 90})
+	all, _, _ := store.LeaderboardTop(LeaderboardWindowAll, LeaderboardWindowAll, 0, 0, 10)
+
+	entries, boards, err := lb.rebuild(store, day2)
+	if err != nil || entries != 9 || boards != 3 {
+		t.Fatalf("rebuild() = %d, %d, %v, want 9 entries and the daily, weekly and monthly boards", entries, boards, err)
+	}
+
+	daily, _, _ := store.LeaderboardTop(LeaderboardWindowDaily, getLeaderboardPeriod(LeaderboardWindowDaily, day2), 100, 0, 10)
+	if len(daily) != 2 || daily[0].Uid != 100001 || daily[0].WinAmount != 90 || daily[0].Nickname != "Alice" ||
+		daily[1].Uid != 1500 || daily[1].WinAmount != -100 || daily[1].Nickname != "Robot" {
+		t.Errorf("daily = %+v, want 100001 with 90 and the robot with -100", daily)
+	}
+	for _, window := range []string{LeaderboardWindowWeekly, LeaderboardWindowMonthly} {
+		top, _, _ := store.LeaderboardTop(window, getLeaderboardPeriod(window, day2), 0, 0, 10)
+		if len(top) != 3 || top[0].WinAmount != 80 || top[1].Uid != 1500 || top[1].WinAmount != -10 || top[2].WinAmount != -100 {
+			t.Errorf("%s = %+v, want 80, -10 and -100 from both days", window, top)
+		}
+	}
+
+	// 总榜和已经归档的周期不变，归档的周期也不会重新出现在实时榜单上
+	if after, _, _ := store.LeaderboardTop(LeaderboardWindowAll, LeaderboardWindowAll, 0, 0, 10); !reflect.DeepEqual(after, all) {
+		t.Errorf("all = %+v, want it untouched %+v", after, all)
+	}
+	archive, _ := store.LeaderboardArchive(LeaderboardWindowDaily, getLeaderboardPeriod(LeaderboardWindowDaily, day1), 100)
+	if archive == nil || archive.Total != 1 || archive.Entries[0].WinAmount != 90 {
+		t.Errorf("archive = %+v, want it untouched", archive)
+	}
+	if periods, _ := store.LeaderboardPeriods(LeaderboardWindowDaily); len(periods) != 1 {
+		t.Errorf("daily periods = %v, want only the current one", periods)
+	}
+}
+
+func TestLeaderboardRebuildKeepsTotalsBeforeLedger(t *testing.T) {
+	store := NewMemoryStore()
+	lb := &Leaderboard{store: store}
+	day1 := time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local)
+	day2 := day1.Add(24 * time.Hour)
+	day3 := day2.Add(24 * time.Hour)
+
+	// 升级前的结果没有流水
+	applyTestResults(t, store, "b1", day2, &SpooledResult{Uid: 100001, Nickname: "Alice", WinAmount: 90}, &SpooledResult{Uid: 1500, WinAmount: -100})
+	snapshot := func() map[string][]*LeaderboardEntry {
+		boards := make(map[string][]*LeaderboardEntry)
+		for _, window := range LeaderboardWindows {
+			top, _, _ := store.LeaderboardTop(window, getLeaderboardPeriod(window, day2), 0, 0, 10)
+			boards[window] = top
+		}
+		return boards
+	}
+	before := snapshot()
+
+	if entries, boards, err := lb.rebuild(store, day2); err != nil || entries != 0 || boards != 0 {
+		t.Errorf("rebuild() = %d, %d, %v without a ledger, want nothing rebuilt", entries, boards, err)
+	}
+
+	// 流水从第二天中午开始，第二天所在的周期只有一部分结果有流水
+	store.AppendLedger([]*LedgerEntry{{Level: 100, Uid: 100001, Amount: 90, Status: LedgerStatusOK, Ts: day2.Unix()}})
+	applyTestResults(t, store, "b2", day2, &SpooledResult{Uid: 100001, Nickname: "Alice", WinAmount: 90})
+	before = snapshot()
+	if entries, boards, err := lb.rebuild(store, day2); err != nil || entries != 1 || boards != 0 {
+		t.Errorf("rebuild() = %d, %d, %v, want the periods where the ledger starts left alone", entries, boards, err)
+	}
+	if after := snapshot(); !reflect.DeepEqual(after, before) {
+		t.Errorf("boards = %+v, want them untouched %+v", after, before)
+	}
+
+	// 第三天的日榜完全有流水，可以重建；周榜、月榜和总榜保留升级前的结果
+	store.AppendLedger([]*LedgerEntry{{Level: 100, Uid: 100001, Amount: -100, Status: LedgerStatusOK, Ts: day3.Unix()}})
+	applyTestResults(t, store, "b3", day3, &SpooledResult{Uid: 100001, Nickname: "Alice", WinAmount: 5})
+	if _, boards, err := lb.rebuild(store, day3); err != nil || boards != 1 {
+		t.Fatalf("rebuild() = %d, %v, want only the daily board of day 3", boards, err)
+	}
+	daily, _, _ := store.LeaderboardTop(LeaderboardWindowDaily, getLeaderboardPeriod(LeaderboardWindowDaily, day3), 0, 0, 10)
+	if len(daily) != 1 || daily[0].WinAmount != -100 || daily[0].Nickname != "Alice" {
+		t.Errorf("daily = %+v, want -100 from the ledger", daily)
+	}
+	monthly, _, _ := store.LeaderboardTop(LeaderboardWindowMonthly, getLeaderboardPeriod(LeaderboardWindowMonthly, day3), 0, 0, 10)
+	if len(monthly) != 2 || monthly[0].WinAmount != 185 || monthly[1].Uid != 1500 || monthly[1].WinAmount != -100 {
+		t.Errorf("monthly = %+v, want the totals before the ledger kept", monthly)
+	}
+}
@@ -1,6 +1,14 @@
 package main
 
-func getCost(level int) (cost float64) {
+import (
+	"strconv"
+)
+
+// level_costs中配置的费用优先，未配置时不收费
+func (impl *LogicImpl) getCost(level int) (cost float64) {
+	if cost, ok := impl.conf.Get().LevelCosts[strconv.Itoa(level)]; ok {
+		return cost
+	}
 	return 0
 	switch level {
 	case 100:
@@ -0,0 +1,257 @@
+// loadtest模拟大量玩家，通过http完整地进行比赛：/match、/ready、/ready/status、/leave，
+// 最后报告吞吐、延迟分位数、超时和各返回码的数量。
+//
+// 服务端需要连接一个能识别任意令牌的账户服务，例如fakewallet：
+//
+//	go build -o loadtest ./loadtest
+//	loadtest -addr http://127.0.0.1:8091 -players 2000 -rate 50 -levels 100,500:0.2 \
+//		-strategies random,cycle:2,copy -rounds 5 -disconnect 0.02
+//
+// 玩家按泊松过程到达，令牌为<token-prefix><编号>。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+var (
+	Strategies = []string{"random", "stone", "paper", "scissors", "cycle", "copy", "counter"}
+)
+
+type Config struct {
+	Addr        string
+	TokenPrefix string
+	Players     int
+	Rate        float64
+	Duration    time.Duration
+	Levels      string
+	Strategies  string
+	Matches     int
+	Rounds      int
+	Think       time.Duration
+	Disconnect  float64
+	Timeout     time.Duration
+	Seed        int64
+	Interval    time.Duration
+	Output      string
+	SignSecret  string
+}
+
+// 带权重的选项，格式为a:2,b,c:0.5，不写权重时为1
+type weighted struct {
+	items   []string
+	weights []float64
+	total   float64
+}
+
+func parseWeighted(s string, valid func(string) bool) (w *weighted, err error) {
+	w = &weighted{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		item, weight := part, 1.0
+		if i := strings.LastIndex(part, ":"); i >= 0 {
+			item = part[:i]
+			if weight, err = strconv.ParseFloat(part[i+1:], 64); err != nil || weight < 0 {
+				return nil, fmt.Errorf("bad weight in %q", part)
+			}
+		}
+		if !valid(item) {
+			return nil, fmt.Errorf("bad item %q", item)
+		}
+
+		w.items = append(w.items, item)
+		w.weights = append(w.weights, weight)
+		w.total += weight
+	}
+
+	if w.total <= 0 {
+		return nil, fmt.Errorf("no items in %q", s)
+	}
+	return
+}
+
+func (w *weighted) pick(rnd *rand.Rand) string {
+	x := rnd.Float64() * w.total
+	for i, weight := range w.weights {
+		if x < weight {
+			return w.items[i]
+		}
+		x -= weight
+	}
+	return w.items[len(w.items)-1]
+}
+
+type Simulation struct {
+	conf       *Config
+	client     *http.Client
+	report     *Report
+	levels     *weighted
+	strategies *weighted
+	stop       int32
+}
+
+func NewSimulation(conf *Config) (sim *Simulation, err error) {
+	sim = &Simulation{}
+	sim.conf = conf
+
+	if sim.levels, err = parseWeighted(conf.Levels, func(s string) bool {
+		lv, err := strconv.Atoi(s)
+		return err == nil && lv > 0
+	}); err != nil {
+		return nil, fmt.Errorf("-levels: %s", err)
+	}
+
+	if sim.strategies, err = parseWeighted(conf.Strategies, func(s string) bool {
+		for _, name := range Strategies {
+			if s == name {
+				return true
+			}
+		}
+		return false
+	}); err != nil {
+		return nil, fmt.Errorf("-strategies: %s", err)
+	}
+
+	sim.report = NewReport()
+	sim.client = &http.Client{
+		Timeout: conf.Timeout,
+		Transport: &http.Transport{
+			MaxIdleConns:        conf.Players,
+			MaxIdleConnsPerHost: conf.Players,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+	return
+}
+
+func (sim *Simulation) stopping() bool {
+	return atomic.LoadInt32(&sim.stop) == 1
+}
+
+// 停止新的到达，已经开始的玩家打完当前比赛后结束
+func (sim *Simulation) Stop() {
+	atomic.StoreInt32(&sim.stop, 1)
+}
+
+func (sim *Simulation) Run() {
+	var (
+		wg sync.WaitGroup
+	)
+
+	rnd := rand.New(rand.NewSource(sim.conf.Seed))
+	deadline := time.Now().Add(sim.conf.Duration)
+
+	for id := 0; id < sim.conf.Players && !sim.stopping(); id++ {
+		if sim.conf.Duration > 0 && time.Now().After(deadline) {
+			break
+		}
+
+		p := NewPlayer(sim, id, rand.New(rand.NewSource(sim.conf.Seed+int64(id)+1)))
+		wg.Add(1)
+		atomic.AddInt64(&sim.report.active, 1)
+		go func() {
+			defer wg.Done()
+			defer atomic.AddInt64(&sim.report.active, -1)
+			p.Play()
+		}()
+
+		// 泊松到达，间隔服从指数分布
+		if sim.conf.Rate > 0 {
+			time.Sleep(time.Duration(rnd.ExpFloat64() / sim.conf.Rate * float64(time.Second)))
+		}
+	}
+
+	wg.Wait()
+}
+
+func main() {
+	conf := &Config{}
+	flag.StringVar(&conf.Addr, "addr", "http://127.0.0.1:8091", "fingerplay http address")
+	flag.StringVar(&conf.TokenPrefix, "token-prefix", "load-", "access token prefix, the player id is appended")
+	flag.IntVar(&conf.Players, "players", 100, "number of simulated players")
+	flag.Float64Var(&conf.Rate, "rate", 10, "player arrivals per second, 0 for all at once")
+	flag.DurationVar(&conf.Duration, "duration", 0, "stop new arrivals after this long, 0 for no limit")
+	flag.StringVar(&conf.Levels, "levels", "100", "levels with optional weights, e.g. 100:3,500")
+	flag.StringVar(&conf.Strategies, "strategies", "random", "move strategies with optional weights: "+strings.Join(Strategies, ", "))
+	flag.IntVar(&conf.Matches, "matches", 1, "matches per player")
+	flag.IntVar(&conf.Rounds, "rounds", 3, "rounds per match before leaving")
+	flag.DurationVar(&conf.Think, "think", 2*time.Second, "max think time before each move")
+	flag.Float64Var(&conf.Disconnect, "disconnect", 0, "probability of dropping out before each round")
+	flag.DurationVar(&conf.Timeout, "timeout", time.Minute, "http request timeout")
+	flag.Int64Var(&conf.Seed, "seed", time.Now().UnixNano(), "random seed")
+	flag.DurationVar(&conf.Interval, "interval", 5*time.Second, "progress report interval, 0 to disable")
+	flag.StringVar(&conf.Output, "o", "table", "summary format: table or json")
+	flag.StringVar(&conf.SignSecret, "sign-secret", "", "sign requests with the server's sign_secret")
+	flag.Parse()
+
+	if conf.Players <= 0 || conf.Rounds <= 0 || conf.Matches <= 0 || conf.Rate < 0 || conf.Disconnect < 0 || conf.Disconnect > 1 ||
+		(conf.Output != "table" && conf.Output != "json") {
+		flag.Usage()
+		os.Exit(2)
+	}
+	conf.Addr = strings.TrimRight(conf.Addr, "/")
+
+	sim, err := NewSimulation(conf)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	fmt.Fprintf(os.Stderr, "loadtest %s: %d players at %.1f/s, seed %d\n", conf.Addr, conf.Players, conf.Rate, conf.Seed)
+
+	// 第一次中断停止新的到达，第二次立即退出并打印报告
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		sim.Run()
+		close(done)
+	}()
+
+	var (
+		tick <-chan time.Time
+	)
+	if conf.Interval > 0 {
+		ticker := time.NewTicker(conf.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+loop:
+	for {
+		select {
+		case <-done:
+			break loop
+		case <-tick:
+			sim.report.Progress(os.Stderr)
+		case <-sigs:
+			if sim.stopping() {
+				break loop
+			}
+			fmt.Fprintln(os.Stderr, "stopping, waiting for matches in progress (interrupt again to quit)")
+			sim.Stop()
+		}
+	}
+
+	summary := sim.report.Summary()
+	if conf.Output == "json" {
+		fmt.Println(string(summary.JSON()))
+	} else {
+		summary.Print(os.Stdout)
+	}
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	Stone = iota
+	Paper
+	Scissors
+)
+
+// 与服务端ret.go一致，只列出需要特殊处理的
+const (
+	ResponseCodeOK               = 0
+	ResponseCodeWaitReadyTimeout = -1
+	ResponseCodeWaitMatchTimeout = -2
+)
+
+const (
+	PathMatch       = "/fingerplay/v1/match"
+	PathReady       = "/fingerplay/v1/ready"
+	PathReadyStatus = "/fingerplay/v1/ready/status"
+	PathLeave       = "/fingerplay/v1/leave"
+)
+
+// 与服务端sign.go一致
+const (
+	HeaderTimestamp = "X-Fingerplay-Timestamp"
+	HeaderNonce     = "X-Fingerplay-Nonce"
+	HeaderSignature = "X-Fingerplay-Signature"
+)
+
+var (
+	ErrTimeout = errors.New("timeout")
+)
+
+type Response struct {
+	Code int             `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+type MatchData struct {
+	MatchId       string `json:"match_id"`
+	Round         int    `json:"round"`
+	TimeoutSecond int    `json:"timeout_second"`
+	SessionTicket string `json:"session_ticket"`
+}
+
+type Result struct {
+	AccessToken string  `json:"access_token"`
+	Operate     int     `json:"operate"`
+	Status      int     `json:"status"`
+	Balance     float64 `json:"balance"`
+	Win         float64 `json:"win"`
+}
+
+type ReadyData struct {
+	Round   int       `json:"round"`
+	Results []*Result `json:"results"`
+}
+
+// 一个模拟玩家：匹配，按策略出拳若干回合，然后离开
+type Player struct {
+	token    string
+	level    int
+	strategy string
+	rounds   int
+
+	sim *Simulation
+	rnd *rand.Rand
+
+	// 上一回合双方的出拳，-1表示没有
+	last, opponentLast int
+}
+
+func NewPlayer(sim *Simulation, id int, rnd *rand.Rand) *Player {
+	p := &Player{}
+	p.sim = sim
+	p.rnd = rnd
+	p.token = sim.conf.TokenPrefix + strconv.Itoa(id)
+	p.level, _ = strconv.Atoi(sim.levels.pick(rnd))
+	p.strategy = sim.strategies.pick(rnd)
+	p.rounds = sim.conf.Rounds
+	p.last, p.opponentLast = -1, -1
+	return p
+}
+
+func (p *Player) Play() {
+	var (
+		match *MatchData
+		err   error
+	)
+
+	for i := 0; i < p.sim.conf.Matches && !p.sim.stopping(); i++ {
+		if match, err = p.match(); err != nil || match == nil {
+			continue
+		}
+
+		p.sim.report.onMatch()
+		if !p.playMatch(match) {
+			// 掉线的玩家不再发起新的匹配
+			return
+		}
+	}
+}
+
+func (p *Player) match() (match *MatchData, err error) {
+	arg := map[string]interface{}{"level": p.level, "access_token": p.token}
+	response, err := p.post(PathMatch, arg)
+	if err != nil || response.Code != ResponseCodeOK {
+		return
+	}
+
+	match = &MatchData{}
+	err = json.Unmarshal(response.Data, match)
+	return
+}
+
+// 返回false表示玩家掉线
+func (p *Player) playMatch(match *MatchData) bool {
+	round := match.Round
+	for i := 0; i < p.rounds; i++ {
+		if p.rnd.Float64() < p.sim.conf.Disconnect {
+			// 不再心跳也不离开，等服务端超时清理
+			p.sim.report.onDisconnect()
+			return false
+		}
+
+		if !p.think(match) {
+			return true
+		}
+
+		arg := map[string]interface{}{"operate": p.next(), "match_id": match.MatchId, "round": round, "access_token": p.token, "session_ticket": match.SessionTicket}
+		response, err := p.post(PathReady, arg)
+		if err != nil || response.Code != ResponseCodeOK {
+			return true
+		}
+
+		data := &ReadyData{}
+		if err = json.Unmarshal(response.Data, data); err != nil {
+			return true
+		}
+		p.remember(data)
+		p.sim.report.onRound()
+		round = data.Round
+	}
+
+	p.post(PathLeave, map[string]interface{}{"match_id": match.MatchId, "access_token": p.token, "session_ticket": match.SessionTicket})
+	return true
+}
+
+// 出拳前的思考时间，期间每秒查询一次对手状态作为心跳。
+// 返回false表示比赛已经结束。
+func (p *Player) think(match *MatchData) bool {
+	var (
+		wait time.Duration
+	)
+
+	if p.sim.conf.Think > 0 {
+		wait = time.Duration(p.rnd.Int63n(int64(p.sim.conf.Think)))
+	}
+
+	deadline := time.Now().Add(wait)
+	for time.Until(deadline) > time.Second {
+		time.Sleep(time.Second)
+		arg := map[string]interface{}{"match_id": match.MatchId, "access_token": p.token, "session_ticket": match.SessionTicket}
+		if response, err := p.post(PathReadyStatus, arg); err != nil || response.Code != ResponseCodeOK {
+			return false
+		}
+	}
+	time.Sleep(time.Until(deadline))
+	return true
+}
+
+func (p *Player) remember(data *ReadyData) {
+	for _, r := range data.Results {
+		if r.AccessToken == p.token {
+			p.last = r.Operate
+		} else {
+			p.opponentLast = r.Operate
+		}
+	}
+}
+
+// 赢过op的出拳
+func beat(op int) int {
+	return (op + 1) % 3
+}
+
+func (p *Player) next() int {
+	switch p.strategy {
+	case "stone":
+		return Stone
+	case "paper":
+		return Paper
+	case "scissors":
+		return Scissors
+	case "cycle":
+		if p.last < 0 {
+			return p.rnd.Intn(3)
+		}
+		return (p.last + 1) % 3
+	case "copy":
+		if p.opponentLast < 0 {
+			return p.rnd.Intn(3)
+		}
+		return p.opponentLast
+	case "counter":
+		if p.opponentLast < 0 {
+			return p.rnd.Intn(3)
+		}
+		return beat(p.opponentLast)
+	default:
+		return p.rnd.Intn(3)
+	}
+}
+
+func (p *Player) post(path string, arg interface{}) (response *Response, err error) {
+	var (
+		resp *http.Response
+		v    []byte
+	)
+
+	v, _ = json.Marshal(arg)
+	begin := time.Now()
+	defer func() {
+		code := 0
+		if response != nil {
+			code = response.Code
+		}
+		p.sim.report.onRequest(path, time.Since(begin), code, err)
+	}()
+
+	req, _ := http.NewRequest("POST", p.sim.conf.Addr+path, bytes.NewReader(v))
+	req.Header.Set("Content-Type", "application/json")
+	if p.sim.conf.SignSecret != "" {
+		p.sign(req, path, v)
+	}
+
+	if resp, err = p.sim.client.Do(req); err != nil {
+		if e, ok := err.(interface{ Timeout() bool }); ok && e.Timeout() {
+			err = ErrTimeout
+		}
+		return
+	}
+	defer resp.Body.Close()
+
+	if v, err = ioutil.ReadAll(resp.Body); err != nil {
+		return
+	}
+
+	response = &Response{}
+	if err = json.Unmarshal(v, response); err != nil {
+		response = nil
+	}
+	return
+}
+
+// 服务端配置了sign_secret时需要签名
+func (p *Player) sign(req *http.Request, path string, body []byte) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce := strconv.FormatInt(p.rnd.Int63(), 36) + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	mac := hmac.New(sha256.New, []byte(p.sim.conf.SignSecret))
+	mac.Write([]byte("POST\n" + path + "\n" + timestamp + "\n" + nonce + "\n"))
+	mac.Write(body)
+
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderNonce, nonce)
+	req.Header.Set(HeaderSignature, hex.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+type endpointStats struct {
+	latencies []time.Duration
+	codes     map[int]int
+	errors    int
+	timeouts  int
+}
+
+type Report struct {
+	mux       sync.Mutex
+	begin     time.Time
+	endpoints map[string]*endpointStats
+
+	requests    int64
+	matches     int64
+	rounds      int64
+	disconnects int64
+	active      int64
+}
+
+func NewReport() *Report {
+	r := &Report{}
+	r.begin = time.Now()
+	r.endpoints = make(map[string]*endpointStats)
+	return r
+}
+
+func (r *Report) onRequest(path string, d time.Duration, code int, err error) {
+	atomic.AddInt64(&r.requests, 1)
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	es := r.endpoints[path]
+	if es == nil {
+		es = &endpointStats{codes: make(map[int]int)}
+		r.endpoints[path] = es
+	}
+
+	es.latencies = append(es.latencies, d)
+	switch {
+	case err == ErrTimeout:
+		es.timeouts++
+	case err != nil:
+		es.errors++
+	default:
+		es.codes[code]++
+		// 服务端等待匹配或等待对手超时也计入超时
+		if code == ResponseCodeWaitMatchTimeout || code == ResponseCodeWaitReadyTimeout {
+			es.timeouts++
+		}
+	}
+}
+
+func (r *Report) onMatch()      { atomic.AddInt64(&r.matches, 1) }
+func (r *Report) onRound()      { atomic.AddInt64(&r.rounds, 1) }
+func (r *Report) onDisconnect() { atomic.AddInt64(&r.disconnects, 1) }
+
+// 运行中的进度，每个报告周期打印一行
+func (r *Report) Progress(w io.Writer) {
+	elapsed := time.Since(r.begin).Seconds()
+	fmt.Fprintf(w, "%6.0fs  players %d  requests %d (%.1f/s)  matches %d  rounds %d (%.1f/s)  disconnects %d\n",
+		elapsed, atomic.LoadInt64(&r.active),
+		atomic.LoadInt64(&r.requests), float64(atomic.LoadInt64(&r.requests))/elapsed,
+		atomic.LoadInt64(&r.matches),
+		atomic.LoadInt64(&r.rounds), float64(atomic.LoadInt64(&r.rounds))/elapsed,
+		atomic.LoadInt64(&r.disconnects))
+}
+
+type EndpointSummary struct {
+	Path     string         `json:"path"`
+	Requests int            `json:"requests"`
+	Rate     float64        `json:"rate"`
+	P50Ms    float64        `json:"p50_ms"`
+	P90Ms    float64        `json:"p90_ms"`
+	P99Ms    float64        `json:"p99_ms"`
+	MaxMs    float64        `json:"max_ms"`
+	Errors   int            `json:"errors"`
+	Timeouts int            `json:"timeouts"`
+	Codes    map[string]int `json:"codes"`
+}
+
+type Summary struct {
+	ElapsedSecond float64            `json:"elapsed_second"`
+	Requests      int64              `json:"requests"`
+	RequestRate   float64            `json:"request_rate"`
+	Matches       int64              `json:"matches"`
+	Rounds        int64              `json:"rounds"`
+	RoundRate     float64            `json:"round_rate"`
+	Disconnects   int64              `json:"disconnects"`
+	Endpoints     []*EndpointSummary `json:"endpoints"`
+}
+
+func percentile(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(float64(len(sorted)-1) * p)
+	return float64(sorted[i]) / float64(time.Millisecond)
+}
+
+func (r *Report) Summary() *Summary {
+	s := &Summary{}
+	s.ElapsedSecond = time.Since(r.begin).Seconds()
+	s.Requests = atomic.LoadInt64(&r.requests)
+	s.RequestRate = float64(s.Requests) / s.ElapsedSecond
+	s.Matches = atomic.LoadInt64(&r.matches)
+	s.Rounds = atomic.LoadInt64(&r.rounds)
+	s.RoundRate = float64(s.Rounds) / s.ElapsedSecond
+	s.Disconnects = atomic.LoadInt64(&r.disconnects)
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	for path, es := range r.endpoints {
+		sorted := make([]time.Duration, len(es.latencies))
+		copy(sorted, es.latencies)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		e := &EndpointSummary{Path: path, Requests: len(sorted), Errors: es.errors, Timeouts: es.timeouts}
+		e.Rate = float64(e.Requests) / s.ElapsedSecond
+		e.P50Ms = percentile(sorted, 0.50)
+		e.P90Ms = percentile(sorted, 0.90)
+		e.P99Ms = percentile(sorted, 0.99)
+		e.MaxMs = percentile(sorted, 1)
+		e.Codes = make(map[string]int)
+		for code, n := range es.codes {
+			e.Codes[strconv.Itoa(code)] = n
+		}
+		s.Endpoints = append(s.Endpoints, e)
+	}
+
+	sort.Slice(s.Endpoints, func(i, j int) bool { return s.Endpoints[i].Path < s.Endpoints[j].Path })
+	return s
+}
+
+func (s *Summary) JSON() []byte {
+	v, _ := json.MarshalIndent(s, "", "  ")
+	return v
+}
+
+func (s *Summary) Print(w io.Writer) {
+	fmt.Fprintf(w, "elapsed %.1fs, %d requests (%.1f/s), %d matches, %d rounds (%.1f/s), %d disconnects\n\n",
+		s.ElapsedSecond, s.Requests, s.RequestRate, s.Matches, s.Rounds, s.RoundRate, s.Disconnects)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PATH\tREQUESTS\tRATE\tP50\tP90\tP99\tMAX\tERRORS\tTIMEOUTS\tCODES")
+	for _, e := range s.Endpoints {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f/s\t%.1fms\t%.1fms\t%.1fms\t%.1fms\t%d\t%d\t%s\n",
+			e.Path, e.Requests, e.Rate, e.P50Ms, e.P90Ms, e.P99Ms, e.MaxMs, e.Errors, e.Timeouts, formatCodes(e.Codes))
+	}
+	tw.Flush()
+}
+
+// 例如 0:1200 -1:3
+func formatCodes(codes map[string]int) string {
+	keys := make([]int, 0, len(codes))
+	for k := range codes {
+		code, _ := strconv.Atoi(k)
+		keys = append(keys, code)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(keys)))
+
+	s := ""
+	for _, code := range keys {
+		if s != "" {
+			s += " "
+		}
+		s += fmt.Sprintf("%d:%d", code, codes[strconv.Itoa(code)])
+	}
+	if s == "" {
+		return "-"
+	}
+	return s
+}
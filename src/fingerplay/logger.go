@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "code.google.com/p/log4go"
+)
+
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+
+	HeaderRequestId    = "X-Request-Id"
+	UserValueRequestId = "request_id"
+)
+
+var (
+	logLevels = map[string]log.Level{
+		"debug": log.DEBUG,
+		"info":  log.INFO,
+		"warn":  log.WARNING,
+		"error": log.ERROR,
+	}
+
+	logLevelNames = map[log.Level]string{
+		log.FINEST:   "FNST",
+		log.FINE:     "FINE",
+		log.DEBUG:    "DEBG",
+		log.TRACE:    "TRAC",
+		log.INFO:     "INFO",
+		log.WARNING:  "WARN",
+		log.ERROR:    "EROR",
+		log.CRITICAL: "CRIT",
+	}
+
+	// json格式使用完整的级别名
+	logLevelJSONNames = map[log.Level]string{
+		log.FINEST:   "finest",
+		log.FINE:     "fine",
+		log.DEBUG:    "debug",
+		log.TRACE:    "trace",
+		log.INFO:     "info",
+		log.WARNING:  "warn",
+		log.ERROR:    "error",
+		log.CRITICAL: "critical",
+	}
+
+	// 运行时可调整的日志级别
+	logLevel = int32(log.DEBUG)
+
+	// 日志中的访问令牌和session ticket只保留前几位，覆盖%#v、json、%q转义后的json和k=v几种写法
+	logTokenPattern = regexp.MustCompile(`(?i)((?:access_?token|\b(?:session_?)?ticket)\\?"?\s*[:=]\s*\\?"?)([0-9a-z_.\-]+)`)
+
+	defaultLogWriter *logWriter
+)
+
+// 只保留令牌的前4位，用于关联日志
+func redactToken(token string) string {
+	if len(token) <= 4 {
+		return "***"
+	}
+	return token[:4] + "***"
+}
+
+func redactMessage(msg string) string {
+	return logTokenPattern.ReplaceAllStringFunc(msg, func(s string) string {
+		m := logTokenPattern.FindStringSubmatch(s)
+		return m[1] + redactToken(m[2])
+	})
+}
+
+type logField struct {
+	key   string
+	value interface{}
+}
+
+// logWriter替换log4go默认的控制台输出，所有日志都经过级别过滤和令牌脱敏
+type logWriter struct {
+	mux    sync.Mutex
+	out    io.Writer
+	format string
+}
+
+func (w *logWriter) LogWrite(rec *log.LogRecord) {
+	w.write(rec.Level, rec.Created, rec.Source, rec.Message, nil)
+}
+
+func (w *logWriter) Close() {}
+
+func (w *logWriter) write(level log.Level, ts time.Time, source, msg string, fields []logField) {
+	if int32(level) < atomic.LoadInt32(&logLevel) {
+		return
+	}
+
+	msg = redactMessage(msg)
+
+	var buf bytes.Buffer
+	if w.format == LogFormatText {
+		fmt.Fprintf(&buf, "[%s] [%s] (%s) %s", ts.Format("2006/01/02 15:04:05 MST"), logLevelNames[level], source, msg)
+		for _, f := range fields {
+			fmt.Fprintf(&buf, " %s=%v", f.key, f.value)
+		}
+	} else {
+		buf.WriteString(`{"ts":`)
+		writeJSONValue(&buf, ts.Format(time.RFC3339Nano))
+		buf.WriteString(`,"level":`)
+		writeJSONValue(&buf, logLevelJSONNames[level])
+		buf.WriteString(`,"src":`)
+		writeJSONValue(&buf, source)
+		buf.WriteString(`,"msg":`)
+		writeJSONValue(&buf, msg)
+		for _, f := range fields {
+			buf.WriteByte(',')
+			writeJSONValue(&buf, f.key)
+			buf.WriteByte(':')
+			writeJSONValue(&buf, f.value)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte('\n')
+
+	w.mux.Lock()
+	w.out.Write(buf.Bytes())
+	w.mux.Unlock()
+}
+
+func writeJSONValue(buf *bytes.Buffer, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(fmt.Sprint(v))
+	}
+	buf.Write(b)
+}
+
+// 用logWriter替换log4go的输出，format为json或text
+func InitLog(format, level string) (err error) {
+	if err = SetLogLevel(level); err != nil {
+		return
+	}
+
+	defaultLogWriter = &logWriter{out: os.Stdout, format: format}
+	log.Global.Close()
+	log.AddFilter("stdout", log.FINEST, defaultLogWriter)
+	return
+}
+
+func SetLogLevel(level string) (err error) {
+	lvl, ok := logLevels[level]
+	if !ok {
+		return fmt.Errorf("bad log level %q", level)
+	}
+	atomic.StoreInt32(&logLevel, int32(lvl))
+	return
+}
+
+// Log带有关联字段，例如request_id、match_id、round、uid
+type Log struct {
+	fields []logField
+}
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+// 返回带有新字段的副本，nil和空字符串不记录
+func (l *Log) With(key string, value interface{}) *Log {
+	if s, ok := value.(string); value == nil || ok && s == "" {
+		return l
+	}
+	_l := &Log{fields: make([]logField, len(l.fields), len(l.fields)+1)}
+	copy(_l.fields, l.fields)
+	_l.fields = append(_l.fields, logField{key: key, value: value})
+	return _l
+}
+
+func (l *Log) Debug(format string, args ...interface{}) { l.log(log.DEBUG, format, args...) }
+func (l *Log) Info(format string, args ...interface{})  { l.log(log.INFO, format, args...) }
+func (l *Log) Warn(format string, args ...interface{})  { l.log(log.WARNING, format, args...) }
+func (l *Log) Error(format string, args ...interface{}) { l.log(log.ERROR, format, args...) }
+
+func (l *Log) log(level log.Level, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+
+	// InitLog之前仍然使用log4go的默认输出
+	if defaultLogWriter == nil {
+		for _, f := range l.fields {
+			msg += fmt.Sprintf(" %s=%v", f.key, f.value)
+		}
+		log.Log(level, logSource(3), msg)
+		return
+	}
+
+	defaultLogWriter.write(level, time.Now(), logSource(3), msg, l.fields)
+}
+
+func logSource(skip int) string {
+	pc, _, line, ok := runtime.Caller(skip)
+	if !ok {
+		return "?"
+	}
+	return fmt.Sprintf("%s:%d", runtime.FuncForPC(pc).Name(), line)
+}
@@ -1,14 +1,19 @@
 package main
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
-	"math/rand"
 	"sort"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	log "code.google.com/p/log4go"
 )
 
@@ -24,6 +29,10 @@ type Logic interface {
 	Leave(request *LeaveRequest, response *LeaveResponse) (err error)
 	Ranking(request *RankingRequest, response *RankingResponse) (err error)
 	OnlineNumber(request *OnlineNumberRequest, response *OnlineNumberResponse) (err error)
+	Stats(request *StatsRequest, response *StatsResponse) (err error)
+	Leaderboard(request *LeaderboardRequest, response *LeaderboardResponse) (err error)
+	Drain(deadline time.Time)
+	Reload(cfg *Config)
 }
 
 func (impl *LogicImpl) OnlineNumber(request *OnlineNumberRequest, response *OnlineNumberResponse) (err error) {
@@ -64,32 +73,43 @@ func (impl *LogicImpl) OnlineNumber(request *OnlineNumberRequest, response *Onli
 }
 
 func (impl *LogicImpl) Leave(request *LeaveRequest, response *LeaveResponse) (err error) {
+	span := request.StartSpan("LogicImpl.Leave")
+	defer func() { endSpan(span, response.Code, err) }()
+
 	defer func() {
 		if response.Code != ResponseCodeOK {
-			log.Error("[%s] Leave => [%s]", getCodeDescription(response.Code), request.AccessToken)
+			NewLog().With("request_id", request.RequestId).With("match_id", request.MatchId).With("access_token", redactToken(request.AccessToken)).
+				Error("[%s] Leave", getCodeDescription(response.Code))
 		}
 	}()
 
-	ms := impl.getMatchSession(request.MatchId)
+	ms, err := impl.lookupMatchSession(request.MatchId)
+	if err != nil {
+		return
+	}
 	if ms == nil {
 		response.Code = ResponseCodeBadMatchId
 		return ErrMatchId
 	}
 
-	if cp := ms.getCompetitor(request.AccessToken); cp != nil {
-		cp.Leave()
-	} else {
-		response.Code = ResponseCodeBadAccessToken
-		return ErrAccessToken
+	cp, code, err := impl.authorize(ms, &request.Traced, request.AccessToken, request.SessionTicket)
+	if cp == nil {
+		response.Code = code
+		return
 	}
+	cp.Leave()
 
 	return
 }
 
 func (impl *LogicImpl) Ranking(request *RankingRequest, response *RankingResponse) (err error) {
+	span := request.StartSpan("LogicImpl.Ranking")
+	defer func() { endSpan(span, response.Code, err) }()
+
 	defer func() {
 		if response.Code != ResponseCodeOK {
-			log.Error("[%s] Ranking => [%s]", getCodeDescription(response.Code), request.AccessToken)
+			NewLog().With("request_id", request.RequestId).With("access_token", redactToken(request.AccessToken)).
+				Error("[%s] Ranking", getCodeDescription(response.Code))
 		}
 	}()
 
@@ -97,26 +117,178 @@ func (impl *LogicImpl) Ranking(request *RankingRequest, response *RankingRespons
 		results []*ResultLog
 	)
 
-	if results, err = DefaultStatisticsManager.Ranking(); err != nil {
+	if results, err = impl.statistics.Ranking(); err != nil {
 		response.Code = ResponseCodeInternalError
 	} else {
 		response.Data.Results = results
 	}
 
-	if len(results) < RankingLimit {
-		response.Data.Results = append(response.Data.Results, Conf.FakeRanking[:RankingLimit-len(results)]...)
-	}
-
 	// SORT
 	sort.Sort(response.Data)
 
 	return
 }
 
+func (impl *LogicImpl) Leaderboard(request *LeaderboardRequest, response *LeaderboardResponse) (err error) {
+	span := request.StartSpan("LogicImpl.Leaderboard")
+	defer func() { endSpan(span, response.Code, err) }()
+
+	defer func() {
+		if response.Code != ResponseCodeOK {
+			NewLog().With("request_id", request.RequestId).With("window", request.Window).With("level", request.Level).With("access_token", redactToken(request.AccessToken)).
+				Error("[%s] Leaderboard", getCodeDescription(response.Code))
+		}
+	}()
+
+	if request.Window == "" {
+		request.Window = LeaderboardWindowAll
+	}
+
+	if !isLeaderboardWindow(request.Window) {
+		response.Code = ResponseCodeBadRequestFormat
+		return ErrLeaderboardWindow
+	}
+
+	if request.Level != 0 && impl.getWaitingList(request.Level) == nil {
+		response.Code = ResponseCodeBadLevel
+		return ErrLevel
+	}
+
+	current := getLeaderboardPeriod(request.Window, impl.clock.Now())
+	if request.Period == "" {
+		request.Period = current
+	}
+
+	if request.Offset < 0 {
+		request.Offset = 0
+	}
+
+	if request.Limit <= 0 {
+		request.Limit = RankingLimit
+	} else if request.Limit > LeaderboardMaxLimit {
+		request.Limit = LeaderboardMaxLimit
+	}
+
+	if request.Neighbours > LeaderboardMaxNeighbours {
+		request.Neighbours = LeaderboardMaxNeighbours
+	}
+
+	lb := impl.statistics.Leaderboard()
+
+	data := &response.Data
+	data.Window = request.Window
+	data.Period = request.Period
+	data.Level = request.Level
+
+	if data.Entries, data.Total, err = lb.Top(request.Window, request.Period, request.Level, request.Offset, request.Limit); err != nil {
+		response.Code = ResponseCodeInternalError
+		return
+	}
+
+	// 只有当前周期才有"我的排名"
+	if request.AccessToken == "" || request.Period != current {
+		return
+	}
+
+	_request := &DescribeUserRequest{}
+	_request.AccessToken = request.AccessToken
+	_request.Traced = request.Traced
+
+	_response := &DescribeUserResponse{}
+
+	if err = impl.accountManager.ValidateToken(_request, _response); err != nil || _response.Code != ResponseCodeOK {
+		log.Error("ValidateToken(%#v, %#v) failed: %s", _request, _response, err)
+		response.Code = ResponseCodeBadAccessToken
+		return ErrAccessToken
+	}
+
+	var (
+		above, below []*LeaderboardEntry
+	)
+
+	if data.Me, above, below, err = lb.Rank(request.Window, request.Level, _response.Data.Uid, request.Neighbours); err != nil {
+		response.Code = ResponseCodeInternalError
+		return
+	}
+
+	data.Neighbours = append(above, below...)
+
+	return
+}
+
+func (impl *LogicImpl) Stats(request *StatsRequest, response *StatsResponse) (err error) {
+	span := request.StartSpan("LogicImpl.Stats")
+	defer func() { endSpan(span, response.Code, err) }()
+
+	defer func() {
+		if response.Code != ResponseCodeOK {
+			NewLog().With("request_id", request.RequestId).With("uid", request.Uid).With("access_token", redactToken(request.AccessToken)).
+				Error("[%s] Stats", getCodeDescription(response.Code))
+		}
+	}()
+
+	uid := request.Uid
+
+	// 不指定uid时查询自己的数据
+	if uid == 0 {
+		_request := &DescribeUserRequest{}
+		_request.AccessToken = request.AccessToken
+		_request.Traced = request.Traced
+
+		_response := &DescribeUserResponse{}
+
+		if err = impl.accountManager.ValidateToken(_request, _response); err != nil || _response.Code != ResponseCodeOK {
+			log.Error("ValidateToken(%#v, %#v) failed: %s", _request, _response, err)
+			response.Code = ResponseCodeBadAccessToken
+			return ErrAccessToken
+		}
+
+		uid = _response.Data.Uid
+	}
+
+	var (
+		ps *PlayerStats
+	)
+
+	if ps, err = impl.playerStats.Get(uid); err != nil {
+		response.Code = ResponseCodeInternalError
+		return
+	}
+
+	data := &response.Data
+	data.Uid = ps.Uid
+	data.Rounds = ps.Rounds
+	data.Wins = ps.Wins
+	data.Losses = ps.Losses
+	data.Draws = ps.Draws
+	if ps.Rounds > 0 {
+		data.WinRate = Math_round(float64(ps.Wins)/float64(ps.Rounds), 4)
+	}
+	data.Moves = ps.Moves
+	data.FavouriteMove = ps.Moves.Favourite()
+	data.LongestWinStreak = ps.LongestWinStreak
+	data.LongestLossStreak = ps.LongestLossStreak
+	data.LevelNet = make(map[int]float64)
+	for k, v := range ps.LevelNet {
+		if lv, e := strconv.Atoi(k); e == nil {
+			data.LevelNet[lv] = v
+			data.Net += v
+		}
+	}
+	data.FirstPlayed = ps.FirstPlayed
+	data.LastPlayed = ps.LastPlayed
+
+	return
+}
+
 func (impl *LogicImpl) Ready(request *ReadyRequest, response *ReadyResponse) (err error) {
+	span := request.StartSpan("LogicImpl.Ready")
+	defer func() { endSpan(span, response.Code, err) }()
+
 	defer func() {
 		if response.Code != ResponseCodeOK {
-			log.Error("[%s] Ready => [%s][%s]", getCodeDescription(response.Code), request.AccessToken, getOperateDescription(request.Operate))
+			NewLog().With("request_id", request.RequestId).With("match_id", request.MatchId).With("round", request.Round).With("access_token", redactToken(request.AccessToken)).
+				Error("[%s] Ready => [%s]", getCodeDescription(response.Code), getOperateDescription(request.Operate))
 		}
 	}()
 
@@ -125,7 +297,10 @@ func (impl *LogicImpl) Ready(request *ReadyRequest, response *ReadyResponse) (er
 		return ErrOperate
 	}
 
-	ms := impl.getMatchSession(request.MatchId)
+	ms, err := impl.lookupMatchSession(request.MatchId)
+	if err != nil {
+		return
+	}
 	if ms == nil {
 		response.Code = ResponseCodeBadMatchId
 		return ErrMatchId
@@ -136,19 +311,28 @@ func (impl *LogicImpl) Ready(request *ReadyRequest, response *ReadyResponse) (er
 		return ErrRound
 	}
 
-	if cp := ms.getCompetitor(request.AccessToken); cp != nil {
-		cp.KeepAlive()
-		if cp.Balance < float64(ms.Level) {
-			response.Code = ResponseCodeInsufficientBalance
-			return ErrInsufficientBalance
-		}
-	} else {
-		response.Code = ResponseCodeBadAccessToken
-		return ErrAccessToken
+	cp, code, err := impl.authorize(ms, &request.Traced, request.AccessToken, request.SessionTicket)
+	if cp == nil {
+		response.Code = code
+		return
+	}
+	cp.KeepAlive(impl.clock.Now().Unix())
+	if cp.Balance < float64(ms.Level) {
+		response.Code = ResponseCodeInsufficientBalance
+		return ErrInsufficientBalance
+	}
+	// 停机时只允许对手已经出拳的回合完成结算
+	if impl.isDraining() && ms.getOpponentStatus(request.AccessToken) != CompetitorStatusReady {
+		response.Code = ResponseCodeMaintenance
+		return
 	}
 
 	ch := ms.waitReady(request, impl)
+
+	// 等待对手出拳，后出拳的一方已经在waitReady中完成了结算
+	_, wait := startSpan(request.Context(), "MatchSession.waitOpponent")
 	*response = *(<-ch)
+	wait.End()
 
 	if response.Code != ResponseCodeOK {
 		close(ch)
@@ -158,48 +342,73 @@ func (impl *LogicImpl) Ready(request *ReadyRequest, response *ReadyResponse) (er
 }
 
 func (impl *LogicImpl) ReadyStatus(request *ReadyStatusRequest, response *ReadyStatusResponse) (err error) {
+	span := request.StartSpan("LogicImpl.ReadyStatus")
+	defer func() { endSpan(span, response.Code, err) }()
+
 	defer func() {
 		if response.Code != ResponseCodeOK {
-			log.Error("[%s] ReadyStatus => [%s]", getCodeDescription(response.Code), request.AccessToken)
+			NewLog().With("request_id", request.RequestId).With("match_id", request.MatchId).With("access_token", redactToken(request.AccessToken)).
+				Error("[%s] ReadyStatus", getCodeDescription(response.Code))
 		}
 	}()
 
-	ms := impl.getMatchSession(request.MatchId)
+	ms, err := impl.lookupMatchSession(request.MatchId)
+	if err != nil {
+		return
+	}
 	if ms == nil {
 		response.Code = ResponseCodeBadMatchId
 		return ErrMatchId
 	}
 
-	if cp := ms.getCompetitor(request.AccessToken); cp != nil {
-		cp.KeepAlive()
-		response.Data.Status = ms.getOpponentStatus(request.AccessToken)
-	} else {
-		response.Code = ResponseCodeBadAccessToken
-		return ErrAccessToken
+	cp, code, err := impl.authorize(ms, &request.Traced, request.AccessToken, request.SessionTicket)
+	if cp == nil {
+		response.Code = code
+		return
 	}
+	cp.KeepAlive(impl.clock.Now().Unix())
+	response.Data.Status = ms.getOpponentStatus(request.AccessToken)
 
 	return
 }
 
 func (impl *LogicImpl) Match(request *MatchRequest, response *MatchResponse) (err error) {
+	span := request.StartSpan("LogicImpl.Match")
+	defer func() { endSpan(span, response.Code, err) }()
+
 	defer func() {
 		if response.Code != ResponseCodeOK {
-			log.Error("[%s] Match => [%d][%s]", getCodeDescription(response.Code), request.Level, request.AccessToken)
+			NewLog().With("request_id", request.RequestId).With("level", request.Level).With("access_token", redactToken(request.AccessToken)).
+				Error("[%s] Match", getCodeDescription(response.Code))
 		}
 	}()
 
+	if impl.isDraining() {
+		response.Code = ResponseCodeMaintenance
+		return
+	}
+
 	wl := impl.getWaitingList(request.Level)
 	if wl == nil {
 		response.Code = ResponseCodeBadLevel
 		return ErrLevel
 	}
 
+	if wl.isPaused() {
+		response.Code = ResponseCodeMaintenance
+		return
+	}
+
 	_request := &DescribeUserRequest{}
 	_request.AccessToken = request.AccessToken
+	_request.Traced = request.Traced
 
 	_response := &DescribeUserResponse{}
 
-	if err = impl.accountManager.DescribeUser(_request, _response); err != nil || _response.Code != ResponseCodeOK {
+	if err = impl.accountManager.DescribeUser(_request, _response); err == ErrTokenRevoked {
+		response.Code = ResponseCodeBadAccessToken
+		return
+	} else if err != nil || _response.Code != ResponseCodeOK {
 		log.Error("DescribeUser(%#v, %#v) failed: %s", _request, _response, err)
 		response.Code = ResponseCodeInternalError
 		return
@@ -210,7 +419,11 @@ func (impl *LogicImpl) Match(request *MatchRequest, response *MatchResponse) (er
 		return ErrInsufficientBalance
 	}
 
-	ch := wl.WaitMatch(_response.Data.Uid, _response.Data.Balance, request.AccessToken, _response.Data.Nickname, _response.Data.FbOpenId)
+	wd := NewWaitingData(_response.Data.Uid, impl.conf.Get().isMan(_response.Data.Uid), _response.Data.Balance, request.AccessToken, _response.Data.Nickname, _response.Data.FbOpenId, impl.clock.Now().Unix())
+	wd.ip = request.ClientIp
+	wd.deviceId = request.DeviceId
+
+	ch := wl.WaitMatch(impl.cluster, wd)
 	*(response) = *(<-ch)
 	close(ch)
 
@@ -218,65 +431,235 @@ func (impl *LogicImpl) Match(request *MatchRequest, response *MatchResponse) (er
 }
 
 type LogicImpl struct {
-	accountManager       *AccountManager
+	accountManager       Accounts
+	robots               Robots
+	cluster              *Cluster
+	statistics           *StatisticsManager
+	playerStats          *PlayerStatsManager
+	history              *HistoryManager
+	risk                 *RiskController
+	events               *EventHub
+	fraud                *FraudDetector
+	conf                 *ConfigValue
+	waitingMux           sync.RWMutex
 	waitingListMap       map[int]*WaitingList
 	matchMux             sync.RWMutex
 	matchSessionMap      map[string]*MatchSession
-	matchWaitSecond      int
-	operateTimeoutSecond int
-	rand                 *rand.Rand
+	matchWaitSecond      int64
+	operateTimeoutSecond int64
+	clock                Clock
+	rand                 Rand
+	draining             int32
+	matchLoopTs          int64
+	cleanLoopTs          int64
 }
 
-func NewLogicImpl(accountManager *AccountManager, levels []int, operateTimeoutSecond, matchWaitSecond int) Logic {
+// 机器人、集群、统计等依赖由Server在Start之前设置，cluster为nil时是单机模式
+func NewLogicImpl(accountManager Accounts, levels []int, operateTimeoutSecond, matchWaitSecond int) *LogicImpl {
 	impl := &LogicImpl{}
 	impl.accountManager = accountManager
+	impl.conf = confValue
 	impl.waitingListMap = make(map[int]*WaitingList)
-	impl.rand = rand.New(rand.NewSource(time.Now().Unix()))
+	impl.clock = DefaultClock
+	impl.rand = newRand(impl.clock)
 	for _, lv := range levels {
 		impl.waitingListMap[lv] = NewWaitingList(lv)
 	}
 	impl.matchSessionMap = make(map[string]*MatchSession)
-	impl.matchWaitSecond = matchWaitSecond
-	impl.operateTimeoutSecond = operateTimeoutSecond
+	impl.matchWaitSecond = int64(matchWaitSecond)
+	impl.operateTimeoutSecond = int64(operateTimeoutSecond)
+	impl.matchLoopTs = impl.clock.Now().UnixNano()
+	impl.cleanLoopTs = impl.matchLoopTs
+	return impl
+}
+
+// 随机数的种子跟随时钟，使用ManualClock时匹配和机器人的等待时间可以重现
+func (impl *LogicImpl) setClock(clock Clock) {
+	impl.clock = clock
+	impl.rand = newRand(clock)
+	impl.matchLoopTs = clock.Now().UnixNano()
+	impl.cleanLoopTs = impl.matchLoopTs
+}
+
+func (impl *LogicImpl) Start() {
 	go impl.matchLoop()
 	go impl.cleanLoop()
+}
 
-	return impl
+func (impl *LogicImpl) isDraining() bool {
+	return atomic.LoadInt32(&impl.draining) == 1
+}
+
+// 停机前排空：拒绝新的匹配，通知等待中的玩家，等待进行中的回合结算，
+// 到deadline后结束剩余的比赛
+func (impl *LogicImpl) Drain(deadline time.Time) {
+	if !atomic.CompareAndSwapInt32(&impl.draining, 0, 1) {
+		return
+	}
+
+	response := &MatchResponse{Code: ResponseCodeMaintenance}
+	for _, wl := range impl.getWaitingLists() {
+		wl.drain(response)
+	}
+	if impl.cluster.Enabled() {
+		impl.cluster.Drain(response)
+	}
+
+	for impl.clock.Now().Before(deadline) {
+		n := impl.pendingRounds()
+		if n == 0 {
+			break
+		}
+		log.Info("Drain: waiting for %d rounds to settle", n)
+		impl.clock.Sleep(500 * time.Millisecond)
+	}
+
+	impl.matchMux.Lock()
+	disposed := []string{}
+	for id, ms := range impl.matchSessionMap {
+		delete(impl.matchSessionMap, id)
+		impl.closeMatch(ms, ResponseCodeMaintenance)
+		disposed = append(disposed, id)
+	}
+	impl.matchMux.Unlock()
+
+	if impl.cluster.Enabled() {
+		for _, id := range disposed {
+			impl.cluster.DeleteMatch(id)
+		}
+	}
+
+	log.Info("Drain: %d matches closed", len(disposed))
+}
+
+// 有玩家已经出拳、正在等待对手的回合数
+func (impl *LogicImpl) pendingRounds() (n int) {
+	impl.matchMux.RLock()
+	for _, ms := range impl.matchSessionMap {
+		for _, cp := range ms.Competitors {
+			if cp.IsReady() {
+				n++
+				break
+			}
+		}
+	}
+	impl.matchMux.RUnlock()
+	return
+}
+
+// 匹配和清理循环在stale时间内都运行过
+func (impl *LogicImpl) loopsAlive(now time.Time, stale time.Duration) bool {
+	for _, ts := range []int64{atomic.LoadInt64(&impl.matchLoopTs), atomic.LoadInt64(&impl.cleanLoopTs)} {
+		if now.Sub(time.Unix(0, ts)) > stale {
+			return false
+		}
+	}
+	return true
+}
+
+func (impl *LogicImpl) getOperateTimeoutSecond() int {
+	return int(atomic.LoadInt64(&impl.operateTimeoutSecond))
+}
+
+// 热更新配置：新的超时对之后的回合生效，新增的等级创建等待队列，
+// 删除的等级通知等待中的玩家，进行中的比赛不受影响
+func (impl *LogicImpl) Reload(cfg *Config) {
+	atomic.StoreInt64(&impl.operateTimeoutSecond, int64(cfg.OperateTimeoutSecond))
+	atomic.StoreInt64(&impl.matchWaitSecond, int64(cfg.MatchWaitSecond))
+
+	levels := make(map[int]bool)
+	for _, lv := range cfg.Levels {
+		levels[lv] = true
+	}
+
+	removed := []*WaitingList{}
+	impl.waitingMux.Lock()
+	for lv := range levels {
+		if _, ok := impl.waitingListMap[lv]; !ok {
+			impl.waitingListMap[lv] = NewWaitingList(lv)
+			log.Info("Reload: level %d added", lv)
+		}
+	}
+	for lv, wl := range impl.waitingListMap {
+		if !levels[lv] {
+			delete(impl.waitingListMap, lv)
+			removed = append(removed, wl)
+			log.Info("Reload: level %d removed", lv)
+		}
+	}
+	impl.waitingMux.Unlock()
+
+	response := &MatchResponse{Code: ResponseCodeMaintenance}
+	for _, wl := range removed {
+		wl.drain(response)
+		if impl.cluster.Enabled() {
+			impl.cluster.DrainLevel(wl.level, response)
+		}
+	}
+}
+
+func (impl *LogicImpl) getWaitingLists() (wls []*WaitingList) {
+	impl.waitingMux.RLock()
+	for _, wl := range impl.waitingListMap {
+		wls = append(wls, wl)
+	}
+	impl.waitingMux.RUnlock()
+	return
 }
 
 func (impl *LogicImpl) getMatchWaitSecond() int {
-	return impl.matchWaitSecond - impl.rand.Intn(6)
+	return int(atomic.LoadInt64(&impl.matchWaitSecond)) - impl.rand.Intn(6)
 }
 
 func (impl *LogicImpl) onlineNumber() int {
 	impl.matchMux.RLock()
 	n := len(impl.matchSessionMap)
 	impl.matchMux.RUnlock()
-	return Conf.BaseOnlineNumbers[time.Now().Hour()%24] + n
+	return impl.conf.Get().BaseOnlineNumbers[impl.clock.Now().Hour()%24] + n
 }
 
 func (impl *LogicImpl) matchLoop() {
 	for {
-		time.Sleep(1 * time.Second)
-		now := time.Now().Unix()
-		for _, wl := range impl.waitingListMap {
+		impl.clock.Sleep(1 * time.Second)
+		atomic.StoreInt64(&impl.matchLoopTs, impl.clock.Now().UnixNano())
+		now := impl.clock.Now().Unix()
+		for _, wl := range impl.getWaitingLists() {
 			wl.match(impl, now)
 		}
+		if impl.cluster.Enabled() {
+			impl.cluster.sweepTickets(now)
+			impl.cluster.match(now)
+		}
 	}
 }
 
 func (impl *LogicImpl) cleanLoop() {
-	for {
-		time.Sleep(1 * time.Second)
-		now := time.Now().Unix()
+	for i := 1; ; i++ {
+		impl.clock.Sleep(1 * time.Second)
+		atomic.StoreInt64(&impl.cleanLoopTs, impl.clock.Now().UnixNano())
+		now := impl.clock.Now().Unix()
+		disposed := []string{}
+		sessions := []*MatchSession{}
 		impl.matchMux.Lock()
 		for id, ms := range impl.matchSessionMap {
-			if ms.clean(now, int64(impl.operateTimeoutSecond+3)) > 0 {
+			if ms.clean(now, int64(impl.getOperateTimeoutSecond()+3)) > 0 {
 				delete(impl.matchSessionMap, id)
-				ms.dispose()
+				impl.closeMatch(ms, ResponseCodeWaitReadyTimeout)
+				disposed = append(disposed, id)
+			} else {
+				sessions = append(sessions, ms)
 			}
 		}
 		impl.matchMux.Unlock()
+
+		if impl.cluster.Enabled() {
+			for _, id := range disposed {
+				impl.cluster.DeleteMatch(id)
+			}
+			if i%10 == 0 {
+				impl.cluster.RefreshMatches(sessions)
+			}
+		}
 	}
 }
 
@@ -287,6 +670,35 @@ func (impl *LogicImpl) getMatchSession(matchId string) (ms *MatchSession) {
 	return
 }
 
+// 本地找不到时到集群中查找，比赛在其他节点上时返回RemoteMatchError
+func (impl *LogicImpl) lookupMatchSession(matchId string) (ms *MatchSession, err error) {
+	if ms = impl.getMatchSession(matchId); ms != nil || !impl.cluster.Enabled() {
+		return
+	}
+
+	if ms, err = impl.cluster.Route(matchId); err != nil {
+		if _, ok := err.(*RemoteMatchError); !ok {
+			log.Error("Cluster route match %s failed: %s", matchId, err)
+			ms, err = nil, nil
+		}
+	}
+	return
+}
+
+// 接管其他节点的比赛，已经存在时返回已有的比赛
+func (impl *LogicImpl) adoptMatchSession(ms *MatchSession) *MatchSession {
+	impl.matchMux.Lock()
+	if _ms, ok := impl.matchSessionMap[ms.MatchId]; ok {
+		impl.matchMux.Unlock()
+		return _ms
+	}
+	impl.matchSessionMap[ms.MatchId] = ms
+	impl.matchMux.Unlock()
+
+	impl.cluster.SaveMatch(ms)
+	return ms
+}
+
 func (impl *LogicImpl) onMatchSuccess(level int, matchId string, round int, competitor1, competitor2 *Competitor) (err error) {
 	impl.matchMux.Lock()
 	defer impl.matchMux.Unlock()
@@ -297,19 +709,30 @@ func (impl *LogicImpl) onMatchSuccess(level int, matchId string, round int, comp
 		return ErrMatchId
 	}
 
-	impl.matchSessionMap[matchId] = NewMatchSession(level, matchId, round, competitor1, competitor2)
+	impl.matchSessionMap[matchId] = NewMatchSession(level, matchId, round, competitor1, competitor2, impl.clock.Now().Unix())
 
 	return
 }
 
-func (impl *LogicImpl) getWaitingList(lv int) *WaitingList { return impl.waitingListMap[lv] }
+func (impl *LogicImpl) getWaitingList(lv int) (wl *WaitingList) {
+	impl.waitingMux.RLock()
+	wl = impl.waitingListMap[lv]
+	impl.waitingMux.RUnlock()
+	return
+}
 
 func (impl *LogicImpl) allowOperate(op int) bool {
 	return op == Stone || op == Paper || op == Scissors
 }
 
-func (wl *WaitingList) WaitMatch(uid int, balance float64, accessToken, nickname, fbOpenId string) chan *MatchResponse {
-	wd := NewWaitingData(uid, balance, accessToken, nickname, fbOpenId, time.Now().Unix())
+func (wl *WaitingList) WaitMatch(cluster *Cluster, wd *WaitingData) chan *MatchResponse {
+	if cluster.Enabled() {
+		if err := cluster.PushWaiting(wl.level, wd); err == nil {
+			return wd.ch
+		} else {
+			log.Error("Cluster push waiting failed, fallback to local: %s", err)
+		}
+	}
 	wl.push(wd)
 	return wd.ch
 }
@@ -320,6 +743,22 @@ func (wl *WaitingList) push(wd *WaitingData) {
 	wl.mux.Unlock()
 }
 
+func (wl *WaitingList) drain(response *MatchResponse) {
+	wl.mux.Lock()
+	for _, wd := range wl.list {
+		wd.Notify(response)
+	}
+	wl.list = nil
+	wl.mux.Unlock()
+}
+
+func (wl *WaitingList) Len() (n int) {
+	wl.mux.Lock()
+	n = len(wl.list)
+	wl.mux.Unlock()
+	return
+}
+
 func (wl *WaitingList) shift() (wd *WaitingData) {
 	wl.mux.Lock()
 	if len(wl.list) > 0 {
@@ -331,21 +770,34 @@ func (wl *WaitingList) shift() (wd *WaitingData) {
 }
 
 func (wl *WaitingList) match(impl *LogicImpl, now int64) {
+	if wl.isPaused() {
+		return
+	}
+
 	wl.mux.Lock()
+	// 被拦截的玩家暂时放在一边，本轮不再匹配
+	var skipped []*WaitingData
 	for len(wl.list) >= 2 {
+		if !impl.fraud.pickOpponent(waitingQueue(wl.list)) {
+			skipped = append(skipped, wl.list[0])
+			wl.list = wl.list[1:]
+			continue
+		}
 		wl.matchOnce(impl)
 	}
+	wl.list = append(skipped, wl.list...)
 	if len(wl.list) > 0 {
 		wl.cleanTimeout(now)
 	}
-	if len(wl.list) == 1 && wl.list[0].IsMan() && now-wl.list[0].ts > int64(impl.getMatchWaitSecond()) {
-		wl.matchAI(wl.list[0].balance)
+	// 剩下的第一个玩家没有可以匹配的对手，可能只剩他一个，也可能与其他人都被拦截
+	if len(wl.list) > 0 && wl.list[0].IsMan() && now-wl.list[0].ts > int64(impl.getMatchWaitSecond()) {
+		wl.matchAI(impl.robots, wl.list[0].balance)
 	}
 	wl.mux.Unlock()
 }
 
-func (wl *WaitingList) matchAI(balance float64) {
-	DefaultRobotManager.GoGoGo(wl.level, balance)
+func (wl *WaitingList) matchAI(robots Robots, balance float64) {
+	robots.GoGoGo(wl.level, balance)
 }
 
 func (wl *WaitingList) matchOnce(impl *LogicImpl) {
@@ -376,20 +828,30 @@ func (wl *WaitingList) matchOnce(impl *LogicImpl) {
 		return
 	}
 
+	response1, response2 := impl.createMatch(wl.level, wd1, wd2)
+
+	wd1.Notify(response1)
+	wd2.Notify(response2)
+}
+
+// 创建比赛并返回双方的匹配结果
+func (impl *LogicImpl) createMatch(level int, wd1, wd2 *WaitingData) (response1, response2 *MatchResponse) {
 	competitor1 := &Competitor{
 		readyCh:     make(chan *ReadyResponse, 1),
 		status:      CompetitorStatusIdle,
 		uid:         wd1.uid,
+		man:         wd1.man,
 		accessToken: wd1.accessToken,
+		ticket:      GetGUID(),
 		Balance:     wd1.balance,
 		Nickname:    wd1.nickname,
 		Avatar:      getAvatarByOpenId(wd1.fbOpenId),
 	}
 
-	competitor1.KeepAlive()
+	competitor1.KeepAlive(impl.clock.Now().Unix())
 
 	if !competitor1.IsMan() {
-		robot := DefaultRobotManager.nextRobotAvatar()
+		robot := impl.robots.nextRobotAvatar()
 		competitor1.Avatar = robot.Avatar
 		competitor1.Nickname = robot.Nickname
 	}
@@ -398,16 +860,18 @@ func (wl *WaitingList) matchOnce(impl *LogicImpl) {
 		readyCh:     make(chan *ReadyResponse, 1),
 		status:      CompetitorStatusIdle,
 		uid:         wd2.uid,
+		man:         wd2.man,
 		accessToken: wd2.accessToken,
+		ticket:      GetGUID(),
 		Balance:     wd2.balance,
 		Nickname:    wd2.nickname,
 		Avatar:      getAvatarByOpenId(wd2.fbOpenId),
 	}
 
-	competitor2.KeepAlive()
+	competitor2.KeepAlive(impl.clock.Now().Unix())
 
 	if !competitor2.IsMan() {
-		robot := DefaultRobotManager.nextRobotAvatar()
+		robot := impl.robots.nextRobotAvatar()
 		competitor2.Avatar = robot.Avatar
 		competitor2.Nickname = robot.Nickname
 	}
@@ -415,11 +879,7 @@ func (wl *WaitingList) matchOnce(impl *LogicImpl) {
 	matchId := GetGUID()
 	round := 0
 
-	var (
-		response1, response2 *MatchResponse
-	)
-
-	if impl.onMatchSuccess(wl.level, matchId, round, competitor1, competitor2) != nil {
+	if impl.onMatchSuccess(level, matchId, round, competitor1, competitor2) != nil {
 		response1 = &MatchResponse{}
 		response1.Code = ResponseCodeBadMatchStatus
 		response1.Data.MatchId = ""
@@ -427,13 +887,14 @@ func (wl *WaitingList) matchOnce(impl *LogicImpl) {
 
 		response2 = response1
 	} else {
-		ts := time.Now().UnixNano() / 1000000
+		ts := impl.clock.Now().UnixNano() / 1000000
 		response1 = &MatchResponse{}
 		response1.Data.ServerTimestamp = ts
-		response1.Data.ExpireTimestamp = ts + int64(impl.operateTimeoutSecond*1000)
+		response1.Data.ExpireTimestamp = ts + int64(impl.getOperateTimeoutSecond()*1000)
 		response1.Data.MatchId = matchId
 		response1.Data.Round = round
-		response1.Data.TimeoutSecond = impl.operateTimeoutSecond
+		response1.Data.TimeoutSecond = impl.getOperateTimeoutSecond()
+		response1.Data.SessionTicket = competitor1.ticket
 		response1.Data.Competitors = append(response1.Data.Competitors, &Competitor{
 			AccessToken: wd1.accessToken,
 			Balance:     wd1.balance,
@@ -447,10 +908,11 @@ func (wl *WaitingList) matchOnce(impl *LogicImpl) {
 
 		response2 = &MatchResponse{}
 		response2.Data.ServerTimestamp = ts
-		response2.Data.ExpireTimestamp = ts + int64(impl.operateTimeoutSecond*1000)
+		response2.Data.ExpireTimestamp = ts + int64(impl.getOperateTimeoutSecond()*1000)
 		response2.Data.MatchId = matchId
 		response2.Data.Round = round
-		response2.Data.TimeoutSecond = impl.operateTimeoutSecond
+		response2.Data.TimeoutSecond = impl.getOperateTimeoutSecond()
+		response2.Data.SessionTicket = competitor2.ticket
 		response2.Data.Competitors = append(response2.Data.Competitors, &Competitor{
 			Balance:  wd1.balance,
 			Nickname: competitor1.Nickname,
@@ -461,12 +923,28 @@ func (wl *WaitingList) matchOnce(impl *LogicImpl) {
 			Nickname:    competitor2.Nickname,
 			Avatar:      competitor2.Avatar,
 		})
+
+		if ms := impl.getMatchSession(matchId); ms != nil {
+			ms.mux.Lock()
+			ms.roundMs = ts
+			ev := ms.event(MatchEventCreated, ResponseCodeOK, impl.clock.Now())
+			ms.mux.Unlock()
+			impl.events.Publish(ev)
+		}
+
+		impl.fraud.OnMatch(level, matchId, wd1, wd2, impl.clock.Now().Unix())
 	}
 
-	wd1.Notify(response1)
-	wd2.Notify(response2)
+	if impl.cluster.Enabled() {
+		if ms := impl.getMatchSession(matchId); ms != nil {
+			ms.mux.RLock()
+			impl.cluster.SaveMatch(ms)
+			ms.mux.RUnlock()
+		}
+	}
 
-	log.Debug("[OK] Match => [%d][%s][%d vs %d]", wl.level, matchId, competitor1.uid, competitor2.uid)
+	log.Debug("[OK] Match => [%d][%s][%d vs %d]", level, matchId, competitor1.uid, competitor2.uid)
+	return
 }
 
 func (wl *WaitingList) cleanTimeout(now int64) {
@@ -483,16 +961,20 @@ type WaitingData struct {
 	ts          int64
 	ch          chan *MatchResponse
 	uid         int
+	man         bool
 	balance     float64
 	nickname    string
 	fbOpenId    string
 	accessToken string
+	ip          string
+	deviceId    string
 }
 
-func NewWaitingData(uid int, balance float64, accessToken, nickname, fbOpenId string, ts int64) *WaitingData {
+func NewWaitingData(uid int, man bool, balance float64, accessToken, nickname, fbOpenId string, ts int64) *WaitingData {
 	wd := &WaitingData{}
 	wd.ts = ts
 	wd.uid = uid
+	wd.man = man
 	wd.balance = balance
 	wd.accessToken = accessToken
 	wd.nickname = nickname
@@ -502,7 +984,7 @@ func NewWaitingData(uid int, balance float64, accessToken, nickname, fbOpenId st
 }
 
 func (wd *WaitingData) IsMan() bool {
-	return wd.uid > 2000
+	return wd.man
 }
 
 func (wd *WaitingData) Before(that *WaitingData) bool {
@@ -522,14 +1004,33 @@ type MatchSession struct {
 	MatchId     string
 	Round       int
 	Competitors []*Competitor
+
+	createdTs int64
+	settled   []*SettledRound
+	// 本回合开始的时间，毫秒，接管的比赛为0
+	roundMs int64
 }
 
-func NewMatchSession(level int, matchId string, round int, competitor1, competitor2 *Competitor) *MatchSession {
+// 本节点上已经结算的回合，用于管理接口查看和退款
+type SettledRound struct {
+	Round    int        `json:"round"`
+	Result   int        `json:"result"`
+	Operates [2]int     `json:"operates"`
+	Wins     [2]float64 `json:"wins"`
+	Code     int        `json:"code"`
+	Refunded bool       `json:"refunded"`
+	Ts       int64      `json:"ts"`
+
+	transfer *TransferRequest
+}
+
+func NewMatchSession(level int, matchId string, round int, competitor1, competitor2 *Competitor, createdTs int64) *MatchSession {
 	ms := &MatchSession{}
 	ms.Level = level
 	ms.MatchId = matchId
 	ms.Round = round
 	ms.Competitors = append(ms.Competitors, competitor1, competitor2)
+	ms.createdTs = createdTs
 	return ms
 }
 
@@ -563,6 +1064,38 @@ func (ms *MatchSession) getCompetitor(accessToken string) (cp *Competitor) {
 	return
 }
 
+// 比赛中的请求：access token是这场比赛的选手，session ticket与匹配时发放的一致，
+// 并且token仍然有效。返回nil时code为需要返回的结果
+func (impl *LogicImpl) authorize(ms *MatchSession, traced *Traced, accessToken, ticket string) (cp *Competitor, code int, err error) {
+	if cp = ms.getCompetitor(accessToken); cp == nil {
+		return nil, ResponseCodeBadAccessToken, ErrAccessToken
+	}
+
+	// 关闭require_session_ticket时旧客户端可以不带ticket，但带了就必须正确
+	if ticket == "" && impl.conf.Get().RequireSessionTicket || ticket != "" && subtle.ConstantTimeCompare([]byte(ticket), []byte(cp.ticket)) != 1 {
+		return nil, ResponseCodeBadSession, ErrSessionTicket
+	}
+
+	// 机器人的账号只在本地
+	if !cp.IsMan() {
+		return
+	}
+
+	_request := &DescribeUserRequest{}
+	_request.AccessToken = accessToken
+	_request.Traced = *traced
+
+	_response := &DescribeUserResponse{}
+
+	if err = impl.accountManager.ValidateToken(_request, _response); err == ErrTokenRevoked || err == nil && _response.Code != ResponseCodeOK {
+		return nil, ResponseCodeBadAccessToken, ErrAccessToken
+	} else if err != nil {
+		log.Error("ValidateToken(%#v, %#v) failed: %s", _request, _response, err)
+		return nil, ResponseCodeInternalError, err
+	}
+	return
+}
+
 func (ms *MatchSession) waitReady(request *ReadyRequest, impl *LogicImpl) chan *ReadyResponse {
 	competitor := ms.getCompetitor(request.AccessToken)
 	if competitor == nil {
@@ -576,7 +1109,7 @@ func (ms *MatchSession) waitReady(request *ReadyRequest, impl *LogicImpl) chan *
 		return ch
 	}
 
-	if !competitor.Ready(request.Operate) {
+	if !competitor.Ready(request.Operate, impl.clock.Now().UnixNano()/1000000) {
 		response := &ReadyResponse{}
 		response.Code = ResponseCodeBadReadyStatus
 
@@ -587,12 +1120,13 @@ func (ms *MatchSession) waitReady(request *ReadyRequest, impl *LogicImpl) chan *
 		return ch
 	}
 
-	ms.checkReady(impl)
+	ms.checkReady(impl, request.Traced)
 
 	return competitor.readyCh
 }
 
-func (ms *MatchSession) checkReady(impl *LogicImpl) {
+// t为最后一个出拳的请求，本回合的判定和转账挂在这个请求下
+func (ms *MatchSession) checkReady(impl *LogicImpl, t Traced) {
 	ms.mux.Lock()
 	defer ms.mux.Unlock()
 
@@ -607,26 +1141,39 @@ func (ms *MatchSession) checkReady(impl *LogicImpl) {
 		return
 	}
 
+	span := t.StartSpan("MatchSession.checkReady", trace.WithAttributes(
+		attribute.String("match_id", ms.MatchId), attribute.Int("round", ms.Round), attribute.Int("level", ms.Level)))
+	defer span.End()
+
 	blc1 := cp1.Balance
 	blc2 := cp2.Balance
 
-	result := ms.judge(ms.Level, cp1, cp2)
+	result := ms.judge(t.Context(), impl.risk, ms.Level, cp1, cp2)
+	observeRound(ms.Level, result)
 
 	round := ms.Round
+	now := impl.clock.Now()
 
 	code := ResponseCodeOK
 
 	win1 := float64(0)
 	win2 := float64(0)
 
+	settled := &SettledRound{}
+	settled.Round = round
+	settled.Result = result
+	settled.Operates = [2]int{cp1.GetOperate(), cp2.GetOperate()}
+	settled.Ts = now.Unix()
+
 	if result != Draw {
 		request := &TransferRequest{}
+		request.Traced = t
 		request.MatchId = ms.MatchId
 		request.Round = round
 		request.Level = ms.Level
 		request.Amount = float64(ms.Level)
 		request.FromCost = 0
-		request.ToCost = getCost(ms.Level)
+		request.ToCost = impl.getCost(ms.Level)
 
 		if request.ToCost == 0 {
 			log.Warn("Bad level cost 0: %d", ms.Level)
@@ -637,7 +1184,7 @@ func (ms *MatchSession) checkReady(impl *LogicImpl) {
 			request.FromAccessToken = cp2.accessToken
 			request.ToUid = cp1.uid
 			request.ToAccessToken = cp1.accessToken
-			win1 = float64(ms.Level) - getCost(ms.Level)
+			win1 = float64(ms.Level) - impl.getCost(ms.Level)
 			win2 = -float64(ms.Level)
 		} else {
 			request.FromUid = cp1.uid
@@ -645,7 +1192,7 @@ func (ms *MatchSession) checkReady(impl *LogicImpl) {
 			request.ToUid = cp2.uid
 			request.ToAccessToken = cp2.accessToken
 			win1 = -float64(ms.Level)
-			win2 = float64(ms.Level) - getCost(ms.Level)
+			win2 = float64(ms.Level) - impl.getCost(ms.Level)
 		}
 
 		response := &TransferResponse{}
@@ -666,20 +1213,42 @@ func (ms *MatchSession) checkReady(impl *LogicImpl) {
 			}
 		}
 
-		onIncomingResult(win1, cp1)
-		onIncomingResult(win2, cp2)
+		impl.onIncomingResult(ms.Level, win1, cp1, now.Unix())
+		impl.onIncomingResult(ms.Level, win2, cp2, now.Unix())
+
+		impl.onIncomingTransfer(request, code, cp1, cp2, win1, win2, now.Unix())
+
+		settled.transfer = request
+	}
+
+	settled.Code = code
+	settled.Wins = [2]float64{win1, win2}
+	ms.settled = append(ms.settled, settled)
+
+	ev := ms.event(MatchEventRoundSettled, code, now)
+	ev.Result = result
+	ev.Competitors[0].Operate, ev.Competitors[0].Win = cp1.GetOperate(), win1
+	ev.Competitors[1].Operate, ev.Competitors[1].Win = cp2.GetOperate(), win2
+	impl.events.Publish(ev)
+
+	if code == ResponseCodeOK {
+		impl.onIncomingRound(ms.Level, ms.MatchId, round, result, win1, cp1, now.Unix())
+		impl.onIncomingRound(ms.Level, ms.MatchId, round, ms.getOpponentResult(result), win2, cp2, now.Unix())
+		impl.fraud.OnRound(ms.Level, ms.MatchId, [2]int{cp1.uid, cp2.uid}, [2]int{result, ms.getOpponentResult(result)},
+			[2]float64{win1, win2}, [2]int64{ms.latency(cp1), ms.latency(cp2)}, now.Unix())
 	}
 
 	ms.Round++
 
-	ts := time.Now().UnixNano() / 1000000
+	ts := now.UnixNano() / 1000000
+	ms.roundMs = ts
 
 	// response to cp1
 	resp1 := &ReadyResponse{}
 	resp1.Code = code
 	resp1.Data.Round = ms.Round
 	resp1.Data.ServerTimestamp = ts
-	resp1.Data.ExpireTimestamp = ts + int64(impl.operateTimeoutSecond*1000)
+	resp1.Data.ExpireTimestamp = ts + int64(impl.getOperateTimeoutSecond()*1000)
 
 	if code == ResponseCodeOK {
 
@@ -704,7 +1273,7 @@ func (ms *MatchSession) checkReady(impl *LogicImpl) {
 	resp2.Code = code
 	resp2.Data.Round = ms.Round
 	resp2.Data.ServerTimestamp = ts
-	resp2.Data.ExpireTimestamp = ts + int64(impl.operateTimeoutSecond*1000)
+	resp2.Data.ExpireTimestamp = ts + int64(impl.getOperateTimeoutSecond()*1000)
 
 	if code == ResponseCodeOK {
 		resp2.Data.Results = append(resp2.Data.Results, &Result{
@@ -726,11 +1295,24 @@ func (ms *MatchSession) checkReady(impl *LogicImpl) {
 	cp1.Idle()
 	cp2.Idle()
 
-	cp1.KeepAlive()
-	cp2.KeepAlive()
+	cp1.KeepAlive(now.Unix())
+	cp2.KeepAlive(now.Unix())
+
+	if impl.cluster.Enabled() {
+		impl.cluster.SaveMatch(ms)
+	}
 
-	log.Debug("[%s] Ready => [%s][%d][%s][%d][%s][%d][%s][%s%.3f]", getCodeDescription(code), getResultDescription(result), ms.Level, ms.MatchId, ms.Round, cp1.accessToken, cp1.uid, getOperateDescription(cp1.GetOperate()), getSign(cp1.Balance-blc1), cp1.Balance-blc1)
-	log.Debug("[%s] Ready => [%s][%d][%s][%d][%s][%d][%s][%s%.3f]", getCodeDescription(code), getResultDescription(ms.getOpponentResult(result)), ms.Level, ms.MatchId, ms.Round, cp2.accessToken, cp2.uid, getOperateDescription(cp2.GetOperate()), getSign(cp2.Balance-blc2), cp2.Balance-blc2)
+	l := NewLog().With("request_id", t.RequestId).With("match_id", ms.MatchId).With("level", ms.Level).With("round", round)
+	l.With("uid", cp1.uid).Debug("[%s] Ready => [%s][%s][%s%.3f]", getCodeDescription(code), getResultDescription(result), getOperateDescription(cp1.GetOperate()), getSign(cp1.Balance-blc1), cp1.Balance-blc1)
+	l.With("uid", cp2.uid).Debug("[%s] Ready => [%s][%s][%s%.3f]", getCodeDescription(code), getResultDescription(ms.getOpponentResult(result)), getOperateDescription(cp2.GetOperate()), getSign(cp2.Balance-blc2), cp2.Balance-blc2)
+}
+
+// 从回合开始到出拳的毫秒数，不知道回合开始的时间时为-1
+func (ms *MatchSession) latency(cp *Competitor) int64 {
+	if ms.roundMs == 0 || cp.readyMs < ms.roundMs {
+		return -1
+	}
+	return cp.readyMs - ms.roundMs
 }
 
 func (ms *MatchSession) getOpponentResult(result int) int {
@@ -760,7 +1342,8 @@ func (ms *MatchSession) getOpponentStatus(accessToken string) int {
 	return CompetitorStatusDisposed
 }
 
-func (ms *MatchSession) dispose() {
+// code为返回给已经出拳的玩家的结果，比赛已经结束时返回false
+func (ms *MatchSession) disposeWith(code int) (disposed bool) {
 	ms.mux.Lock()
 	defer ms.mux.Unlock()
 
@@ -771,22 +1354,26 @@ func (ms *MatchSession) dispose() {
 	for _, cp := range ms.Competitors {
 		if cp.IsReady() {
 			response := &ReadyResponse{}
-			response.Code = ResponseCodeWaitReadyTimeout
+			response.Code = code
 			cp.readyCh <- response
 			cp.Idle()
 			// 这里不需要关闭chan是因为，receiver会关闭
-			log.Debug("dispose competitor %s cp because of ready timeout(has ready), matchId=%s round=%d", cp.accessToken, ms.MatchId, ms.Round)
+			NewLog().With("match_id", ms.MatchId).With("round", ms.Round).With("uid", cp.uid).Debug("dispose competitor because of %s(has ready)", getCodeDescription(code))
 		} else {
-			log.Debug("dispose competitor %s cp because of ready timeout(not ready), matchId=%s round=%d", cp.accessToken, ms.MatchId, ms.Round)
+			NewLog().With("match_id", ms.MatchId).With("round", ms.Round).With("uid", cp.uid).Debug("dispose competitor because of %s(not ready)", getCodeDescription(code))
 			// 这里需要关闭chan是因为，没有receiver
 			cp.closeChan()
 		}
 
 		cp.Dispose()
 	}
+	return true
 }
 
-func (ms *MatchSession) judge(lv int, cp1, cp2 *Competitor) int {
+func (ms *MatchSession) judge(ctx context.Context, risk *RiskController, lv int, cp1, cp2 *Competitor) int {
+	ctx, span := startSpan(ctx, "MatchSession.judge")
+	defer span.End()
+
 	op1 := cp1.GetOperate()
 	op2 := cp2.GetOperate()
 
@@ -795,7 +1382,7 @@ func (ms *MatchSession) judge(lv int, cp1, cp2 *Competitor) int {
 	}
 
 	if !cp1.IsMan() || !cp2.IsMan() {
-		return DefaultRiskController.Judge(float64(lv), cp1, cp2)
+		return risk.Judge(ctx, float64(lv), cp1, cp2)
 	}
 
 	switch op1 {
@@ -820,9 +1407,20 @@ func (ms *MatchSession) judge(lv int, cp1, cp2 *Competitor) int {
 }
 
 type WaitingList struct {
-	mux   sync.RWMutex
-	level int
-	list  []*WaitingData
+	mux    sync.RWMutex
+	level  int
+	list   []*WaitingData
+	paused int32
+}
+
+type waitingQueue []*WaitingData
+
+func (q waitingQueue) Len() int      { return len(q) }
+func (q waitingQueue) Uid(i int) int { return q[i].uid }
+func (q waitingQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (wl *WaitingList) isPaused() bool {
+	return atomic.LoadInt32(&wl.paused) == 1
 }
 
 func NewWaitingList(lv int) *WaitingList {
@@ -834,10 +1432,6 @@ func (ms *MatchSession) JSON() []byte {
 	return v
 }
 
-var (
-	DefaultLogicImpl Logic
-)
-
 var (
 	n int64 = 0
 )
@@ -845,11 +1439,12 @@ var (
 func getAvatarByOpenId(openId string) string {
 	return fmt.Sprintf("https://graph.facebook.com/%s/picture?type=large", openId)
 	if openId == "x" {
-		i := atomic.AddInt64(&n, 1) % int64(Conf.AvatarNum)
+		conf := GetConf()
+		i := atomic.AddInt64(&n, 1) % int64(conf.AvatarNum)
 		if i == 0 {
-			i = int64(Conf.AvatarNum)
+			i = int64(conf.AvatarNum)
 		}
-		return fmt.Sprintf(Conf.AvatarUrlTemplate, i)
+		return fmt.Sprintf(conf.AvatarUrlTemplate, i)
 	} else {
 		return fmt.Sprintf("https://graph.facebook.com/%s/picture?type=large", openId)
 	}
@@ -859,12 +1454,61 @@ var (
 	m int64 = -1
 )
 
-func onIncomingResult(winAmount float64, cp *Competitor) {
+func (impl *LogicImpl) onIncomingResult(level int, winAmount float64, cp *Competitor, ts int64) {
 	resultLog := &ResultLog{}
 	resultLog.Uid = cp.uid
+	resultLog.Level = level
 	resultLog.Avatar = cp.Avatar
 	resultLog.WinAmount = winAmount
 	resultLog.Nickname = cp.Nickname
-	resultLog.TimeUpdated = time.Now().Unix()
-	DefaultStatisticsManager.OnResult(resultLog)
+	resultLog.TimeUpdated = ts
+	impl.statistics.OnResult(resultLog)
+}
+
+func (impl *LogicImpl) onIncomingRound(level int, matchId string, round int, result int, winAmount float64, cp *Competitor, ts int64) {
+	// 机器人不统计
+	if !cp.IsMan() {
+		return
+	}
+
+	roundLog := &RoundLog{}
+	roundLog.Uid = cp.uid
+	roundLog.Level = level
+	roundLog.MatchId = matchId
+	roundLog.Round = round
+	roundLog.Operate = cp.GetOperate()
+	roundLog.Result = result
+	roundLog.WinAmount = winAmount
+	roundLog.Ts = ts
+	impl.playerStats.OnRound(roundLog)
+	impl.history.OnRound(roundLog)
+}
+
+func (impl *LogicImpl) onIncomingTransfer(request *TransferRequest, code int, cp1, cp2 *Competitor, win1, win2 float64, ts int64) {
+	status := LedgerStatusOK
+	if code != ResponseCodeOK {
+		status = LedgerStatusFailed
+	}
+
+	for _, e := range []struct {
+		cp, opponent *Competitor
+		win          float64
+	}{{cp1, cp2, win1}, {cp2, cp1, win2}} {
+		entry := &LedgerEntry{}
+		entry.MatchId = request.MatchId
+		entry.Round = request.Round
+		entry.Level = request.Level
+		entry.Uid = e.cp.uid
+		entry.CounterpartyUid = e.opponent.uid
+		entry.Amount = e.win
+		if e.cp.uid == request.ToUid {
+			entry.Cost = request.ToCost
+		} else {
+			entry.Cost = request.FromCost
+		}
+		entry.Balance = e.cp.Balance
+		entry.Status = status
+		entry.Ts = ts
+		impl.history.OnLedger(entry)
+	}
 }
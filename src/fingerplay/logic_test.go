@@ -0,0 +1,311 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fingerplay/wallettest"
+)
+
+// 每个Server的匹配循环、清理循环和榜单归档循环
+const testServerSleepers = 3
+
+// 按秒推进servers个Server共用的时钟，每一秒都等匹配和清理循环跑完一轮
+func stepClock(clock *ManualClock, servers, seconds int) {
+	for i := 0; i < seconds; i++ {
+		clock.BlockUntil(servers * testServerSleepers)
+		clock.Advance(time.Second)
+	}
+	clock.BlockUntil(servers * testServerSleepers)
+}
+
+func newClockServer(t *testing.T, cfg *Config, robots Robots) (*Server, *ManualClock) {
+	s := newTestServer(t, cfg)
+	clock := NewManualClock(time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local))
+	s.Clock = clock
+	s.Robots = robots
+	return startTestServer(t, s), clock
+}
+
+func (wl *WaitingList) waiting() int {
+	wl.mux.RLock()
+	defer wl.mux.RUnlock()
+	return len(wl.list)
+}
+
+// 两个玩家在同一个节点上排队，推进一秒后互相匹配
+func matchPlayers(t *testing.T, s *Server, clock *ManualClock, tokens []string) (m1, m2 *MatchResponse) {
+	responses := []*MatchResponse{{}, {}}
+	matched := make(chan bool, 2)
+	for i := range tokens {
+		go func(i int) {
+			s.Logic.Match(&MatchRequest{Level: 100, AccessToken: tokens[i]}, responses[i])
+			matched <- true
+		}(i)
+	}
+	wl := s.Logic.getWaitingList(100)
+	waitFor(t, "both players in the waiting list", func() bool { return wl.waiting() == 2 })
+
+	stepClock(clock, 1, 1)
+	<-matched
+	<-matched
+	m1, m2 = responses[0], responses[1]
+	if m1.Code != ResponseCodeOK || m2.Code != ResponseCodeOK || m1.Data.MatchId != m2.Data.MatchId {
+		t.Fatalf("match responses = %d, %d, want one match", m1.Code, m2.Code)
+	}
+	return
+}
+
+func TestMatchWaitFallsBackToRobot(t *testing.T) {
+	cfg := newTestConfig(t)
+	// 实际等待match_wait_second减去0到5秒
+	cfg.MatchWaitSecond = 10
+	useTestWallet(t, cfg)
+	robots := &countingRobots{}
+	s, clock := newClockServer(t, cfg, robots)
+
+	go s.Logic.Match(&MatchRequest{Level: 100, AccessToken: "player-1"}, &MatchResponse{})
+	wl := s.Logic.getWaitingList(100)
+	waitFor(t, "player in the waiting list", func() bool { return wl.waiting() == 1 })
+
+	stepClock(clock, 1, 5)
+	if n := robots.Calls(); n != 0 {
+		t.Fatalf("robot called %d times after 5s, want none before match_wait_second", n)
+	}
+
+	stepClock(clock, 1, 6)
+	if n := robots.Calls(); n == 0 {
+		t.Errorf("no robot after 11s, want one after match_wait_second")
+	}
+}
+
+func TestOperateTimeoutClosesMatch(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.OperateTimeoutSecond = 10
+	useTestWallet(t, cfg)
+	s, clock := newClockServer(t, cfg, &countingRobots{})
+
+	tokens := []string{"player-1", "player-2"}
+	m1, _ := matchPlayers(t, s, clock, tokens)
+
+	// 只有一方出拳，对手一直不出拳
+	ready := make(chan *ReadyResponse, 1)
+	go func() {
+		response := &ReadyResponse{}
+		s.Logic.Ready(&ReadyRequest{Operate: Stone, MatchId: m1.Data.MatchId, Round: m1.Data.Round,
+			AccessToken: tokens[0], SessionTicket: m1.Data.SessionTicket}, response)
+		ready <- response
+	}()
+	waitFor(t, "ready waiting for the opponent", func() bool {
+		ms, _ := s.Logic.lookupMatchSession(m1.Data.MatchId)
+		return ms != nil && ms.getOpponentStatus(tokens[1]) == CompetitorStatusReady
+	})
+
+	// 超过operate_timeout_second+3秒后清理循环结束比赛
+	stepClock(clock, 1, cfg.OperateTimeoutSecond+3)
+	select {
+	case response := <-ready:
+		t.Fatalf("ready returned %d before the operate timeout", response.Code)
+	default:
+	}
+
+	stepClock(clock, 1, 1)
+	select {
+	case response := <-ready:
+		if response.Code != ResponseCodeWaitReadyTimeout {
+			t.Errorf("ready code = %d, want %d", response.Code, ResponseCodeWaitReadyTimeout)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("ready did not return after the operate timeout")
+	}
+}
+
+func TestSessionTicket(t *testing.T) {
+	cfg := newTestConfig(t)
+	useTestWallet(t, cfg)
+	s, clock := newClockServer(t, cfg, &countingRobots{})
+
+	tokens := []string{"player-1", "player-2"}
+	m1, m2 := matchPlayers(t, s, clock, tokens)
+	if m1.Data.SessionTicket == "" || m1.Data.SessionTicket == m2.Data.SessionTicket {
+		t.Fatalf("session tickets = %q, %q, want one per player", m1.Data.SessionTicket, m2.Data.SessionTicket)
+	}
+
+	status := func(ticket string) int {
+		response := &ReadyStatusResponse{}
+		s.Logic.ReadyStatus(&ReadyStatusRequest{AccessToken: tokens[0], MatchId: m1.Data.MatchId, SessionTicket: ticket}, response)
+		return response.Code
+	}
+
+	for _, c := range []struct {
+		require bool
+		ticket  string
+		code    int
+	}{
+		{true, m1.Data.SessionTicket, ResponseCodeOK},
+		{true, "", ResponseCodeBadSession},
+		{true, "wrong", ResponseCodeBadSession},
+		// 对手的ticket也不行
+		{true, m2.Data.SessionTicket, ResponseCodeBadSession},
+		// 关闭后可以不带，带了仍然要正确
+		{false, "", ResponseCodeOK},
+		{false, m1.Data.SessionTicket, ResponseCodeOK},
+		{false, "wrong", ResponseCodeBadSession},
+	} {
+		conf := *cfg
+		conf.RequireSessionTicket = c.require
+		s.conf.Set(&conf)
+		if code := status(c.ticket); code != c.code {
+			t.Errorf("require_session_ticket=%v, ticket %q: code = %d, want %d", c.require, c.ticket, code, c.code)
+		}
+	}
+}
+
+// 两个玩家分别出拳，返回各自的结果
+func playRound(t *testing.T, s *Server, m1, m2 *MatchResponse, round, op1, op2 int) (r1, r2 *ReadyResponse) {
+	r1, r2 = &ReadyResponse{}, &ReadyResponse{}
+	matchId := m1.Data.MatchId
+	done := make(chan bool)
+	go func() {
+		s.Logic.Ready(&ReadyRequest{Operate: op1, MatchId: matchId, Round: round,
+			AccessToken: "player-1", SessionTicket: m1.Data.SessionTicket}, r1)
+		close(done)
+	}()
+	waitFor(t, "player-1 ready", func() bool {
+		ms, _ := s.Logic.lookupMatchSession(matchId)
+		return ms != nil && ms.getOpponentStatus("player-2") == CompetitorStatusReady
+	})
+	s.Logic.Ready(&ReadyRequest{Operate: op2, MatchId: matchId, Round: round,
+		AccessToken: "player-2", SessionTicket: m2.Data.SessionTicket}, r2)
+	<-done
+	return
+}
+
+// 结果中自己的一项带有access_token
+func ownResult(r *ReadyResponse) (own, opponent *Result) {
+	for _, result := range r.Data.Results {
+		if result.AccessToken != "" {
+			own = result
+		} else {
+			opponent = result
+		}
+	}
+	return
+}
+
+func TestSettlement(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.LevelCosts = map[string]float64{"100": 5}
+	wallet := useTestWallet(t, cfg,
+		&wallettest.User{Uid: 100001, AccessToken: "player-1", Balance: 1000},
+		&wallettest.User{Uid: 100002, AccessToken: "player-2", Balance: 1000})
+	robots := &countingRobots{}
+	s, clock := newClockServer(t, cfg, robots)
+
+	// 两个玩家直接互相匹配，不需要机器人
+	m1, m2 := matchPlayers(t, s, clock, []string{"player-1", "player-2"})
+	if n := robots.Calls(); n != 0 {
+		t.Errorf("robot called %d times, want two players matched with each other", n)
+	}
+	ms, _ := s.Logic.lookupMatchSession(m1.Data.MatchId)
+	if !ms.getCompetitor("player-1").IsMan() || !ms.getCompetitor("player-2").IsMan() {
+		t.Fatalf("competitors are not both players")
+	}
+
+	balance := func(uid int) float64 {
+		u, _ := wallet.User(uid)
+		return u.Balance
+	}
+
+	round := m1.Data.Round
+	for _, c := range []struct {
+		name         string
+		op1, op2     int
+		status1      int
+		win1, win2   float64
+		balances     [2]float64
+		transfers    int
+		from, to     int
+		fromCost     float64
+		toCost       float64
+		failTransfer bool
+	}{
+		// 赢家收到金额减去手续费，输家只付金额
+		{name: "won", op1: Stone, op2: Scissors, status1: Won, win1: 95, win2: -100, balances: [2]float64{1095, 900},
+			transfers: 1, from: 100002, to: 100001, toCost: 5},
+		{name: "draw", op1: Paper, op2: Paper, status1: Draw, balances: [2]float64{1095, 900}, transfers: 1},
+		{name: "lost", op1: Stone, op2: Paper, status1: Lost, win1: -100, win2: 95, balances: [2]float64{995, 995},
+			transfers: 2, from: 100001, to: 100002, toCost: 5},
+		// 账户服务返回错误时没有转账，余额不变，回合照样结束
+		{name: "failed", op1: Scissors, op2: Paper, win1: 95, win2: -100, balances: [2]float64{995, 995},
+			transfers: 2, failTransfer: true},
+	} {
+		if c.failTransfer {
+			wallet.SetFaults(wallettest.Faults{ErrorRate: 1})
+		}
+		r1, r2 := playRound(t, s, m1, m2, round, c.op1, c.op2)
+		wallet.SetFaults(wallettest.Faults{})
+		round = r1.Data.Round
+
+		if c.failTransfer {
+			if r1.Code != ResponseCodeInternalError || r2.Code != ResponseCodeInternalError || len(r1.Data.Results) != 0 {
+				t.Errorf("%s: codes = %d, %d, want %d without results", c.name, r1.Code, r2.Code, ResponseCodeInternalError)
+			}
+		} else {
+			own, opponent := ownResult(r1)
+			if r1.Code != ResponseCodeOK || own == nil || opponent == nil {
+				t.Fatalf("%s: ready = %+v, want our result and the opponent's", c.name, r1)
+			}
+			if own.Status != c.status1 || own.Win != c.win1 || own.Balance != c.balances[0] || opponent.Win != c.win2 || opponent.Balance != c.balances[1] {
+				t.Errorf("%s: own %+v, opponent %+v, want win %.0f/%.0f and balances %v", c.name, own, opponent, c.win1, c.win2, c.balances)
+			}
+			if own2, _ := ownResult(r2); own2 == nil || own2.Win != c.win2 || own2.Balance != c.balances[1] {
+				t.Errorf("%s: player-2 result = %+v, want win %.0f", c.name, own2, c.win2)
+			}
+		}
+
+		if b := [2]float64{balance(100001), balance(100002)}; b != c.balances {
+			t.Errorf("%s: wallet balances = %v, want %v", c.name, b, c.balances)
+		}
+		transfers := wallet.Transfers(100001)
+		if len(transfers) != c.transfers {
+			t.Fatalf("%s: %d transfers, want %d", c.name, len(transfers), c.transfers)
+		}
+		if c.from == 0 {
+			continue
+		}
+		if tr := transfers[len(transfers)-1]; tr.FromUid != c.from || tr.ToUid != c.to || tr.Amount != 100 || tr.FromCost != c.fromCost || tr.ToCost != c.toCost {
+			t.Errorf("%s: transfer = %+v, want %d to %d, amount 100 and to_cost 5", c.name, tr, c.from, c.to)
+		}
+	}
+
+	// 回合从0开始；每次转账双方各一条流水，平局没有流水，按时间倒序
+	s.History.Flush()
+	for _, c := range []struct {
+		uid  int
+		want []LedgerEntry
+	}{
+		{100001, []LedgerEntry{
+			{Round: 3, Uid: 100001, CounterpartyUid: 100002, Amount: 95, Cost: 5, Balance: 995, Status: LedgerStatusFailed},
+			{Round: 2, Uid: 100001, CounterpartyUid: 100002, Amount: -100, Balance: 995, Status: LedgerStatusOK},
+			{Round: 0, Uid: 100001, CounterpartyUid: 100002, Amount: 95, Cost: 5, Balance: 1095, Status: LedgerStatusOK},
+		}},
+		{100002, []LedgerEntry{
+			{Round: 3, Uid: 100002, CounterpartyUid: 100001, Amount: -100, Balance: 995, Status: LedgerStatusFailed},
+			{Round: 2, Uid: 100002, CounterpartyUid: 100001, Amount: 95, Cost: 5, Balance: 995, Status: LedgerStatusOK},
+			{Round: 0, Uid: 100002, CounterpartyUid: 100001, Amount: -100, Balance: 900, Status: LedgerStatusOK},
+		}},
+	} {
+		entries, err := s.History.Ledger(c.uid, 0, 10)
+		if err != nil || len(entries) != len(c.want) {
+			t.Fatalf("ledger of %d = %d entries, %v, want %d", c.uid, len(entries), err, len(c.want))
+		}
+		for i, e := range entries {
+			want := c.want[i]
+			want.MatchId, want.Level, want.Ts = m1.Data.MatchId, 100, clock.Now().Unix()
+			if *e != want {
+				t.Errorf("ledger of %d [%d] = %+v, want %+v", c.uid, i, *e, want)
+			}
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
+	"fmt"
 	"gamemania/libs/signal"
+	"os"
 	"runtime"
 	"time"
 
@@ -18,7 +22,20 @@ func main() {
 	flag.Parse()
 
 	if err := InitConfig(); err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if checkConfig {
+		fmt.Printf("config %s is ok\n", confFile)
+		return
+	}
+
+	if printConfig {
+		var out bytes.Buffer
+		json.Indent(&out, GetConf().JSON(), "", "  ")
+		fmt.Println(out.String())
+		return
 	}
 
 	runtime.GOMAXPROCS(runtime.NumCPU())
@@ -34,12 +51,25 @@ func main() {
 }
 
 func quit() {
-	// TODO
-	log.Debug("Get quit signal")
+	log.Info("Get quit signal, draining")
+
+	deadline := time.Now().Add(time.Duration(GetConf().ShutdownTimeoutSecond) * time.Second)
+
+	DefaultServer.Shutdown(deadline)
+	ShutdownTracing(deadline)
+
+	log.Info("Shutdown complete")
 	log.Close()
 }
 
 func reload() {
-	// TODO
-	log.Debug("Get reload signal")
+	log.Info("Get reload signal")
+	cfg, err := ReloadConfig()
+	if err != nil {
+		log.Error("Reload config failed, keep the current config: %s", err)
+		return
+	}
+	if cfg != nil {
+		DefaultServer.Reload(cfg)
+	}
 }
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+const (
+	MetricsNamespace = "fingerplay"
+	MetricsPath      = "/metrics"
+
+	// 未知的路径统一记为other，避免标签无限增长
+	MetricsPathOther = "other"
+	// 转发到其他节点的请求没有本地的返回码
+	MetricsCodeForwarded = "forwarded"
+)
+
+var (
+	metricsHttpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: MetricsNamespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by path and response code.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"path", "code"})
+
+	metricsResponseBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "http_response_bytes_total",
+		Help:      "Bytes of API response bodies by negotiated encoding.",
+	}, []string{"encoding"})
+
+	metricsGrpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: MetricsNamespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC unary call latency by method and grpc status code.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "code"})
+
+	metricsRounds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "rounds_total",
+		Help:      "Rounds judged, by level and result of the first competitor.",
+	}, []string{"level", "result"})
+
+	metricsUpstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: MetricsNamespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Latency of calls to the account service by endpoint.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint"})
+
+	metricsUpstreamFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "upstream_failures_total",
+		Help:      "Failed calls to the account service by endpoint and reason (error or code).",
+	}, []string{"endpoint", "reason"})
+
+	metricsTokenCache = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "token_cache_lookups_total",
+		Help:      "Access token validations by cache result (hit or miss).",
+	}, []string{"result"})
+
+	metricsSignatureRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "signature_rejected_total",
+		Help:      "Requests rejected by signature verification, by reason.",
+	}, []string{"reason"})
+
+	metricsRateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "rate_limited_total",
+		Help:      "Requests throttled by the rate limiter, by route and the key that ran out (ip, token or uid).",
+	}, []string{"route", "by"})
+
+	metricsFraudAlerts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "fraud_alerts_total",
+		Help:      "Collusion alerts raised on this node, by kind.",
+	}, []string{"kind"})
+
+	metricsFraudBlocked = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "fraud_blocked_pairings_total",
+		Help:      "Pairings skipped by the matcher because of fraud_block_kinds, counted on every matching pass.",
+	})
+)
+
+// 每个Server使用自己的registry，状态指标读取的是这个Server的组件。
+// 请求和回合的计数是进程级别的，同一进程中的Server共享。
+func newMetricsHandler(s *Server) fasthttp.RequestHandler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metricsHttpDuration, metricsResponseBytes, metricsGrpcDuration, metricsRounds, metricsUpstreamDuration, metricsUpstreamFailures, metricsTokenCache, metricsSignatureRejected, metricsRateLimited, metricsFraudAlerts, metricsFraudBlocked, &stateCollector{s: s})
+	return fasthttpadaptor.NewFastHTTPHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+}
+
+func observeHttp(path string, code string, begin time.Time) {
+	metricsHttpDuration.WithLabelValues(path, code).Observe(time.Since(begin).Seconds())
+}
+
+func observeResponseBytes(encoding string, n int) {
+	metricsResponseBytes.WithLabelValues(encoding).Add(float64(n))
+}
+
+func observeGrpc(method string, code string, begin time.Time) {
+	metricsGrpcDuration.WithLabelValues(method, code).Observe(time.Since(begin).Seconds())
+}
+
+func observeRound(level int, result int) {
+	metricsRounds.WithLabelValues(strconv.Itoa(level), getResultDescription(result)).Inc()
+}
+
+// 记录一次对账户服务的调用，code为业务返回码
+func observeUpstream(endpoint string, begin time.Time, err error, code int) {
+	metricsUpstreamDuration.WithLabelValues(endpoint).Observe(time.Since(begin).Seconds())
+	if err != nil {
+		metricsUpstreamFailures.WithLabelValues(endpoint, "error").Inc()
+	} else if code != ResponseCodeOK {
+		metricsUpstreamFailures.WithLabelValues(endpoint, "code").Inc()
+	}
+}
+
+func observeTokenCache(hit bool) {
+	if hit {
+		metricsTokenCache.WithLabelValues("hit").Inc()
+	} else {
+		metricsTokenCache.WithLabelValues("miss").Inc()
+	}
+}
+
+func observeSignatureRejected(err error) {
+	reason := "mismatch"
+	switch err {
+	case ErrSignatureMissing:
+		reason = "missing"
+	case ErrSignatureExpired:
+		reason = "expired"
+	case ErrNonceReplayed:
+		reason = "replayed"
+	}
+	metricsSignatureRejected.WithLabelValues(reason).Inc()
+}
+
+func observeRateLimited(route, by string) {
+	metricsRateLimited.WithLabelValues(route, by).Inc()
+}
+
+func observeFraudAlert(kind string) {
+	metricsFraudAlerts.WithLabelValues(kind).Inc()
+}
+
+func observeFraudBlocked() {
+	metricsFraudBlocked.Inc()
+}
+
+var (
+	descWaiting = prometheus.NewDesc(MetricsNamespace+"_waiting_players",
+		"Players waiting for a match on this node, by level.", []string{"level"}, nil)
+	descMatchSessions = prometheus.NewDesc(MetricsNamespace+"_match_sessions",
+		"Active match sessions on this node.", nil, nil)
+	descRobotSessions = prometheus.NewDesc(MetricsNamespace+"_robot_sessions_in_use",
+		"Robot account sessions currently logged in.", nil, nil)
+	descStatisticsQueue = prometheus.NewDesc(MetricsNamespace+"_statistics_queue_depth",
+		"Results waiting in the statistics queue.", nil, nil)
+	descStatisticsQueueCap = prometheus.NewDesc(MetricsNamespace+"_statistics_queue_capacity",
+		"Capacity of the statistics queue.", nil, nil)
+	descStatisticsDropped = prometheus.NewDesc(MetricsNamespace+"_statistics_dropped_total",
+		"Results dropped by the statistics manager.", nil, nil)
+	descStatisticsSpilled = prometheus.NewDesc(MetricsNamespace+"_statistics_spilled_total",
+		"Results spilled to the disk spool.", nil, nil)
+	descStatisticsSpool = prometheus.NewDesc(MetricsNamespace+"_statistics_spool_bytes",
+		"Size of the statistics disk spool.", nil, nil)
+	descSpoolQueue = prometheus.NewDesc(MetricsNamespace+"_spool_queue_depth",
+		"Records waiting in a history write queue.", []string{"queue"}, nil)
+	descSpoolDropped = prometheus.NewDesc(MetricsNamespace+"_spool_queue_dropped_total",
+		"Records lost because neither the store nor the disk spool took them.", []string{"queue"}, nil)
+	descSpoolSpilled = prometheus.NewDesc(MetricsNamespace+"_spool_queue_spilled_total",
+		"Records spilled to the disk spool.", []string{"queue"}, nil)
+	descSpoolBytes = prometheus.NewDesc(MetricsNamespace+"_spool_queue_spool_bytes",
+		"Size of the disk spool of a history write queue.", []string{"queue"}, nil)
+	descEventSubscribers = prometheus.NewDesc(MetricsNamespace+"_match_event_subscribers",
+		"Match event streams open on this node.", nil, nil)
+	descEventsDropped = prometheus.NewDesc(MetricsNamespace+"_match_events_dropped_total",
+		"Match events dropped because a subscriber was too slow.", nil, nil)
+	descCachedTokens = prometheus.NewDesc(MetricsNamespace+"_cached_tokens",
+		"Validated access tokens in the cache.", nil, nil)
+	descRevokedTokens = prometheus.NewDesc(MetricsNamespace+"_revoked_tokens",
+		"Revoked access tokens remembered on this node.", nil, nil)
+	descRateLimitBuckets = prometheus.NewDesc(MetricsNamespace+"_rate_limit_buckets",
+		"Token buckets held by the local rate limiter.", nil, nil)
+	descFraudPairs = prometheus.NewDesc(MetricsNamespace+"_fraud_tracked_pairs",
+		"Player pairs tracked by the collusion detector on this node.", nil, nil)
+)
+
+// 在抓取时读取各个模块的当前状态
+type stateCollector struct {
+	s *Server
+}
+
+func (c *stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descWaiting
+	ch <- descMatchSessions
+	ch <- descRobotSessions
+	ch <- descStatisticsQueue
+	ch <- descStatisticsQueueCap
+	ch <- descStatisticsDropped
+	ch <- descStatisticsSpilled
+	ch <- descStatisticsSpool
+	ch <- descSpoolQueue
+	ch <- descSpoolDropped
+	ch <- descSpoolSpilled
+	ch <- descSpoolBytes
+	ch <- descEventSubscribers
+	ch <- descEventsDropped
+	ch <- descCachedTokens
+	ch <- descRevokedTokens
+	ch <- descRateLimitBuckets
+	ch <- descFraudPairs
+}
+
+func (c *stateCollector) Collect(ch chan<- prometheus.Metric) {
+	if impl := c.s.Logic; impl != nil {
+		for _, wl := range impl.getWaitingLists() {
+			ch <- prometheus.MustNewConstMetric(descWaiting, prometheus.GaugeValue, float64(wl.Len()), strconv.Itoa(wl.level))
+		}
+		impl.matchMux.RLock()
+		n := len(impl.matchSessionMap)
+		impl.matchMux.RUnlock()
+		ch <- prometheus.MustNewConstMetric(descMatchSessions, prometheus.GaugeValue, float64(n))
+	}
+
+	if c.s.Accounts != nil {
+		ch <- prometheus.MustNewConstMetric(descRobotSessions, prometheus.GaugeValue, float64(c.s.Accounts.RobotSessionsInUse()))
+	}
+
+	if am, ok := c.s.Accounts.(*AccountManager); ok {
+		cached, revoked := am.TokenCacheSize()
+		ch <- prometheus.MustNewConstMetric(descCachedTokens, prometheus.GaugeValue, float64(cached))
+		ch <- prometheus.MustNewConstMetric(descRevokedTokens, prometheus.GaugeValue, float64(revoked))
+	}
+
+	if c.s.Limiter != nil {
+		ch <- prometheus.MustNewConstMetric(descRateLimitBuckets, prometheus.GaugeValue, float64(c.s.Limiter.Len()))
+	}
+
+	if c.s.Fraud != nil {
+		ch <- prometheus.MustNewConstMetric(descFraudPairs, prometheus.GaugeValue, float64(c.s.Fraud.Len()))
+	}
+
+	if c.s.Statistics != nil {
+		m := c.s.Statistics.Metrics()
+		ch <- prometheus.MustNewConstMetric(descStatisticsQueue, prometheus.GaugeValue, float64(m.QueueDepth))
+		ch <- prometheus.MustNewConstMetric(descStatisticsQueueCap, prometheus.GaugeValue, float64(m.QueueCap))
+		ch <- prometheus.MustNewConstMetric(descStatisticsDropped, prometheus.CounterValue, float64(m.Dropped))
+		ch <- prometheus.MustNewConstMetric(descStatisticsSpilled, prometheus.CounterValue, float64(m.Spilled))
+		ch <- prometheus.MustNewConstMetric(descStatisticsSpool, prometheus.GaugeValue, float64(m.SpoolBytes))
+	}
+
+	if c.s.History != nil {
+		for name, m := range c.s.History.Metrics() {
+			c.collectSpoolQueue(ch, name, m)
+		}
+	}
+	if c.s.PlayerStats != nil {
+		c.collectSpoolQueue(ch, "player_stats", c.s.PlayerStats.Metrics())
+	}
+
+	if c.s.Events != nil {
+		ch <- prometheus.MustNewConstMetric(descEventSubscribers, prometheus.GaugeValue, float64(c.s.Events.Len()))
+		ch <- prometheus.MustNewConstMetric(descEventsDropped, prometheus.CounterValue, float64(c.s.Events.Dropped()))
+	}
+}
+
+func (c *stateCollector) collectSpoolQueue(ch chan<- prometheus.Metric, name string, m *SpoolQueueMetrics) {
+	ch <- prometheus.MustNewConstMetric(descSpoolQueue, prometheus.GaugeValue, float64(m.QueueDepth), name)
+	ch <- prometheus.MustNewConstMetric(descSpoolDropped, prometheus.CounterValue, float64(m.Dropped), name)
+	ch <- prometheus.MustNewConstMetric(descSpoolSpilled, prometheus.CounterValue, float64(m.Spilled), name)
+	ch <- prometheus.MustNewConstMetric(descSpoolBytes, prometheus.GaugeValue, float64(m.SpoolBytes), name)
+}
@@ -1,12 +1,18 @@
 package main
 
 import (
+	"sync"
+	"time"
+
 	"gopkg.in/mgo.v2"
+
+	log "code.google.com/p/log4go"
 )
 
 // mgo使用文档：http://gopkg.in/mgo.v2
 type MongoManager struct {
 	config  MongoConfig
+	mux     sync.Mutex
 	session *mgo.Session
 }
 
@@ -14,22 +20,38 @@ type MongoConfig struct {
 	serverAddr string
 }
 
+// 启动时连接失败不再panic，之后每次GetSession都会重试连接
 func NewMongoManager(config MongoConfig) *MongoManager {
-	var (
-		err error
-	)
 	mgm := &MongoManager{}
 	mgm.config = config
 
-	mgm.session, err = mgo.Dial(mgm.config.serverAddr)
-	if err != nil {
-		panic(err)
+	if _, err := mgm.dial(); err != nil {
+		log.Error("mgo.Dial(%q) failed: %s", mgm.config.serverAddr, err)
 	}
 
-	mgm.session.SetMode(mgo.Eventual, true)
 	return mgm
 }
 
+func (mgm *MongoManager) dial() (session *mgo.Session, err error) {
+	mgm.mux.Lock()
+	defer mgm.mux.Unlock()
+
+	if mgm.session != nil {
+		return mgm.session, nil
+	}
+
+	if session, err = mgo.DialWithTimeout(mgm.config.serverAddr, 3*time.Second); err != nil {
+		return
+	}
+
+	session.SetMode(mgo.Eventual, true)
+	mgm.session = session
+	return
+}
+
 func (mgm *MongoManager) GetSession() (session *mgo.Session, err error) {
-	return mgm.session.Clone(), nil
+	if session, err = mgm.dial(); err != nil {
+		return
+	}
+	return session.Clone(), nil
 }
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MessagePack编解码，只处理json的数据模型：
+// map[string]interface{}、[]interface{}、string、数字、bool和nil，
+// 结构体先由jsonValue转换，字段名与json相同
+var (
+	ErrMsgpackShort = errors.New("msgpack: unexpected end of data")
+)
+
+func msgpackMarshal(v interface{}) (b []byte, err error) {
+	return msgpackAppend(nil, v)
+}
+
+func msgpackAppend(b []byte, v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xc0), nil
+	case bool:
+		if v {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case int64:
+		return msgpackAppendInt(b, v), nil
+	case uint64:
+		if v <= math.MaxInt64 {
+			return msgpackAppendInt(b, int64(v)), nil
+		}
+		return binary.BigEndian.AppendUint64(append(b, 0xcf), v), nil
+	case float64:
+		return msgpackAppendFloat(b, v), nil
+	case string:
+		return msgpackAppendString(b, v), nil
+	case []interface{}:
+		b = msgpackAppendHeader(b, len(v), 0x90, 0xdc)
+		var err error
+		for _, e := range v {
+			if b, err = msgpackAppend(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]interface{}:
+		// 按key排序，相同的内容编码结果相同
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b = msgpackAppendHeader(b, len(v), 0x80, 0xde)
+		var err error
+		for _, k := range keys {
+			b = msgpackAppendString(b, k)
+			if b, err = msgpackAppend(b, v[k]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("msgpack: unsupported type %T", v)
+	}
+}
+
+// 使用能放下的最短格式
+func msgpackAppendInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0 && n <= 0x7f:
+		return append(b, byte(n))
+	case n < 0 && n >= -32:
+		return append(b, byte(n))
+	case n >= 0 && n <= math.MaxUint8:
+		return append(b, 0xcc, byte(n))
+	case n >= 0 && n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(n))
+	case n >= 0 && n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(n))
+	case n >= 0:
+		return binary.BigEndian.AppendUint64(append(b, 0xcf), uint64(n))
+	case n >= math.MinInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(n))
+	case n >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(n))
+	}
+}
+
+// 余额和输赢大多是整数，按整数编码可以省下大部分字节；
+// 解码时整数可以放进float64，客户端不需要区分
+func msgpackAppendFloat(b []byte, f float64) []byte {
+	if f == math.Trunc(f) && math.Abs(f) < 1<<53 && !(f == 0 && math.Signbit(f)) {
+		return msgpackAppendInt(b, int64(f))
+	}
+	if float64(float32(f)) == f {
+		return binary.BigEndian.AppendUint32(append(b, 0xca), math.Float32bits(float32(f)))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(f))
+}
+
+func msgpackAppendString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n <= 31:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+// fix为fixarray或fixmap的前缀，code16为16位长度的格式，code16+1为32位
+func msgpackAppendHeader(b []byte, n int, fix, code16 byte) []byte {
+	switch {
+	case n <= 15:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, code16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, code16+1), uint32(n))
+	}
+}
+
+// 解码为json的数据模型，整数为int64，浮点数为float64，bin为string
+func msgpackUnmarshal(b []byte) (v interface{}, err error) {
+	d := &msgpackDecoder{b: b}
+	if v, err = d.decode(0); err != nil {
+		return
+	}
+	if d.off != len(b) {
+		return nil, fmt.Errorf("msgpack: %d bytes after the value", len(b)-d.off)
+	}
+	return
+}
+
+const (
+	// 嵌套太深的输入直接拒绝，避免栈溢出
+	msgpackMaxDepth = 32
+)
+
+type msgpackDecoder struct {
+	b   []byte
+	off int
+}
+
+func (d *msgpackDecoder) next(n int) (p []byte, err error) {
+	if n < 0 || len(d.b)-d.off < n {
+		return nil, ErrMsgpackShort
+	}
+	p = d.b[d.off : d.off+n]
+	d.off += n
+	return
+}
+
+func (d *msgpackDecoder) uint(n int) (u uint64, err error) {
+	p, err := d.next(n)
+	if err != nil {
+		return
+	}
+	for _, c := range p {
+		u = u<<8 | uint64(c)
+	}
+	return
+}
+
+func (d *msgpackDecoder) decode(depth int) (v interface{}, err error) {
+	if depth > msgpackMaxDepth {
+		return nil, errors.New("msgpack: nested too deep")
+	}
+
+	p, err := d.next(1)
+	if err != nil {
+		return
+	}
+	c := p[0]
+
+	var (
+		u uint64
+		n int
+	)
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xe0 == 0xa0:
+		return d.string(int(c & 0x1f))
+	case c&0xf0 == 0x90:
+		return d.array(int(c&0x0f), depth)
+	case c&0xf0 == 0x80:
+		return d.object(int(c&0x0f), depth)
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		if u, err = d.uint(1 << (c - 0xcc)); err != nil {
+			return
+		}
+		if u > math.MaxInt64 {
+			return float64(u), nil
+		}
+		return int64(u), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		if u, err = d.uint(size); err != nil {
+			return
+		}
+		// 符号扩展
+		shift := uint(64 - 8*size)
+		return int64(u<<shift) >> shift, nil
+	case 0xca:
+		if u, err = d.uint(4); err != nil {
+			return
+		}
+		return float64(math.Float32frombits(uint32(u))), nil
+	case 0xcb:
+		if u, err = d.uint(8); err != nil {
+			return
+		}
+		return math.Float64frombits(u), nil
+	case 0xd9, 0xda, 0xdb:
+		if u, err = d.uint(1 << (c - 0xd9)); err != nil {
+			return
+		}
+		return d.string(int(u))
+	case 0xc4, 0xc5, 0xc6:
+		if u, err = d.uint(1 << (c - 0xc4)); err != nil {
+			return
+		}
+		return d.string(int(u))
+	case 0xdc, 0xdd, 0xde, 0xdf:
+		if u, err = d.uint(2 << ((c - 0xdc) % 2)); err != nil {
+			return
+		}
+		if n = int(u); c <= 0xdd {
+			return d.array(n, depth)
+		}
+		return d.object(n, depth)
+	default:
+		return nil, fmt.Errorf("msgpack: unsupported format 0x%02x", c)
+	}
+}
+
+func (d *msgpackDecoder) string(n int) (v interface{}, err error) {
+	p, err := d.next(n)
+	if err != nil {
+		return
+	}
+	return string(p), nil
+}
+
+func (d *msgpackDecoder) array(n int, depth int) (v interface{}, err error) {
+	// 每个元素至少一个字节，长度不可信时先检查
+	if n > len(d.b)-d.off {
+		return nil, ErrMsgpackShort
+	}
+	a := make([]interface{}, n)
+	for i := range a {
+		if a[i], err = d.decode(depth + 1); err != nil {
+			return
+		}
+	}
+	return a, nil
+}
+
+func (d *msgpackDecoder) object(n int, depth int) (v interface{}, err error) {
+	if 2*n > len(d.b)-d.off {
+		return nil, ErrMsgpackShort
+	}
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		var k, e interface{}
+		if k, err = d.decode(depth + 1); err != nil {
+			return
+		}
+		if e, err = d.decode(depth + 1); err != nil {
+			return
+		}
+		// json的对象只有字符串key，整数key转换为字符串
+		switch k := k.(type) {
+		case string:
+			m[k] = e
+		case int64:
+			m[fmt.Sprint(k)] = e
+		default:
+			return nil, fmt.Errorf("msgpack: unsupported map key %T", k)
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	OpenApiPath    = "/openapi.json"
+	OpenApiVersion = "3.0.3"
+)
+
+var (
+	openApiOnce sync.Once
+	openApiDoc  []byte
+)
+
+// 由路由表生成，进程内只生成一次
+func openApiDocument() []byte {
+	openApiOnce.Do(func() {
+		openApiDoc, _ = json.MarshalIndent(newOpenApi(), "", "  ")
+	})
+	return openApiDoc
+}
+
+func (api *HttpApi) handleOpenApi(ctx *fasthttp.RequestCtx) {
+	ctx.SetContentType("application/json")
+	ctx.Write(openApiDocument())
+}
+
+func newOpenApi() map[string]interface{} {
+	components := make(map[string]*Schema)
+	apiResponse := schemaOf(reflect.TypeOf(ApiResponse{}), components)
+
+	paths := make(map[string]interface{})
+	for _, version := range ApiVersions {
+		for _, route := range Routes {
+			request := schemaOf(reflect.TypeOf(route.Request), components)
+			response := schemaOf(reflect.TypeOf(route.Response), components)
+
+			responses := map[string]interface{}{}
+			if version == ApiV1 {
+				responses["200"] = openApiResponse("Always 200, the result is in code, GET answers \"Method Not Allowed\"", response)
+			} else {
+				responses["405"] = openApiResponse("Method not allowed, only POST is accepted", apiResponse)
+				for status, codes := range openApiStatusCodes() {
+					desc := "code " + strings.Join(codes, ", ")
+					switch status {
+					case fasthttp.StatusOK:
+						desc = "OK"
+					case fasthttp.StatusBadRequest:
+						desc = "Request does not match the schema, or " + desc
+					}
+					responses[strconv.Itoa(status)] = openApiResponse(desc, response)
+				}
+			}
+
+			paths[apiPath(version, route.Path)] = map[string]interface{}{
+				"post": map[string]interface{}{
+					"operationId": fmt.Sprintf("v%d%s", version, route.Name),
+					"summary":     route.Summary,
+					"tags":        []string{fmt.Sprintf("v%d", version)},
+					"requestBody": map[string]interface{}{
+						"required": version == ApiV1,
+						"content":  openApiContent(request),
+					},
+					"responses": responses,
+				},
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"openapi": OpenApiVersion,
+		"info": map[string]interface{}{
+			"title":       "FingerPlay",
+			"version":     strconv.Itoa(ApiVersions[len(ApiVersions)-1]),
+			"description": openApiDescription(),
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": components},
+	}
+}
+
+func openApiResponse(desc string, schema *Schema) map[string]interface{} {
+	return map[string]interface{}{
+		"description": desc,
+		"content":     openApiContent(schema),
+	}
+}
+
+// json和MessagePack使用同一个schema
+func openApiContent(schema *Schema) map[string]interface{} {
+	return map[string]interface{}{
+		ContentTypeJSON:    map[string]interface{}{"schema": schema},
+		ContentTypeMsgpack: map[string]interface{}{"schema": schema},
+	}
+}
+
+// v2中每个http状态码对应的code
+func openApiStatusCodes() map[int][]string {
+	statuses := make(map[int][]string)
+	for code := ResponseCodeOK; code >= ResponseCodeMin; code-- {
+		if getCodeDescription(code) == "Undefined" {
+			continue
+		}
+		status := httpStatus(code)
+		statuses[status] = append(statuses[status], strconv.Itoa(code))
+	}
+	return statuses
+}
+
+func openApiDescription() string {
+	lines := []string{
+		"Every response has a numeric `code`, 0 means OK.",
+		"",
+		"v1 always replies http 200 and does not validate requests.",
+		"v2 validates requests against the schema and maps `code` to the http status.",
+		"",
+		"Requests and responses can be MessagePack with the same field names: send `Content-Type: application/msgpack` and `Accept: application/msgpack`.",
+		"JSON responses are gzipped when `Accept-Encoding` allows it.",
+		"With `" + HeaderDelta + ": 1` the responses within a match (ready, ready/status, leave) omit zero values and the access_token of results.",
+		"This is not a diff against earlier responses: a missing field means its zero value, e.g. a result without `operate` and `status` is stone and lost;",
+		"results are in the order of the competitors in the match response.",
+		"",
+		"When the server has a sign_secret every request carries `" + HeaderTimestamp + "` (unix seconds), `" + HeaderNonce + "` (8 to 64 characters, used once)",
+		"and `" + HeaderSignature + "`: hex HMAC-SHA256 of `method\\npath\\ntimestamp\\nnonce\\nbody` with the secret.",
+		"ready, ready/status and leave must pass the `session_ticket` of the match response unless the server turns require_session_ticket off.",
+		"",
+		"Requests are rate limited per route by client ip, access_token and uid; throttled requests get code -23 and a `Retry-After` header in seconds.",
+		"Match takes an optional `device_id`; players that look like they collude may be kept from being paired with each other and wait for another opponent or a robot.",
+		"",
+		"| code | description | v2 status |",
+		"| --- | --- | --- |",
+	}
+
+	for code := ResponseCodeOK; code >= ResponseCodeMin; code-- {
+		if getCodeDescription(code) == "Undefined" {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("| %d | %s | %d |", code, getCodeDescription(code), httpStatus(code)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,5059 @@
+// gRPC接口，与JSON接口共用同一个LogicImpl。消息与proto.go和admin.go中的结构一一对应，
+// code和msg的含义与JSON接口相同：业务结果放在code里，gRPC状态只用于
+// 认证失败(UNAUTHENTICATED)、请求不符合schema(INVALID_ARGUMENT)和
+// 比赛在其他节点上(FAILED_PRECONDITION)。
+//
+// 修改后重新生成：
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//		--go-grpc_out=. --go-grpc_opt=paths=source_relative fingerplay.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        v5.28.3
+// source: fingerplay.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MatchEvent_Type int32
+
+const (
+	MatchEvent_TYPE_UNSPECIFIED MatchEvent_Type = 0
+	MatchEvent_MATCH_CREATED    MatchEvent_Type = 1
+	MatchEvent_ROUND_SETTLED    MatchEvent_Type = 2
+	MatchEvent_MATCH_CLOSED     MatchEvent_Type = 3
+)
+
+// Enum value maps for MatchEvent_Type.
+var (
+	MatchEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "MATCH_CREATED",
+		2: "ROUND_SETTLED",
+		3: "MATCH_CLOSED",
+	}
+	MatchEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"MATCH_CREATED":    1,
+		"ROUND_SETTLED":    2,
+		"MATCH_CLOSED":     3,
+	}
+)
+
+func (x MatchEvent_Type) Enum() *MatchEvent_Type {
+	p := new(MatchEvent_Type)
+	*p = x
+	return p
+}
+
+func (x MatchEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_fingerplay_proto_enumTypes[0].Descriptor()
+}
+
+func (MatchEvent_Type) Type() protoreflect.EnumType {
+	return &file_fingerplay_proto_enumTypes[0]
+}
+
+func (x MatchEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchEvent_Type.Descriptor instead.
+func (MatchEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{30, 0}
+}
+
+type Competitor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccessToken string  `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Balance     float64 `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Nickname    string  `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Avatar      string  `protobuf:"bytes,4,opt,name=avatar,proto3" json:"avatar,omitempty"`
+}
+
+func (x *Competitor) Reset() {
+	*x = Competitor{}
+	mi := &file_fingerplay_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Competitor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Competitor) ProtoMessage() {}
+
+func (x *Competitor) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Competitor.ProtoReflect.Descriptor instead.
+func (*Competitor) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Competitor) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *Competitor) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *Competitor) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *Competitor) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+type MatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level       int32  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	AccessToken string `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	// 可选，设备的稳定标识，用于发现串通
+	DeviceId string `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+}
+
+func (x *MatchRequest) Reset() {
+	*x = MatchRequest{}
+	mi := &file_fingerplay_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchRequest) ProtoMessage() {}
+
+func (x *MatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchRequest.ProtoReflect.Descriptor instead.
+func (*MatchRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MatchRequest) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *MatchRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *MatchRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type MatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code int32              `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg  string             `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data *MatchResponseData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *MatchResponse) Reset() {
+	*x = MatchResponse{}
+	mi := &file_fingerplay_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchResponse) ProtoMessage() {}
+
+func (x *MatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchResponse.ProtoReflect.Descriptor instead.
+func (*MatchResponse) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MatchResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *MatchResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *MatchResponse) GetData() *MatchResponseData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type MatchResponseData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerTimestamp int64         `protobuf:"varint,1,opt,name=server_timestamp,json=serverTimestamp,proto3" json:"server_timestamp,omitempty"`
+	ExpireTimestamp int64         `protobuf:"varint,2,opt,name=expire_timestamp,json=expireTimestamp,proto3" json:"expire_timestamp,omitempty"`
+	MatchId         string        `protobuf:"bytes,3,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Round           int32         `protobuf:"varint,4,opt,name=round,proto3" json:"round,omitempty"`
+	Competitors     []*Competitor `protobuf:"bytes,5,rep,name=competitors,proto3" json:"competitors,omitempty"`
+	TimeoutSecond   int32         `protobuf:"varint,6,opt,name=timeout_second,json=timeoutSecond,proto3" json:"timeout_second,omitempty"`
+	// 比赛中的请求需要带上
+	SessionTicket string `protobuf:"bytes,7,opt,name=session_ticket,json=sessionTicket,proto3" json:"session_ticket,omitempty"`
+}
+
+func (x *MatchResponseData) Reset() {
+	*x = MatchResponseData{}
+	mi := &file_fingerplay_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchResponseData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchResponseData) ProtoMessage() {}
+
+func (x *MatchResponseData) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchResponseData.ProtoReflect.Descriptor instead.
+func (*MatchResponseData) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MatchResponseData) GetServerTimestamp() int64 {
+	if x != nil {
+		return x.ServerTimestamp
+	}
+	return 0
+}
+
+func (x *MatchResponseData) GetExpireTimestamp() int64 {
+	if x != nil {
+		return x.ExpireTimestamp
+	}
+	return 0
+}
+
+func (x *MatchResponseData) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchResponseData) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *MatchResponseData) GetCompetitors() []*Competitor {
+	if x != nil {
+		return x.Competitors
+	}
+	return nil
+}
+
+func (x *MatchResponseData) GetTimeoutSecond() int32 {
+	if x != nil {
+		return x.TimeoutSecond
+	}
+	return 0
+}
+
+func (x *MatchResponseData) GetSessionTicket() string {
+	if x != nil {
+		return x.SessionTicket
+	}
+	return ""
+}
+
+type ReadyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 0 stone, 1 paper, 2 scissors
+	Operate       int32  `protobuf:"varint,1,opt,name=operate,proto3" json:"operate,omitempty"`
+	MatchId       string `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Round         int32  `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	AccessToken   string `protobuf:"bytes,4,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	SessionTicket string `protobuf:"bytes,5,opt,name=session_ticket,json=sessionTicket,proto3" json:"session_ticket,omitempty"`
+}
+
+func (x *ReadyRequest) Reset() {
+	*x = ReadyRequest{}
+	mi := &file_fingerplay_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadyRequest) ProtoMessage() {}
+
+func (x *ReadyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadyRequest.ProtoReflect.Descriptor instead.
+func (*ReadyRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReadyRequest) GetOperate() int32 {
+	if x != nil {
+		return x.Operate
+	}
+	return 0
+}
+
+func (x *ReadyRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *ReadyRequest) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *ReadyRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ReadyRequest) GetSessionTicket() string {
+	if x != nil {
+		return x.SessionTicket
+	}
+	return ""
+}
+
+type ReadyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code int32              `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg  string             `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data *ReadyResponseData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ReadyResponse) Reset() {
+	*x = ReadyResponse{}
+	mi := &file_fingerplay_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadyResponse) ProtoMessage() {}
+
+func (x *ReadyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadyResponse.ProtoReflect.Descriptor instead.
+func (*ReadyResponse) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReadyResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ReadyResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *ReadyResponse) GetData() *ReadyResponseData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ReadyResponseData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerTimestamp int64     `protobuf:"varint,1,opt,name=server_timestamp,json=serverTimestamp,proto3" json:"server_timestamp,omitempty"`
+	ExpireTimestamp int64     `protobuf:"varint,2,opt,name=expire_timestamp,json=expireTimestamp,proto3" json:"expire_timestamp,omitempty"`
+	Round           int32     `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Results         []*Result `protobuf:"bytes,4,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *ReadyResponseData) Reset() {
+	*x = ReadyResponseData{}
+	mi := &file_fingerplay_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadyResponseData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadyResponseData) ProtoMessage() {}
+
+func (x *ReadyResponseData) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadyResponseData.ProtoReflect.Descriptor instead.
+func (*ReadyResponseData) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReadyResponseData) GetServerTimestamp() int64 {
+	if x != nil {
+		return x.ServerTimestamp
+	}
+	return 0
+}
+
+func (x *ReadyResponseData) GetExpireTimestamp() int64 {
+	if x != nil {
+		return x.ExpireTimestamp
+	}
+	return 0
+}
+
+func (x *ReadyResponseData) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *ReadyResponseData) GetResults() []*Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccessToken string  `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Operate     int32   `protobuf:"varint,2,opt,name=operate,proto3" json:"operate,omitempty"`
+	Status      int32   `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	Balance     float64 `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	Win         float64 `protobuf:"fixed64,5,opt,name=win,proto3" json:"win,omitempty"`
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	mi := &file_fingerplay_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Result) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *Result) GetOperate() int32 {
+	if x != nil {
+		return x.Operate
+	}
+	return 0
+}
+
+func (x *Result) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *Result) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *Result) GetWin() float64 {
+	if x != nil {
+		return x.Win
+	}
+	return 0
+}
+
+type ReadyStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccessToken   string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	MatchId       string `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	SessionTicket string `protobuf:"bytes,3,opt,name=session_ticket,json=sessionTicket,proto3" json:"session_ticket,omitempty"`
+}
+
+func (x *ReadyStatusRequest) Reset() {
+	*x = ReadyStatusRequest{}
+	mi := &file_fingerplay_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadyStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadyStatusRequest) ProtoMessage() {}
+
+func (x *ReadyStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadyStatusRequest.ProtoReflect.Descriptor instead.
+func (*ReadyStatusRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReadyStatusRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ReadyStatusRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *ReadyStatusRequest) GetSessionTicket() string {
+	if x != nil {
+		return x.SessionTicket
+	}
+	return ""
+}
+
+type ReadyStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code int32                    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg  string                   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data *ReadyStatusResponseData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ReadyStatusResponse) Reset() {
+	*x = ReadyStatusResponse{}
+	mi := &file_fingerplay_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadyStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadyStatusResponse) ProtoMessage() {}
+
+func (x *ReadyStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadyStatusResponse.ProtoReflect.Descriptor instead.
+func (*ReadyStatusResponse) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReadyStatusResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ReadyStatusResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *ReadyStatusResponse) GetData() *ReadyStatusResponseData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ReadyStatusResponseData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status int32 `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *ReadyStatusResponseData) Reset() {
+	*x = ReadyStatusResponseData{}
+	mi := &file_fingerplay_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadyStatusResponseData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadyStatusResponseData) ProtoMessage() {}
+
+func (x *ReadyStatusResponseData) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadyStatusResponseData.ProtoReflect.Descriptor instead.
+func (*ReadyStatusResponseData) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReadyStatusResponseData) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+type LeaveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccessToken   string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	MatchId       string `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	SessionTicket string `protobuf:"bytes,3,opt,name=session_ticket,json=sessionTicket,proto3" json:"session_ticket,omitempty"`
+}
+
+func (x *LeaveRequest) Reset() {
+	*x = LeaveRequest{}
+	mi := &file_fingerplay_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveRequest) ProtoMessage() {}
+
+func (x *LeaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveRequest.ProtoReflect.Descriptor instead.
+func (*LeaveRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LeaveRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LeaveRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *LeaveRequest) GetSessionTicket() string {
+	if x != nil {
+		return x.SessionTicket
+	}
+	return ""
+}
+
+type LeaveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg  string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *LeaveResponse) Reset() {
+	*x = LeaveResponse{}
+	mi := &file_fingerplay_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveResponse) ProtoMessage() {}
+
+func (x *LeaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveResponse.ProtoReflect.Descriptor instead.
+func (*LeaveResponse) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LeaveResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *LeaveResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type RankingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccessToken string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+}
+
+func (x *RankingRequest) Reset() {
+	*x = RankingRequest{}
+	mi := &file_fingerplay_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankingRequest) ProtoMessage() {}
+
+func (x *RankingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankingRequest.ProtoReflect.Descriptor instead.
+func (*RankingRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RankingRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+type RankingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code int32                `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg  string               `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data *RankingResponseData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *RankingResponse) Reset() {
+	*x = RankingResponse{}
+	mi := &file_fingerplay_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankingResponse) ProtoMessage() {}
+
+func (x *RankingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankingResponse.ProtoReflect.Descriptor instead.
+func (*RankingResponse) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RankingResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *RankingResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *RankingResponse) GetData() *RankingResponseData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type RankingResponseData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*ResultLog `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *RankingResponseData) Reset() {
+	*x = RankingResponseData{}
+	mi := &file_fingerplay_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankingResponseData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankingResponseData) ProtoMessage() {}
+
+func (x *RankingResponseData) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankingResponseData.ProtoReflect.Descriptor instead.
+func (*RankingResponseData) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RankingResponseData) GetResults() []*ResultLog {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ResultLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Avatar      string  `protobuf:"bytes,1,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	WinAmount   float64 `protobuf:"fixed64,2,opt,name=win_amount,json=winAmount,proto3" json:"win_amount,omitempty"`
+	Nickname    string  `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	TimeUpdated int64   `protobuf:"varint,4,opt,name=time_updated,json=timeUpdated,proto3" json:"time_updated,omitempty"`
+}
+
+func (x *ResultLog) Reset() {
+	*x = ResultLog{}
+	mi := &file_fingerplay_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResultLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultLog) ProtoMessage() {}
+
+func (x *ResultLog) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultLog.ProtoReflect.Descriptor instead.
+func (*ResultLog) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ResultLog) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *ResultLog) GetWinAmount() float64 {
+	if x != nil {
+		return x.WinAmount
+	}
+	return 0
+}
+
+func (x *ResultLog) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *ResultLog) GetTimeUpdated() int64 {
+	if x != nil {
+		return x.TimeUpdated
+	}
+	return 0
+}
+
+type OnlineNumberRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *OnlineNumberRequest) Reset() {
+	*x = OnlineNumberRequest{}
+	mi := &file_fingerplay_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnlineNumberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineNumberRequest) ProtoMessage() {}
+
+func (x *OnlineNumberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineNumberRequest.ProtoReflect.Descriptor instead.
+func (*OnlineNumberRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{17}
+}
+
+type OnlineNumberResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code int32                     `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg  string                    `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data *OnlineNumberResponseData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *OnlineNumberResponse) Reset() {
+	*x = OnlineNumberResponse{}
+	mi := &file_fingerplay_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnlineNumberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineNumberResponse) ProtoMessage() {}
+
+func (x *OnlineNumberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineNumberResponse.ProtoReflect.Descriptor instead.
+func (*OnlineNumberResponse) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OnlineNumberResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *OnlineNumberResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *OnlineNumberResponse) GetData() *OnlineNumberResponseData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type OnlineNumberResponseData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number int32   `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Rooms  []*Room `protobuf:"bytes,2,rep,name=rooms,proto3" json:"rooms,omitempty"`
+}
+
+func (x *OnlineNumberResponseData) Reset() {
+	*x = OnlineNumberResponseData{}
+	mi := &file_fingerplay_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnlineNumberResponseData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnlineNumberResponseData) ProtoMessage() {}
+
+func (x *OnlineNumberResponseData) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnlineNumberResponseData.ProtoReflect.Descriptor instead.
+func (*OnlineNumberResponseData) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OnlineNumberResponseData) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *OnlineNumberResponseData) GetRooms() []*Room {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+type Room struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level  int32 `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Number int32 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *Room) Reset() {
+	*x = Room{}
+	mi := &file_fingerplay_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Room) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Room) ProtoMessage() {}
+
+func (x *Room) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Room.ProtoReflect.Descriptor instead.
+func (*Room) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Room) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Room) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type StatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccessToken string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	// 0时查询access_token对应的玩家
+	Uid int64 `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_fingerplay_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StatsRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *StatsRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type StatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code int32              `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg  string             `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data *StatsResponseData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_fingerplay_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StatsResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *StatsResponse) GetData() *StatsResponseData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type StatsResponseData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid               int64             `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Rounds            int32             `protobuf:"varint,2,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	Wins              int32             `protobuf:"varint,3,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses            int32             `protobuf:"varint,4,opt,name=losses,proto3" json:"losses,omitempty"`
+	Draws             int32             `protobuf:"varint,5,opt,name=draws,proto3" json:"draws,omitempty"`
+	WinRate           float64           `protobuf:"fixed64,6,opt,name=win_rate,json=winRate,proto3" json:"win_rate,omitempty"`
+	Moves             *PlayerMoves      `protobuf:"bytes,7,opt,name=moves,proto3" json:"moves,omitempty"`
+	FavouriteMove     int32             `protobuf:"varint,8,opt,name=favourite_move,json=favouriteMove,proto3" json:"favourite_move,omitempty"`
+	LongestWinStreak  int32             `protobuf:"varint,9,opt,name=longest_win_streak,json=longestWinStreak,proto3" json:"longest_win_streak,omitempty"`
+	LongestLossStreak int32             `protobuf:"varint,10,opt,name=longest_loss_streak,json=longestLossStreak,proto3" json:"longest_loss_streak,omitempty"`
+	Net               float64           `protobuf:"fixed64,11,opt,name=net,proto3" json:"net,omitempty"`
+	LevelNet          map[int32]float64 `protobuf:"bytes,12,rep,name=level_net,json=levelNet,proto3" json:"level_net,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	FirstPlayed       int64             `protobuf:"varint,13,opt,name=first_played,json=firstPlayed,proto3" json:"first_played,omitempty"`
+	LastPlayed        int64             `protobuf:"varint,14,opt,name=last_played,json=lastPlayed,proto3" json:"last_played,omitempty"`
+}
+
+func (x *StatsResponseData) Reset() {
+	*x = StatsResponseData{}
+	mi := &file_fingerplay_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponseData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponseData) ProtoMessage() {}
+
+func (x *StatsResponseData) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponseData.ProtoReflect.Descriptor instead.
+func (*StatsResponseData) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StatsResponseData) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *StatsResponseData) GetRounds() int32 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *StatsResponseData) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *StatsResponseData) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *StatsResponseData) GetDraws() int32 {
+	if x != nil {
+		return x.Draws
+	}
+	return 0
+}
+
+func (x *StatsResponseData) GetWinRate() float64 {
+	if x != nil {
+		return x.WinRate
+	}
+	return 0
+}
+
+func (x *StatsResponseData) GetMoves() *PlayerMoves {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *StatsResponseData) GetFavouriteMove() int32 {
+	if x != nil {
+		return x.FavouriteMove
+	}
+	return 0
+}
+
+func (x *StatsResponseData) GetLongestWinStreak() int32 {
+	if x != nil {
+		return x.LongestWinStreak
+	}
+	return 0
+}
+
+func (x *StatsResponseData) GetLongestLossStreak() int32 {
+	if x != nil {
+		return x.LongestLossStreak
+	}
+	return 0
+}
+
+func (x *StatsResponseData) GetNet() float64 {
+	if x != nil {
+		return x.Net
+	}
+	return 0
+}
+
+func (x *StatsResponseData) GetLevelNet() map[int32]float64 {
+	if x != nil {
+		return x.LevelNet
+	}
+	return nil
+}
+
+func (x *StatsResponseData) GetFirstPlayed() int64 {
+	if x != nil {
+		return x.FirstPlayed
+	}
+	return 0
+}
+
+func (x *StatsResponseData) GetLastPlayed() int64 {
+	if x != nil {
+		return x.LastPlayed
+	}
+	return 0
+}
+
+type PlayerMoves struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stone    int32 `protobuf:"varint,1,opt,name=stone,proto3" json:"stone,omitempty"`
+	Paper    int32 `protobuf:"varint,2,opt,name=paper,proto3" json:"paper,omitempty"`
+	Scissors int32 `protobuf:"varint,3,opt,name=scissors,proto3" json:"scissors,omitempty"`
+}
+
+func (x *PlayerMoves) Reset() {
+	*x = PlayerMoves{}
+	mi := &file_fingerplay_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerMoves) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerMoves) ProtoMessage() {}
+
+func (x *PlayerMoves) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerMoves.ProtoReflect.Descriptor instead.
+func (*PlayerMoves) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PlayerMoves) GetStone() int32 {
+	if x != nil {
+		return x.Stone
+	}
+	return 0
+}
+
+func (x *PlayerMoves) GetPaper() int32 {
+	if x != nil {
+		return x.Paper
+	}
+	return 0
+}
+
+func (x *PlayerMoves) GetScissors() int32 {
+	if x != nil {
+		return x.Scissors
+	}
+	return 0
+}
+
+type LeaderboardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccessToken string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Window      string `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	Level       int32  `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`
+	Period      string `protobuf:"bytes,4,opt,name=period,proto3" json:"period,omitempty"`
+	Offset      int32  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit       int32  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	Neighbours  int32  `protobuf:"varint,7,opt,name=neighbours,proto3" json:"neighbours,omitempty"`
+}
+
+func (x *LeaderboardRequest) Reset() {
+	*x = LeaderboardRequest{}
+	mi := &file_fingerplay_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardRequest) ProtoMessage() {}
+
+func (x *LeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*LeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *LeaderboardRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LeaderboardRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *LeaderboardRequest) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *LeaderboardRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *LeaderboardRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *LeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *LeaderboardRequest) GetNeighbours() int32 {
+	if x != nil {
+		return x.Neighbours
+	}
+	return 0
+}
+
+type LeaderboardResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code int32                    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg  string                   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data *LeaderboardResponseData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *LeaderboardResponse) Reset() {
+	*x = LeaderboardResponse{}
+	mi := &file_fingerplay_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardResponse) ProtoMessage() {}
+
+func (x *LeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*LeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LeaderboardResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *LeaderboardResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *LeaderboardResponse) GetData() *LeaderboardResponseData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type LeaderboardResponseData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Window     string              `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	Period     string              `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	Level      int32               `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`
+	Total      int32               `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Entries    []*LeaderboardEntry `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	Me         *LeaderboardEntry   `protobuf:"bytes,6,opt,name=me,proto3" json:"me,omitempty"`
+	Neighbours []*LeaderboardEntry `protobuf:"bytes,7,rep,name=neighbours,proto3" json:"neighbours,omitempty"`
+}
+
+func (x *LeaderboardResponseData) Reset() {
+	*x = LeaderboardResponseData{}
+	mi := &file_fingerplay_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardResponseData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardResponseData) ProtoMessage() {}
+
+func (x *LeaderboardResponseData) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardResponseData.ProtoReflect.Descriptor instead.
+func (*LeaderboardResponseData) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LeaderboardResponseData) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *LeaderboardResponseData) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *LeaderboardResponseData) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *LeaderboardResponseData) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *LeaderboardResponseData) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *LeaderboardResponseData) GetMe() *LeaderboardEntry {
+	if x != nil {
+		return x.Me
+	}
+	return nil
+}
+
+func (x *LeaderboardResponseData) GetNeighbours() []*LeaderboardEntry {
+	if x != nil {
+		return x.Neighbours
+	}
+	return nil
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank        int32   `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Uid         int64   `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Avatar      string  `protobuf:"bytes,3,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Nickname    string  `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	WinAmount   float64 `protobuf:"fixed64,5,opt,name=win_amount,json=winAmount,proto3" json:"win_amount,omitempty"`
+	TimeUpdated int64   `protobuf:"varint,6,opt,name=time_updated,json=timeUpdated,proto3" json:"time_updated,omitempty"`
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_fingerplay_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *LeaderboardEntry) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetWinAmount() float64 {
+	if x != nil {
+		return x.WinAmount
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetTimeUpdated() int64 {
+	if x != nil {
+		return x.TimeUpdated
+	}
+	return 0
+}
+
+// 条件为空时不过滤
+type WatchMatchEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level   int32  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Uid     int64  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	MatchId string `protobuf:"bytes,3,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+}
+
+func (x *WatchMatchEventsRequest) Reset() {
+	*x = WatchMatchEventsRequest{}
+	mi := &file_fingerplay_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchMatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchMatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *WatchMatchEventsRequest) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *WatchMatchEventsRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *WatchMatchEventsRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type MatchEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    MatchEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=fingerplay.v1.MatchEvent_Type" json:"type,omitempty"`
+	MatchId string          `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Level   int32           `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`
+	Round   int32           `protobuf:"varint,4,opt,name=round,proto3" json:"round,omitempty"`
+	// 毫秒
+	Ts int64 `protobuf:"varint,5,opt,name=ts,proto3" json:"ts,omitempty"`
+	// ROUND_SETTLED时为结算结果，MATCH_CLOSED时为结束原因
+	Code int32 `protobuf:"varint,6,opt,name=code,proto3" json:"code,omitempty"`
+	// 第一个选手的胜负：1 won，-1 lost，0 draw
+	Result      int32                   `protobuf:"varint,7,opt,name=result,proto3" json:"result,omitempty"`
+	Competitors []*MatchEventCompetitor `protobuf:"bytes,8,rep,name=competitors,proto3" json:"competitors,omitempty"`
+	// 订阅者处理不及时被丢弃的事件数
+	Dropped int64 `protobuf:"varint,9,opt,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (x *MatchEvent) Reset() {
+	*x = MatchEvent{}
+	mi := &file_fingerplay_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchEvent) ProtoMessage() {}
+
+func (x *MatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchEvent.ProtoReflect.Descriptor instead.
+func (*MatchEvent) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *MatchEvent) GetType() MatchEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return MatchEvent_TYPE_UNSPECIFIED
+}
+
+func (x *MatchEvent) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchEvent) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *MatchEvent) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *MatchEvent) GetTs() int64 {
+	if x != nil {
+		return x.Ts
+	}
+	return 0
+}
+
+func (x *MatchEvent) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *MatchEvent) GetResult() int32 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *MatchEvent) GetCompetitors() []*MatchEventCompetitor {
+	if x != nil {
+		return x.Competitors
+	}
+	return nil
+}
+
+func (x *MatchEvent) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type MatchEventCompetitor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid      int64   `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Nickname string  `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Robot    bool    `protobuf:"varint,3,opt,name=robot,proto3" json:"robot,omitempty"`
+	Operate  int32   `protobuf:"varint,4,opt,name=operate,proto3" json:"operate,omitempty"`
+	Win      float64 `protobuf:"fixed64,5,opt,name=win,proto3" json:"win,omitempty"`
+	Balance  float64 `protobuf:"fixed64,6,opt,name=balance,proto3" json:"balance,omitempty"`
+}
+
+func (x *MatchEventCompetitor) Reset() {
+	*x = MatchEventCompetitor{}
+	mi := &file_fingerplay_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchEventCompetitor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchEventCompetitor) ProtoMessage() {}
+
+func (x *MatchEventCompetitor) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchEventCompetitor.ProtoReflect.Descriptor instead.
+func (*MatchEventCompetitor) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MatchEventCompetitor) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *MatchEventCompetitor) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *MatchEventCompetitor) GetRobot() bool {
+	if x != nil {
+		return x.Robot
+	}
+	return false
+}
+
+func (x *MatchEventCompetitor) GetOperate() int32 {
+	if x != nil {
+		return x.Operate
+	}
+	return 0
+}
+
+func (x *MatchEventCompetitor) GetWin() float64 {
+	if x != nil {
+		return x.Win
+	}
+	return 0
+}
+
+func (x *MatchEventCompetitor) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type AdminEmpty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AdminEmpty) Reset() {
+	*x = AdminEmpty{}
+	mi := &file_fingerplay_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminEmpty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminEmpty) ProtoMessage() {}
+
+func (x *AdminEmpty) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminEmpty.ProtoReflect.Descriptor instead.
+func (*AdminEmpty) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{32}
+}
+
+type AdminWaiting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid      int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Nickname string `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Robot    bool   `protobuf:"varint,3,opt,name=robot,proto3" json:"robot,omitempty"`
+	Node     string `protobuf:"bytes,4,opt,name=node,proto3" json:"node,omitempty"`
+	Ts       int64  `protobuf:"varint,5,opt,name=ts,proto3" json:"ts,omitempty"`
+}
+
+func (x *AdminWaiting) Reset() {
+	*x = AdminWaiting{}
+	mi := &file_fingerplay_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminWaiting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminWaiting) ProtoMessage() {}
+
+func (x *AdminWaiting) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminWaiting.ProtoReflect.Descriptor instead.
+func (*AdminWaiting) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AdminWaiting) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *AdminWaiting) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *AdminWaiting) GetRobot() bool {
+	if x != nil {
+		return x.Robot
+	}
+	return false
+}
+
+func (x *AdminWaiting) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *AdminWaiting) GetTs() int64 {
+	if x != nil {
+		return x.Ts
+	}
+	return 0
+}
+
+type AdminWaitingList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level   int32           `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Paused  bool            `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
+	Waiting []*AdminWaiting `protobuf:"bytes,3,rep,name=waiting,proto3" json:"waiting,omitempty"`
+}
+
+func (x *AdminWaitingList) Reset() {
+	*x = AdminWaitingList{}
+	mi := &file_fingerplay_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminWaitingList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminWaitingList) ProtoMessage() {}
+
+func (x *AdminWaitingList) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminWaitingList.ProtoReflect.Descriptor instead.
+func (*AdminWaitingList) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AdminWaitingList) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *AdminWaitingList) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *AdminWaitingList) GetWaiting() []*AdminWaiting {
+	if x != nil {
+		return x.Waiting
+	}
+	return nil
+}
+
+type AdminWaitingLists struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lists []*AdminWaitingList `protobuf:"bytes,1,rep,name=lists,proto3" json:"lists,omitempty"`
+}
+
+func (x *AdminWaitingLists) Reset() {
+	*x = AdminWaitingLists{}
+	mi := &file_fingerplay_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminWaitingLists) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminWaitingLists) ProtoMessage() {}
+
+func (x *AdminWaitingLists) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminWaitingLists.ProtoReflect.Descriptor instead.
+func (*AdminWaitingLists) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AdminWaitingLists) GetLists() []*AdminWaitingList {
+	if x != nil {
+		return x.Lists
+	}
+	return nil
+}
+
+type AdminCompetitor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid         int64   `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Nickname    string  `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Robot       bool    `protobuf:"varint,3,opt,name=robot,proto3" json:"robot,omitempty"`
+	Balance     float64 `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	Status      int32   `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+	KeepAliveTs int64   `protobuf:"varint,6,opt,name=keep_alive_ts,json=keepAliveTs,proto3" json:"keep_alive_ts,omitempty"`
+}
+
+func (x *AdminCompetitor) Reset() {
+	*x = AdminCompetitor{}
+	mi := &file_fingerplay_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminCompetitor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminCompetitor) ProtoMessage() {}
+
+func (x *AdminCompetitor) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminCompetitor.ProtoReflect.Descriptor instead.
+func (*AdminCompetitor) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AdminCompetitor) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *AdminCompetitor) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *AdminCompetitor) GetRobot() bool {
+	if x != nil {
+		return x.Robot
+	}
+	return false
+}
+
+func (x *AdminCompetitor) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *AdminCompetitor) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *AdminCompetitor) GetKeepAliveTs() int64 {
+	if x != nil {
+		return x.KeepAliveTs
+	}
+	return 0
+}
+
+type AdminSettledRound struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round    int32     `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Result   int32     `protobuf:"varint,2,opt,name=result,proto3" json:"result,omitempty"`
+	Operates []int32   `protobuf:"varint,3,rep,packed,name=operates,proto3" json:"operates,omitempty"`
+	Wins     []float64 `protobuf:"fixed64,4,rep,packed,name=wins,proto3" json:"wins,omitempty"`
+	Code     int32     `protobuf:"varint,5,opt,name=code,proto3" json:"code,omitempty"`
+	Refunded bool      `protobuf:"varint,6,opt,name=refunded,proto3" json:"refunded,omitempty"`
+	Ts       int64     `protobuf:"varint,7,opt,name=ts,proto3" json:"ts,omitempty"`
+}
+
+func (x *AdminSettledRound) Reset() {
+	*x = AdminSettledRound{}
+	mi := &file_fingerplay_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminSettledRound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminSettledRound) ProtoMessage() {}
+
+func (x *AdminSettledRound) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminSettledRound.ProtoReflect.Descriptor instead.
+func (*AdminSettledRound) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AdminSettledRound) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *AdminSettledRound) GetResult() int32 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *AdminSettledRound) GetOperates() []int32 {
+	if x != nil {
+		return x.Operates
+	}
+	return nil
+}
+
+func (x *AdminSettledRound) GetWins() []float64 {
+	if x != nil {
+		return x.Wins
+	}
+	return nil
+}
+
+func (x *AdminSettledRound) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *AdminSettledRound) GetRefunded() bool {
+	if x != nil {
+		return x.Refunded
+	}
+	return false
+}
+
+func (x *AdminSettledRound) GetTs() int64 {
+	if x != nil {
+		return x.Ts
+	}
+	return 0
+}
+
+type AdminMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId     string               `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Level       int32                `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	Round       int32                `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Disposed    bool                 `protobuf:"varint,4,opt,name=disposed,proto3" json:"disposed,omitempty"`
+	CreatedTs   int64                `protobuf:"varint,5,opt,name=created_ts,json=createdTs,proto3" json:"created_ts,omitempty"`
+	Competitors []*AdminCompetitor   `protobuf:"bytes,6,rep,name=competitors,proto3" json:"competitors,omitempty"`
+	Rounds      []*AdminSettledRound `protobuf:"bytes,7,rep,name=rounds,proto3" json:"rounds,omitempty"`
+}
+
+func (x *AdminMatch) Reset() {
+	*x = AdminMatch{}
+	mi := &file_fingerplay_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminMatch) ProtoMessage() {}
+
+func (x *AdminMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminMatch.ProtoReflect.Descriptor instead.
+func (*AdminMatch) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AdminMatch) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *AdminMatch) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *AdminMatch) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *AdminMatch) GetDisposed() bool {
+	if x != nil {
+		return x.Disposed
+	}
+	return false
+}
+
+func (x *AdminMatch) GetCreatedTs() int64 {
+	if x != nil {
+		return x.CreatedTs
+	}
+	return 0
+}
+
+func (x *AdminMatch) GetCompetitors() []*AdminCompetitor {
+	if x != nil {
+		return x.Competitors
+	}
+	return nil
+}
+
+func (x *AdminMatch) GetRounds() []*AdminSettledRound {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+type AdminMatches struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Matches []*AdminMatch `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+}
+
+func (x *AdminMatches) Reset() {
+	*x = AdminMatches{}
+	mi := &file_fingerplay_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminMatches) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminMatches) ProtoMessage() {}
+
+func (x *AdminMatches) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminMatches.ProtoReflect.Descriptor instead.
+func (*AdminMatches) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AdminMatches) GetMatches() []*AdminMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type AdminMatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// DisposeMatch时退还已经结算的回合
+	Refund bool `protobuf:"varint,2,opt,name=refund,proto3" json:"refund,omitempty"`
+}
+
+func (x *AdminMatchRequest) Reset() {
+	*x = AdminMatchRequest{}
+	mi := &file_fingerplay_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminMatchRequest) ProtoMessage() {}
+
+func (x *AdminMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminMatchRequest.ProtoReflect.Descriptor instead.
+func (*AdminMatchRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AdminMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *AdminMatchRequest) GetRefund() bool {
+	if x != nil {
+		return x.Refund
+	}
+	return false
+}
+
+type AdminRefund struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round   int32   `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	FromUid int64   `protobuf:"varint,2,opt,name=from_uid,json=fromUid,proto3" json:"from_uid,omitempty"`
+	ToUid   int64   `protobuf:"varint,3,opt,name=to_uid,json=toUid,proto3" json:"to_uid,omitempty"`
+	Amount  float64 `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Code    int32   `protobuf:"varint,5,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *AdminRefund) Reset() {
+	*x = AdminRefund{}
+	mi := &file_fingerplay_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminRefund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminRefund) ProtoMessage() {}
+
+func (x *AdminRefund) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminRefund.ProtoReflect.Descriptor instead.
+func (*AdminRefund) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AdminRefund) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *AdminRefund) GetFromUid() int64 {
+	if x != nil {
+		return x.FromUid
+	}
+	return 0
+}
+
+func (x *AdminRefund) GetToUid() int64 {
+	if x != nil {
+		return x.ToUid
+	}
+	return 0
+}
+
+func (x *AdminRefund) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AdminRefund) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+type AdminDisposeResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId string         `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Refunds []*AdminRefund `protobuf:"bytes,2,rep,name=refunds,proto3" json:"refunds,omitempty"`
+}
+
+func (x *AdminDisposeResult) Reset() {
+	*x = AdminDisposeResult{}
+	mi := &file_fingerplay_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminDisposeResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminDisposeResult) ProtoMessage() {}
+
+func (x *AdminDisposeResult) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminDisposeResult.ProtoReflect.Descriptor instead.
+func (*AdminDisposeResult) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AdminDisposeResult) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *AdminDisposeResult) GetRefunds() []*AdminRefund {
+	if x != nil {
+		return x.Refunds
+	}
+	return nil
+}
+
+type AdminKickRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid int64 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+}
+
+func (x *AdminKickRequest) Reset() {
+	*x = AdminKickRequest{}
+	mi := &file_fingerplay_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminKickRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminKickRequest) ProtoMessage() {}
+
+func (x *AdminKickRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminKickRequest.ProtoReflect.Descriptor instead.
+func (*AdminKickRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AdminKickRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type AdminRevokeTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccessToken string `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+}
+
+func (x *AdminRevokeTokenRequest) Reset() {
+	*x = AdminRevokeTokenRequest{}
+	mi := &file_fingerplay_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminRevokeTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminRevokeTokenRequest) ProtoMessage() {}
+
+func (x *AdminRevokeTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminRevokeTokenRequest.ProtoReflect.Descriptor instead.
+func (*AdminRevokeTokenRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AdminRevokeTokenRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+type AdminKickResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kicked int32 `protobuf:"varint,1,opt,name=kicked,proto3" json:"kicked,omitempty"`
+}
+
+func (x *AdminKickResult) Reset() {
+	*x = AdminKickResult{}
+	mi := &file_fingerplay_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminKickResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminKickResult) ProtoMessage() {}
+
+func (x *AdminKickResult) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminKickResult.ProtoReflect.Descriptor instead.
+func (*AdminKickResult) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *AdminKickResult) GetKicked() int32 {
+	if x != nil {
+		return x.Kicked
+	}
+	return 0
+}
+
+type AdminLevelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level int32 `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *AdminLevelRequest) Reset() {
+	*x = AdminLevelRequest{}
+	mi := &file_fingerplay_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminLevelRequest) ProtoMessage() {}
+
+func (x *AdminLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminLevelRequest.ProtoReflect.Descriptor instead.
+func (*AdminLevelRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AdminLevelRequest) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type AdminRobotUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Idle            int32           `protobuf:"varint,1,opt,name=idle,proto3" json:"idle,omitempty"`
+	Playing         map[int32]int32 `protobuf:"bytes,2,rep,name=playing,proto3" json:"playing,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	SessionsInUse   int32           `protobuf:"varint,3,opt,name=sessions_in_use,json=sessionsInUse,proto3" json:"sessions_in_use,omitempty"`
+	SessionsIdle    int32           `protobuf:"varint,4,opt,name=sessions_idle,json=sessionsIdle,proto3" json:"sessions_idle,omitempty"`
+	MaxRobotUid     int64           `protobuf:"varint,5,opt,name=max_robot_uid,json=maxRobotUid,proto3" json:"max_robot_uid,omitempty"`
+	LifetimeSeconds int64           `protobuf:"varint,6,opt,name=lifetime_seconds,json=lifetimeSeconds,proto3" json:"lifetime_seconds,omitempty"`
+}
+
+func (x *AdminRobotUsage) Reset() {
+	*x = AdminRobotUsage{}
+	mi := &file_fingerplay_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminRobotUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminRobotUsage) ProtoMessage() {}
+
+func (x *AdminRobotUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminRobotUsage.ProtoReflect.Descriptor instead.
+func (*AdminRobotUsage) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AdminRobotUsage) GetIdle() int32 {
+	if x != nil {
+		return x.Idle
+	}
+	return 0
+}
+
+func (x *AdminRobotUsage) GetPlaying() map[int32]int32 {
+	if x != nil {
+		return x.Playing
+	}
+	return nil
+}
+
+func (x *AdminRobotUsage) GetSessionsInUse() int32 {
+	if x != nil {
+		return x.SessionsInUse
+	}
+	return 0
+}
+
+func (x *AdminRobotUsage) GetSessionsIdle() int32 {
+	if x != nil {
+		return x.SessionsIdle
+	}
+	return 0
+}
+
+func (x *AdminRobotUsage) GetMaxRobotUid() int64 {
+	if x != nil {
+		return x.MaxRobotUid
+	}
+	return 0
+}
+
+func (x *AdminRobotUsage) GetLifetimeSeconds() int64 {
+	if x != nil {
+		return x.LifetimeSeconds
+	}
+	return 0
+}
+
+type AdminLedgerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid    int64 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit  int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *AdminLedgerRequest) Reset() {
+	*x = AdminLedgerRequest{}
+	mi := &file_fingerplay_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminLedgerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminLedgerRequest) ProtoMessage() {}
+
+func (x *AdminLedgerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminLedgerRequest.ProtoReflect.Descriptor instead.
+func (*AdminLedgerRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *AdminLedgerRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *AdminLedgerRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *AdminLedgerRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type LedgerEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MatchId         string  `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Round           int32   `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Level           int32   `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`
+	Uid             int64   `protobuf:"varint,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	CounterpartyUid int64   `protobuf:"varint,5,opt,name=counterparty_uid,json=counterpartyUid,proto3" json:"counterparty_uid,omitempty"`
+	Amount          float64 `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Cost            float64 `protobuf:"fixed64,7,opt,name=cost,proto3" json:"cost,omitempty"`
+	Balance         float64 `protobuf:"fixed64,8,opt,name=balance,proto3" json:"balance,omitempty"`
+	Status          string  `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	Ts              int64   `protobuf:"varint,10,opt,name=ts,proto3" json:"ts,omitempty"`
+}
+
+func (x *LedgerEntry) Reset() {
+	*x = LedgerEntry{}
+	mi := &file_fingerplay_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntry) ProtoMessage() {}
+
+func (x *LedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntry.ProtoReflect.Descriptor instead.
+func (*LedgerEntry) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *LedgerEntry) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetCounterpartyUid() int64 {
+	if x != nil {
+		return x.CounterpartyUid
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetTs() int64 {
+	if x != nil {
+		return x.Ts
+	}
+	return 0
+}
+
+type AdminLedger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*LedgerEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *AdminLedger) Reset() {
+	*x = AdminLedger{}
+	mi := &file_fingerplay_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminLedger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminLedger) ProtoMessage() {}
+
+func (x *AdminLedger) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminLedger.ProtoReflect.Descriptor instead.
+func (*AdminLedger) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AdminLedger) GetEntries() []*LedgerEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type AdminDrainRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 0时使用shutdown_timeout_second
+	TimeoutSecond int32 `protobuf:"varint,1,opt,name=timeout_second,json=timeoutSecond,proto3" json:"timeout_second,omitempty"`
+}
+
+func (x *AdminDrainRequest) Reset() {
+	*x = AdminDrainRequest{}
+	mi := &file_fingerplay_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminDrainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminDrainRequest) ProtoMessage() {}
+
+func (x *AdminDrainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminDrainRequest.ProtoReflect.Descriptor instead.
+func (*AdminDrainRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *AdminDrainRequest) GetTimeoutSecond() int32 {
+	if x != nil {
+		return x.TimeoutSecond
+	}
+	return 0
+}
+
+type AdminDrainResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Draining bool  `protobuf:"varint,1,opt,name=draining,proto3" json:"draining,omitempty"`
+	Matches  int32 `protobuf:"varint,2,opt,name=matches,proto3" json:"matches,omitempty"`
+}
+
+func (x *AdminDrainResult) Reset() {
+	*x = AdminDrainResult{}
+	mi := &file_fingerplay_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminDrainResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminDrainResult) ProtoMessage() {}
+
+func (x *AdminDrainResult) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminDrainResult.ProtoReflect.Descriptor instead.
+func (*AdminDrainResult) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *AdminDrainResult) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+func (x *AdminDrainResult) GetMatches() int32 {
+	if x != nil {
+		return x.Matches
+	}
+	return 0
+}
+
+type AdminConfigCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	File   string   `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Valid  bool     `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	Errors []string `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *AdminConfigCheck) Reset() {
+	*x = AdminConfigCheck{}
+	mi := &file_fingerplay_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminConfigCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminConfigCheck) ProtoMessage() {}
+
+func (x *AdminConfigCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminConfigCheck.ProtoReflect.Descriptor instead.
+func (*AdminConfigCheck) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *AdminConfigCheck) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *AdminConfigCheck) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *AdminConfigCheck) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type AdminLeaderboardRebuild struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Replayed   int64 `protobuf:"varint,1,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	Archived   int32 `protobuf:"varint,2,opt,name=archived,proto3" json:"archived,omitempty"`
+	SpoolBytes int64 `protobuf:"varint,3,opt,name=spool_bytes,json=spoolBytes,proto3" json:"spool_bytes,omitempty"`
+	Entries    int32 `protobuf:"varint,4,opt,name=entries,proto3" json:"entries,omitempty"`
+	Rebuilt    int32 `protobuf:"varint,5,opt,name=rebuilt,proto3" json:"rebuilt,omitempty"`
+}
+
+func (x *AdminLeaderboardRebuild) Reset() {
+	*x = AdminLeaderboardRebuild{}
+	mi := &file_fingerplay_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminLeaderboardRebuild) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminLeaderboardRebuild) ProtoMessage() {}
+
+func (x *AdminLeaderboardRebuild) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminLeaderboardRebuild.ProtoReflect.Descriptor instead.
+func (*AdminLeaderboardRebuild) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *AdminLeaderboardRebuild) GetReplayed() int64 {
+	if x != nil {
+		return x.Replayed
+	}
+	return 0
+}
+
+func (x *AdminLeaderboardRebuild) GetArchived() int32 {
+	if x != nil {
+		return x.Archived
+	}
+	return 0
+}
+
+func (x *AdminLeaderboardRebuild) GetSpoolBytes() int64 {
+	if x != nil {
+		return x.SpoolBytes
+	}
+	return 0
+}
+
+func (x *AdminLeaderboardRebuild) GetEntries() int32 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *AdminLeaderboardRebuild) GetRebuilt() int32 {
+	if x != nil {
+		return x.Rebuilt
+	}
+	return 0
+}
+
+type AdminFraudAlertsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 0时不限
+	Uid   int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Kind  string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Limit int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *AdminFraudAlertsRequest) Reset() {
+	*x = AdminFraudAlertsRequest{}
+	mi := &file_fingerplay_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminFraudAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminFraudAlertsRequest) ProtoMessage() {}
+
+func (x *AdminFraudAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminFraudAlertsRequest.ProtoReflect.Descriptor instead.
+func (*AdminFraudAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *AdminFraudAlertsRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *AdminFraudAlertsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AdminFraudAlertsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type FraudAlert struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind    string  `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Uids    []int64 `protobuf:"varint,3,rep,packed,name=uids,proto3" json:"uids,omitempty"`
+	Level   int32   `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	MatchId string  `protobuf:"bytes,5,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Detail  string  `protobuf:"bytes,6,opt,name=detail,proto3" json:"detail,omitempty"`
+	Node    string  `protobuf:"bytes,7,opt,name=node,proto3" json:"node,omitempty"`
+	Ts      int64   `protobuf:"varint,8,opt,name=ts,proto3" json:"ts,omitempty"`
+}
+
+func (x *FraudAlert) Reset() {
+	*x = FraudAlert{}
+	mi := &file_fingerplay_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FraudAlert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FraudAlert) ProtoMessage() {}
+
+func (x *FraudAlert) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FraudAlert.ProtoReflect.Descriptor instead.
+func (*FraudAlert) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *FraudAlert) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FraudAlert) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *FraudAlert) GetUids() []int64 {
+	if x != nil {
+		return x.Uids
+	}
+	return nil
+}
+
+func (x *FraudAlert) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *FraudAlert) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *FraudAlert) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *FraudAlert) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *FraudAlert) GetTs() int64 {
+	if x != nil {
+		return x.Ts
+	}
+	return 0
+}
+
+type AdminFraudAlerts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Alerts []*FraudAlert `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+}
+
+func (x *AdminFraudAlerts) Reset() {
+	*x = AdminFraudAlerts{}
+	mi := &file_fingerplay_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminFraudAlerts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminFraudAlerts) ProtoMessage() {}
+
+func (x *AdminFraudAlerts) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminFraudAlerts.ProtoReflect.Descriptor instead.
+func (*AdminFraudAlerts) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *AdminFraudAlerts) GetAlerts() []*FraudAlert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+type AdminFraudPairsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid int64 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// 读取的流水条数
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *AdminFraudPairsRequest) Reset() {
+	*x = AdminFraudPairsRequest{}
+	mi := &file_fingerplay_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminFraudPairsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminFraudPairsRequest) ProtoMessage() {}
+
+func (x *AdminFraudPairsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminFraudPairsRequest.ProtoReflect.Descriptor instead.
+func (*AdminFraudPairsRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *AdminFraudPairsRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *AdminFraudPairsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type FraudPairStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid             int64    `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	CounterpartyUid int64    `protobuf:"varint,2,opt,name=counterparty_uid,json=counterpartyUid,proto3" json:"counterparty_uid,omitempty"`
+	Matches         int32    `protobuf:"varint,3,opt,name=matches,proto3" json:"matches,omitempty"`
+	Rounds          int32    `protobuf:"varint,4,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	Losses          int32    `protobuf:"varint,5,opt,name=losses,proto3" json:"losses,omitempty"`
+	Net             float64  `protobuf:"fixed64,6,opt,name=net,proto3" json:"net,omitempty"`
+	FirstTs         int64    `protobuf:"varint,7,opt,name=first_ts,json=firstTs,proto3" json:"first_ts,omitempty"`
+	LastTs          int64    `protobuf:"varint,8,opt,name=last_ts,json=lastTs,proto3" json:"last_ts,omitempty"`
+	Flags           []string `protobuf:"bytes,9,rep,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *FraudPairStats) Reset() {
+	*x = FraudPairStats{}
+	mi := &file_fingerplay_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FraudPairStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FraudPairStats) ProtoMessage() {}
+
+func (x *FraudPairStats) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FraudPairStats.ProtoReflect.Descriptor instead.
+func (*FraudPairStats) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *FraudPairStats) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *FraudPairStats) GetCounterpartyUid() int64 {
+	if x != nil {
+		return x.CounterpartyUid
+	}
+	return 0
+}
+
+func (x *FraudPairStats) GetMatches() int32 {
+	if x != nil {
+		return x.Matches
+	}
+	return 0
+}
+
+func (x *FraudPairStats) GetRounds() int32 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *FraudPairStats) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *FraudPairStats) GetNet() float64 {
+	if x != nil {
+		return x.Net
+	}
+	return 0
+}
+
+func (x *FraudPairStats) GetFirstTs() int64 {
+	if x != nil {
+		return x.FirstTs
+	}
+	return 0
+}
+
+func (x *FraudPairStats) GetLastTs() int64 {
+	if x != nil {
+		return x.LastTs
+	}
+	return 0
+}
+
+func (x *FraudPairStats) GetFlags() []string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type AdminFraudPairs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pairs []*FraudPairStats `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+}
+
+func (x *AdminFraudPairs) Reset() {
+	*x = AdminFraudPairs{}
+	mi := &file_fingerplay_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminFraudPairs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminFraudPairs) ProtoMessage() {}
+
+func (x *AdminFraudPairs) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminFraudPairs.ProtoReflect.Descriptor instead.
+func (*AdminFraudPairs) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *AdminFraudPairs) GetPairs() []*FraudPairStats {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+type AdminFraudClearRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uids []int64 `protobuf:"varint,1,rep,packed,name=uids,proto3" json:"uids,omitempty"`
+}
+
+func (x *AdminFraudClearRequest) Reset() {
+	*x = AdminFraudClearRequest{}
+	mi := &file_fingerplay_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminFraudClearRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminFraudClearRequest) ProtoMessage() {}
+
+func (x *AdminFraudClearRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminFraudClearRequest.ProtoReflect.Descriptor instead.
+func (*AdminFraudClearRequest) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *AdminFraudClearRequest) GetUids() []int64 {
+	if x != nil {
+		return x.Uids
+	}
+	return nil
+}
+
+type AdminFraudClearResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cleared bool `protobuf:"varint,1,opt,name=cleared,proto3" json:"cleared,omitempty"`
+}
+
+func (x *AdminFraudClearResult) Reset() {
+	*x = AdminFraudClearResult{}
+	mi := &file_fingerplay_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminFraudClearResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminFraudClearResult) ProtoMessage() {}
+
+func (x *AdminFraudClearResult) ProtoReflect() protoreflect.Message {
+	mi := &file_fingerplay_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminFraudClearResult.ProtoReflect.Descriptor instead.
+func (*AdminFraudClearResult) Descriptor() ([]byte, []int) {
+	return file_fingerplay_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *AdminFraudClearResult) GetCleared() bool {
+	if x != nil {
+		return x.Cleared
+	}
+	return false
+}
+
+var File_fingerplay_proto protoreflect.FileDescriptor
+
+var file_fingerplay_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0d, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76,
+	0x31, 0x22, 0x7d, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x12,
+	0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74,
+	0x61, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72,
+	0x22, 0x64, 0x0a, 0x0c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x6b, 0x0a, 0x0d, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x34, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x66, 0x69,
+	0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0xa5, 0x02, 0x0a, 0x11, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c,
+	0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72,
+	0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x25, 0x0a,
+	0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x22, 0xa3, 0x01, 0x0a, 0x0c,
+	0x52, 0x65, 0x61, 0x64, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x22, 0x6b, 0x0a, 0x0d, 0x52, 0x65, 0x61, 0x64, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x34, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xb0,
+	0x01, 0x0a, 0x11, 0x52, 0x65, 0x61, 0x64, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x2f, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x22, 0x89, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x18, 0x0a, 0x07, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x77,
+	0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x77, 0x69, 0x6e, 0x22, 0x79, 0x0a,
+	0x12, 0x52, 0x65, 0x61, 0x64, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49,
+	0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x77, 0x0a, 0x13, 0x52, 0x65, 0x61, 0x64,
+	0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x3a, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x22, 0x31, 0x0a, 0x17, 0x52, 0x65, 0x61, 0x64, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0x73, 0x0a, 0x0c, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x35, 0x0a, 0x0d, 0x4c, 0x65, 0x61,
+	0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67,
+	0x22, 0x33, 0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x6f, 0x0a, 0x0f, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x36,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66,
+	0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e,
+	0x6b, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x49, 0x0a, 0x13, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x32, 0x0a,
+	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x22, 0x81, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4c, 0x6f, 0x67, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x77, 0x69, 0x6e,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x79, 0x0a, 0x14,
+	0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x3b, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x5d, 0x0a, 0x18, 0x4f, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x05, 0x72,
+	0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x69, 0x6e,
+	0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x52,
+	0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x22, 0x34, 0x0a, 0x04, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x43, 0x0a, 0x0c,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69,
+	0x64, 0x22, 0x6b, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x34, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xb1,
+	0x04, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x77, 0x69, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x77, 0x69,
+	0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x72,
+	0x61, 0x77, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x72, 0x61, 0x77, 0x73,
+	0x12, 0x19, 0x0a, 0x08, 0x77, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x6d,
+	0x6f, 0x76, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x69, 0x6e,
+	0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x4d, 0x6f, 0x76, 0x65, 0x73, 0x52, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x12, 0x25, 0x0a,
+	0x0e, 0x66, 0x61, 0x76, 0x6f, 0x75, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x66, 0x61, 0x76, 0x6f, 0x75, 0x72, 0x69, 0x74, 0x65,
+	0x4d, 0x6f, 0x76, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x5f,
+	0x77, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x10, 0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x57, 0x69, 0x6e, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6b, 0x12, 0x2e, 0x0a, 0x13, 0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x6c, 0x6f,
+	0x73, 0x73, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x11, 0x6c, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x4c, 0x6f, 0x73, 0x73, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x03, 0x6e, 0x65, 0x74, 0x12, 0x4b, 0x0a, 0x09, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x5f, 0x6e, 0x65,
+	0x74, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72,
+	0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x4e,
+	0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x4e, 0x65,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x66, 0x69, 0x72, 0x73, 0x74, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x64, 0x1a, 0x3b, 0x0a, 0x0d, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x4e, 0x65,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x55, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x6f, 0x76, 0x65,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x70, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x70, 0x61, 0x70, 0x65, 0x72, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x63, 0x69, 0x73, 0x73, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x73, 0x63, 0x69, 0x73, 0x73, 0x6f, 0x72, 0x73, 0x22, 0xcb, 0x01, 0x0a, 0x12, 0x4c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x69, 0x67, 0x68,
+	0x62, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6e, 0x65, 0x69,
+	0x67, 0x68, 0x62, 0x6f, 0x75, 0x72, 0x73, 0x22, 0x77, 0x0a, 0x13, 0x4c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6d, 0x73, 0x67, 0x12, 0x3a, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0xa2, 0x02, 0x0a, 0x17, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06,
+	0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x39, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x66, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x02, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x02, 0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x75,
+	0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x6e, 0x65, 0x69, 0x67, 0x68,
+	0x62, 0x6f, 0x75, 0x72, 0x73, 0x22, 0xae, 0x01, 0x0a, 0x10, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61,
+	0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x5c, 0x0a, 0x17, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x49, 0x64, 0x22, 0xfa, 0x02, 0x0a, 0x0a, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1e, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x45, 0x0a, 0x0b, 0x63, 0x6f,
+	0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74,
+	0x69, 0x74, 0x6f, 0x72, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0x54, 0x0a, 0x04, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x41, 0x54,
+	0x43, 0x48, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d,
+	0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x53, 0x45, 0x54, 0x54, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12,
+	0x10, 0x0a, 0x0c, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10,
+	0x03, 0x22, 0xa0, 0x01, 0x0a, 0x14, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x62, 0x6f,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x6f, 0x62, 0x6f, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x77, 0x69, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x77, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x22, 0x0c, 0x0a, 0x0a, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x76, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x57, 0x61, 0x69, 0x74, 0x69,
+	0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x75, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x62, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x72, 0x6f, 0x62, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x73, 0x22, 0x77, 0x0a, 0x10, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x57, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x07,
+	0x77, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x57, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x77, 0x61, 0x69, 0x74,
+	0x69, 0x6e, 0x67, 0x22, 0x4a, 0x0a, 0x11, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x57, 0x61, 0x69, 0x74,
+	0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x05, 0x6c, 0x69, 0x73, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72,
+	0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x57, 0x61, 0x69,
+	0x74, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x05, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x22,
+	0xab, 0x01, 0x0a, 0x0f, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69,
+	0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x62, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x72, 0x6f, 0x62, 0x6f, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6b, 0x65, 0x65,
+	0x70, 0x5f, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x6b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x54, 0x73, 0x22, 0xb1, 0x01,
+	0x0a, 0x11, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x52, 0x6f,
+	0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x77, 0x69, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x04, 0x77, 0x69, 0x6e,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x65,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x65,
+	0x64, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74,
+	0x73, 0x22, 0x8a, 0x02, 0x0a, 0x0a, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x70, 0x6f,
+	0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x70, 0x6f,
+	0x73, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x54, 0x73, 0x12, 0x40, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72,
+	0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6f, 0x6d,
+	0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x74, 0x6c, 0x65,
+	0x64, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x22, 0x43,
+	0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x33,
+	0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x22, 0x46, 0x0a, 0x11, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x22, 0x81, 0x01, 0x0a, 0x0b,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x55, 0x69, 0x64, 0x12, 0x15, 0x0a, 0x06,
+	0x74, 0x6f, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f,
+	0x55, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22,
+	0x65, 0x0a, 0x12, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x44, 0x69, 0x73, 0x70, 0x6f, 0x73, 0x65, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64,
+	0x12, 0x34, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x07, 0x72,
+	0x65, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x22, 0x24, 0x0a, 0x10, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4b,
+	0x69, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x3c, 0x0a, 0x17,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x29, 0x0a, 0x0f, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x6b, 0x69, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6b,
+	0x69, 0x63, 0x6b, 0x65, 0x64, 0x22, 0x29, 0x0a, 0x11, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x22, 0xc4, 0x02, 0x0a, 0x0f, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x6f, 0x62, 0x6f, 0x74, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x12, 0x45, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79,
+	0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x66, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52,
+	0x6f, 0x62, 0x6f, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x69, 0x6e,
+	0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x69, 0x6e, 0x67, 0x12,
+	0x26, 0x0a, 0x0f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x69, 0x6e, 0x5f, 0x75,
+	0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x49, 0x6e, 0x55, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x49, 0x64, 0x6c, 0x65, 0x12, 0x22, 0x0a, 0x0d,
+	0x6d, 0x61, 0x78, 0x5f, 0x72, 0x6f, 0x62, 0x6f, 0x74, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x52, 0x6f, 0x62, 0x6f, 0x74, 0x55, 0x69, 0x64,
+	0x12, 0x29, 0x0a, 0x10, 0x6c, 0x69, 0x66, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6c, 0x69, 0x66, 0x65,
+	0x74, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x50,
+	0x6c, 0x61, 0x79, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x54, 0x0a, 0x12, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xff, 0x01,
+	0x0a, 0x0b, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65,
+	0x72, 0x70, 0x61, 0x72, 0x74, 0x79, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x61, 0x72, 0x74, 0x79, 0x55, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x73,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07,
+	0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x0e, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x73, 0x22,
+	0x43, 0x0a, 0x0b, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x12, 0x34,
+	0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x22, 0x3a, 0x0a, 0x11, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x44, 0x72, 0x61,
+	0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x22, 0x48, 0x0a, 0x10, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0x54, 0x0a, 0x10, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69,
+	0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x22, 0xa6, 0x01, 0x0a, 0x17, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x70, 0x6f, 0x6f, 0x6c,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x22, 0x55, 0x0a, 0x17, 0x41, 0x64, 0x6d,
+	0x69, 0x6e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x22, 0xb1, 0x01, 0x0a, 0x0a, 0x46, 0x72, 0x61, 0x75, 0x64, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x04, 0x75, 0x69, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x74, 0x73, 0x22, 0x45, 0x0a, 0x10, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x61,
+	0x75, 0x64, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x61, 0x6c, 0x65, 0x72,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x41, 0x6c,
+	0x65, 0x72, 0x74, 0x52, 0x06, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x22, 0x40, 0x0a, 0x16, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x50, 0x61, 0x69, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xf3, 0x01,
+	0x0a, 0x0e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x50, 0x61, 0x69, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75,
+	0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x61, 0x72,
+	0x74, 0x79, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x61, 0x72, 0x74, 0x79, 0x55, 0x69, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x65, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6e, 0x65, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x5f, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x54, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6c, 0x61, 0x73, 0x74, 0x54, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x22, 0x46, 0x0a, 0x0f, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x61, 0x75,
+	0x64, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x33, 0x0a, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c,
+	0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x50, 0x61, 0x69, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x22, 0x2c, 0x0a, 0x16, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x03, 0x52, 0x04, 0x75, 0x69, 0x64, 0x73, 0x22, 0x31, 0x0a, 0x15, 0x41, 0x64, 0x6d,
+	0x69, 0x6e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x65, 0x64, 0x32, 0xc4, 0x05, 0x0a,
+	0x0a, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x79, 0x12, 0x42, 0x0a, 0x05, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x1b, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x42, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x1b, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c,
+	0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0b, 0x52, 0x65, 0x61, 0x64, 0x79, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x21, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c,
+	0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x05, 0x4c, 0x65, 0x61,
+	0x76, 0x65, 0x12, 0x1b, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a,
+	0x07, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72,
+	0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x4f, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x22, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72,
+	0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x66, 0x69,
+	0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x6e, 0x6c, 0x69,
+	0x6e, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x42, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x66, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0b, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x12, 0x21, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x10, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26,
+	0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x30, 0x01, 0x32, 0x82, 0x0a, 0x0a, 0x0f, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x50, 0x6c,
+	0x61, 0x79, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x4a, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x57,
+	0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x19, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x20, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x57, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x4c, 0x69,
+	0x73, 0x74, 0x73, 0x12, 0x45, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x12, 0x19, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e,
+	0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x47, 0x0a, 0x08, 0x47, 0x65,
+	0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x20, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x53, 0x0a, 0x0c, 0x44, 0x69, 0x73, 0x70, 0x6f, 0x73, 0x65, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x20, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c,
+	0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x44, 0x69, 0x73, 0x70, 0x6f,
+	0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x47, 0x0a, 0x04, 0x4b, 0x69, 0x63, 0x6b,
+	0x12, 0x1f, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x55, 0x0a, 0x0b, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x26, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4b, 0x69,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x49, 0x0a, 0x0a, 0x50, 0x61, 0x75, 0x73,
+	0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x20, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x4a, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x20, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x43, 0x0a, 0x06, 0x52, 0x6f, 0x62, 0x6f, 0x74, 0x73, 0x12, 0x19, 0x2e, 0x66, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1e, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x6f, 0x62, 0x6f, 0x74, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x47, 0x0a, 0x06, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x12, 0x21,
+	0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x12, 0x4a, 0x0a,
+	0x05, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x12, 0x20, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x44, 0x72, 0x61, 0x69,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x44, 0x72,
+	0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4c, 0x0a, 0x0e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x19, 0x2e, 0x66, 0x69,
+	0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1f, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x57, 0x0a, 0x12, 0x4c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x19, 0x2e,
+	0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x26, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x12, 0x56, 0x0a, 0x0b, 0x46, 0x72, 0x61, 0x75, 0x64, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x12,
+	0x26, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72,
+	0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x61,
+	0x75, 0x64, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x12, 0x53, 0x0a, 0x0a, 0x46, 0x72, 0x61, 0x75,
+	0x64, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x25, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70,
+	0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x61, 0x75,
+	0x64, 0x50, 0x61, 0x69, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x59, 0x0a,
+	0x0a, 0x46, 0x72, 0x61, 0x75, 0x64, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x12, 0x25, 0x2e, 0x66, 0x69,
+	0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x43, 0x6c, 0x65,
+	0x61, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42, 0x12, 0x5a, 0x10, 0x66, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x70, 0x6c, 0x61, 0x79, 0x2f, 0x70, 0x62, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_fingerplay_proto_rawDescOnce sync.Once
+	file_fingerplay_proto_rawDescData = file_fingerplay_proto_rawDesc
+)
+
+func file_fingerplay_proto_rawDescGZIP() []byte {
+	file_fingerplay_proto_rawDescOnce.Do(func() {
+		file_fingerplay_proto_rawDescData = protoimpl.X.CompressGZIP(file_fingerplay_proto_rawDescData)
+	})
+	return file_fingerplay_proto_rawDescData
+}
+
+var file_fingerplay_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_fingerplay_proto_msgTypes = make([]protoimpl.MessageInfo, 65)
+var file_fingerplay_proto_goTypes = []any{
+	(MatchEvent_Type)(0),             // 0: fingerplay.v1.MatchEvent.Type
+	(*Competitor)(nil),               // 1: fingerplay.v1.Competitor
+	(*MatchRequest)(nil),             // 2: fingerplay.v1.MatchRequest
+	(*MatchResponse)(nil),            // 3: fingerplay.v1.MatchResponse
+	(*MatchResponseData)(nil),        // 4: fingerplay.v1.MatchResponseData
+	(*ReadyRequest)(nil),             // 5: fingerplay.v1.ReadyRequest
+	(*ReadyResponse)(nil),            // 6: fingerplay.v1.ReadyResponse
+	(*ReadyResponseData)(nil),        // 7: fingerplay.v1.ReadyResponseData
+	(*Result)(nil),                   // 8: fingerplay.v1.Result
+	(*ReadyStatusRequest)(nil),       // 9: fingerplay.v1.ReadyStatusRequest
+	(*ReadyStatusResponse)(nil),      // 10: fingerplay.v1.ReadyStatusResponse
+	(*ReadyStatusResponseData)(nil),  // 11: fingerplay.v1.ReadyStatusResponseData
+	(*LeaveRequest)(nil),             // 12: fingerplay.v1.LeaveRequest
+	(*LeaveResponse)(nil),            // 13: fingerplay.v1.LeaveResponse
+	(*RankingRequest)(nil),           // 14: fingerplay.v1.RankingRequest
+	(*RankingResponse)(nil),          // 15: fingerplay.v1.RankingResponse
+	(*RankingResponseData)(nil),      // 16: fingerplay.v1.RankingResponseData
+	(*ResultLog)(nil),                // 17: fingerplay.v1.ResultLog
+	(*OnlineNumberRequest)(nil),      // 18: fingerplay.v1.OnlineNumberRequest
+	(*OnlineNumberResponse)(nil),     // 19: fingerplay.v1.OnlineNumberResponse
+	(*OnlineNumberResponseData)(nil), // 20: fingerplay.v1.OnlineNumberResponseData
+	(*Room)(nil),                     // 21: fingerplay.v1.Room
+	(*StatsRequest)(nil),             // 22: fingerplay.v1.StatsRequest
+	(*StatsResponse)(nil),            // 23: fingerplay.v1.StatsResponse
+	(*StatsResponseData)(nil),        // 24: fingerplay.v1.StatsResponseData
+	(*PlayerMoves)(nil),              // 25: fingerplay.v1.PlayerMoves
+	(*LeaderboardRequest)(nil),       // 26: fingerplay.v1.LeaderboardRequest
+	(*LeaderboardResponse)(nil),      // 27: fingerplay.v1.LeaderboardResponse
+	(*LeaderboardResponseData)(nil),  // 28: fingerplay.v1.LeaderboardResponseData
+	(*LeaderboardEntry)(nil),         // 29: fingerplay.v1.LeaderboardEntry
+	(*WatchMatchEventsRequest)(nil),  // 30: fingerplay.v1.WatchMatchEventsRequest
+	(*MatchEvent)(nil),               // 31: fingerplay.v1.MatchEvent
+	(*MatchEventCompetitor)(nil),     // 32: fingerplay.v1.MatchEventCompetitor
+	(*AdminEmpty)(nil),               // 33: fingerplay.v1.AdminEmpty
+	(*AdminWaiting)(nil),             // 34: fingerplay.v1.AdminWaiting
+	(*AdminWaitingList)(nil),         // 35: fingerplay.v1.AdminWaitingList
+	(*AdminWaitingLists)(nil),        // 36: fingerplay.v1.AdminWaitingLists
+	(*AdminCompetitor)(nil),          // 37: fingerplay.v1.AdminCompetitor
+	(*AdminSettledRound)(nil),        // 38: fingerplay.v1.AdminSettledRound
+	(*AdminMatch)(nil),               // 39: fingerplay.v1.AdminMatch
+	(*AdminMatches)(nil),             // 40: fingerplay.v1.AdminMatches
+	(*AdminMatchRequest)(nil),        // 41: fingerplay.v1.AdminMatchRequest
+	(*AdminRefund)(nil),              // 42: fingerplay.v1.AdminRefund
+	(*AdminDisposeResult)(nil),       // 43: fingerplay.v1.AdminDisposeResult
+	(*AdminKickRequest)(nil),         // 44: fingerplay.v1.AdminKickRequest
+	(*AdminRevokeTokenRequest)(nil),  // 45: fingerplay.v1.AdminRevokeTokenRequest
+	(*AdminKickResult)(nil),          // 46: fingerplay.v1.AdminKickResult
+	(*AdminLevelRequest)(nil),        // 47: fingerplay.v1.AdminLevelRequest
+	(*AdminRobotUsage)(nil),          // 48: fingerplay.v1.AdminRobotUsage
+	(*AdminLedgerRequest)(nil),       // 49: fingerplay.v1.AdminLedgerRequest
+	(*LedgerEntry)(nil),              // 50: fingerplay.v1.LedgerEntry
+	(*AdminLedger)(nil),              // 51: fingerplay.v1.AdminLedger
+	(*AdminDrainRequest)(nil),        // 52: fingerplay.v1.AdminDrainRequest
+	(*AdminDrainResult)(nil),         // 53: fingerplay.v1.AdminDrainResult
+	(*AdminConfigCheck)(nil),         // 54: fingerplay.v1.AdminConfigCheck
+	(*AdminLeaderboardRebuild)(nil),  // 55: fingerplay.v1.AdminLeaderboardRebuild
+	(*AdminFraudAlertsRequest)(nil),  // 56: fingerplay.v1.AdminFraudAlertsRequest
+	(*FraudAlert)(nil),               // 57: fingerplay.v1.FraudAlert
+	(*AdminFraudAlerts)(nil),         // 58: fingerplay.v1.AdminFraudAlerts
+	(*AdminFraudPairsRequest)(nil),   // 59: fingerplay.v1.AdminFraudPairsRequest
+	(*FraudPairStats)(nil),           // 60: fingerplay.v1.FraudPairStats
+	(*AdminFraudPairs)(nil),          // 61: fingerplay.v1.AdminFraudPairs
+	(*AdminFraudClearRequest)(nil),   // 62: fingerplay.v1.AdminFraudClearRequest
+	(*AdminFraudClearResult)(nil),    // 63: fingerplay.v1.AdminFraudClearResult
+	nil,                              // 64: fingerplay.v1.StatsResponseData.LevelNetEntry
+	nil,                              // 65: fingerplay.v1.AdminRobotUsage.PlayingEntry
+}
+var file_fingerplay_proto_depIdxs = []int32{
+	4,  // 0: fingerplay.v1.MatchResponse.data:type_name -> fingerplay.v1.MatchResponseData
+	1,  // 1: fingerplay.v1.MatchResponseData.competitors:type_name -> fingerplay.v1.Competitor
+	7,  // 2: fingerplay.v1.ReadyResponse.data:type_name -> fingerplay.v1.ReadyResponseData
+	8,  // 3: fingerplay.v1.ReadyResponseData.results:type_name -> fingerplay.v1.Result
+	11, // 4: fingerplay.v1.ReadyStatusResponse.data:type_name -> fingerplay.v1.ReadyStatusResponseData
+	16, // 5: fingerplay.v1.RankingResponse.data:type_name -> fingerplay.v1.RankingResponseData
+	17, // 6: fingerplay.v1.RankingResponseData.results:type_name -> fingerplay.v1.ResultLog
+	20, // 7: fingerplay.v1.OnlineNumberResponse.data:type_name -> fingerplay.v1.OnlineNumberResponseData
+	21, // 8: fingerplay.v1.OnlineNumberResponseData.rooms:type_name -> fingerplay.v1.Room
+	24, // 9: fingerplay.v1.StatsResponse.data:type_name -> fingerplay.v1.StatsResponseData
+	25, // 10: fingerplay.v1.StatsResponseData.moves:type_name -> fingerplay.v1.PlayerMoves
+	64, // 11: fingerplay.v1.StatsResponseData.level_net:type_name -> fingerplay.v1.StatsResponseData.LevelNetEntry
+	28, // 12: fingerplay.v1.LeaderboardResponse.data:type_name -> fingerplay.v1.LeaderboardResponseData
+	29, // 13: fingerplay.v1.LeaderboardResponseData.entries:type_name -> fingerplay.v1.LeaderboardEntry
+	29, // 14: fingerplay.v1.LeaderboardResponseData.me:type_name -> fingerplay.v1.LeaderboardEntry
+	29, // 15: fingerplay.v1.LeaderboardResponseData.neighbours:type_name -> fingerplay.v1.LeaderboardEntry
+	0,  // 16: fingerplay.v1.MatchEvent.type:type_name -> fingerplay.v1.MatchEvent.Type
+	32, // 17: fingerplay.v1.MatchEvent.competitors:type_name -> fingerplay.v1.MatchEventCompetitor
+	34, // 18: fingerplay.v1.AdminWaitingList.waiting:type_name -> fingerplay.v1.AdminWaiting
+	35, // 19: fingerplay.v1.AdminWaitingLists.lists:type_name -> fingerplay.v1.AdminWaitingList
+	37, // 20: fingerplay.v1.AdminMatch.competitors:type_name -> fingerplay.v1.AdminCompetitor
+	38, // 21: fingerplay.v1.AdminMatch.rounds:type_name -> fingerplay.v1.AdminSettledRound
+	39, // 22: fingerplay.v1.AdminMatches.matches:type_name -> fingerplay.v1.AdminMatch
+	42, // 23: fingerplay.v1.AdminDisposeResult.refunds:type_name -> fingerplay.v1.AdminRefund
+	65, // 24: fingerplay.v1.AdminRobotUsage.playing:type_name -> fingerplay.v1.AdminRobotUsage.PlayingEntry
+	50, // 25: fingerplay.v1.AdminLedger.entries:type_name -> fingerplay.v1.LedgerEntry
+	57, // 26: fingerplay.v1.AdminFraudAlerts.alerts:type_name -> fingerplay.v1.FraudAlert
+	60, // 27: fingerplay.v1.AdminFraudPairs.pairs:type_name -> fingerplay.v1.FraudPairStats
+	2,  // 28: fingerplay.v1.FingerPlay.Match:input_type -> fingerplay.v1.MatchRequest
+	5,  // 29: fingerplay.v1.FingerPlay.Ready:input_type -> fingerplay.v1.ReadyRequest
+	9,  // 30: fingerplay.v1.FingerPlay.ReadyStatus:input_type -> fingerplay.v1.ReadyStatusRequest
+	12, // 31: fingerplay.v1.FingerPlay.Leave:input_type -> fingerplay.v1.LeaveRequest
+	14, // 32: fingerplay.v1.FingerPlay.Ranking:input_type -> fingerplay.v1.RankingRequest
+	18, // 33: fingerplay.v1.FingerPlay.OnlineNumber:input_type -> fingerplay.v1.OnlineNumberRequest
+	22, // 34: fingerplay.v1.FingerPlay.Stats:input_type -> fingerplay.v1.StatsRequest
+	26, // 35: fingerplay.v1.FingerPlay.Leaderboard:input_type -> fingerplay.v1.LeaderboardRequest
+	30, // 36: fingerplay.v1.FingerPlay.WatchMatchEvents:input_type -> fingerplay.v1.WatchMatchEventsRequest
+	33, // 37: fingerplay.v1.FingerPlayAdmin.ListWaiting:input_type -> fingerplay.v1.AdminEmpty
+	33, // 38: fingerplay.v1.FingerPlayAdmin.ListMatches:input_type -> fingerplay.v1.AdminEmpty
+	41, // 39: fingerplay.v1.FingerPlayAdmin.GetMatch:input_type -> fingerplay.v1.AdminMatchRequest
+	41, // 40: fingerplay.v1.FingerPlayAdmin.DisposeMatch:input_type -> fingerplay.v1.AdminMatchRequest
+	44, // 41: fingerplay.v1.FingerPlayAdmin.Kick:input_type -> fingerplay.v1.AdminKickRequest
+	45, // 42: fingerplay.v1.FingerPlayAdmin.RevokeToken:input_type -> fingerplay.v1.AdminRevokeTokenRequest
+	47, // 43: fingerplay.v1.FingerPlayAdmin.PauseLevel:input_type -> fingerplay.v1.AdminLevelRequest
+	47, // 44: fingerplay.v1.FingerPlayAdmin.ResumeLevel:input_type -> fingerplay.v1.AdminLevelRequest
+	33, // 45: fingerplay.v1.FingerPlayAdmin.Robots:input_type -> fingerplay.v1.AdminEmpty
+	49, // 46: fingerplay.v1.FingerPlayAdmin.Ledger:input_type -> fingerplay.v1.AdminLedgerRequest
+	52, // 47: fingerplay.v1.FingerPlayAdmin.Drain:input_type -> fingerplay.v1.AdminDrainRequest
+	33, // 48: fingerplay.v1.FingerPlayAdmin.ConfigValidate:input_type -> fingerplay.v1.AdminEmpty
+	33, // 49: fingerplay.v1.FingerPlayAdmin.LeaderboardRebuild:input_type -> fingerplay.v1.AdminEmpty
+	56, // 50: fingerplay.v1.FingerPlayAdmin.FraudAlerts:input_type -> fingerplay.v1.AdminFraudAlertsRequest
+	59, // 51: fingerplay.v1.FingerPlayAdmin.FraudPairs:input_type -> fingerplay.v1.AdminFraudPairsRequest
+	62, // 52: fingerplay.v1.FingerPlayAdmin.FraudClear:input_type -> fingerplay.v1.AdminFraudClearRequest
+	3,  // 53: fingerplay.v1.FingerPlay.Match:output_type -> fingerplay.v1.MatchResponse
+	6,  // 54: fingerplay.v1.FingerPlay.Ready:output_type -> fingerplay.v1.ReadyResponse
+	10, // 55: fingerplay.v1.FingerPlay.ReadyStatus:output_type -> fingerplay.v1.ReadyStatusResponse
+	13, // 56: fingerplay.v1.FingerPlay.Leave:output_type -> fingerplay.v1.LeaveResponse
+	15, // 57: fingerplay.v1.FingerPlay.Ranking:output_type -> fingerplay.v1.RankingResponse
+	19, // 58: fingerplay.v1.FingerPlay.OnlineNumber:output_type -> fingerplay.v1.OnlineNumberResponse
+	23, // 59: fingerplay.v1.FingerPlay.Stats:output_type -> fingerplay.v1.StatsResponse
+	27, // 60: fingerplay.v1.FingerPlay.Leaderboard:output_type -> fingerplay.v1.LeaderboardResponse
+	31, // 61: fingerplay.v1.FingerPlay.WatchMatchEvents:output_type -> fingerplay.v1.MatchEvent
+	36, // 62: fingerplay.v1.FingerPlayAdmin.ListWaiting:output_type -> fingerplay.v1.AdminWaitingLists
+	40, // 63: fingerplay.v1.FingerPlayAdmin.ListMatches:output_type -> fingerplay.v1.AdminMatches
+	39, // 64: fingerplay.v1.FingerPlayAdmin.GetMatch:output_type -> fingerplay.v1.AdminMatch
+	43, // 65: fingerplay.v1.FingerPlayAdmin.DisposeMatch:output_type -> fingerplay.v1.AdminDisposeResult
+	46, // 66: fingerplay.v1.FingerPlayAdmin.Kick:output_type -> fingerplay.v1.AdminKickResult
+	46, // 67: fingerplay.v1.FingerPlayAdmin.RevokeToken:output_type -> fingerplay.v1.AdminKickResult
+	33, // 68: fingerplay.v1.FingerPlayAdmin.PauseLevel:output_type -> fingerplay.v1.AdminEmpty
+	33, // 69: fingerplay.v1.FingerPlayAdmin.ResumeLevel:output_type -> fingerplay.v1.AdminEmpty
+	48, // 70: fingerplay.v1.FingerPlayAdmin.Robots:output_type -> fingerplay.v1.AdminRobotUsage
+	51, // 71: fingerplay.v1.FingerPlayAdmin.Ledger:output_type -> fingerplay.v1.AdminLedger
+	53, // 72: fingerplay.v1.FingerPlayAdmin.Drain:output_type -> fingerplay.v1.AdminDrainResult
+	54, // 73: fingerplay.v1.FingerPlayAdmin.ConfigValidate:output_type -> fingerplay.v1.AdminConfigCheck
+	55, // 74: fingerplay.v1.FingerPlayAdmin.LeaderboardRebuild:output_type -> fingerplay.v1.AdminLeaderboardRebuild
+	58, // 75: fingerplay.v1.FingerPlayAdmin.FraudAlerts:output_type -> fingerplay.v1.AdminFraudAlerts
+	61, // 76: fingerplay.v1.FingerPlayAdmin.FraudPairs:output_type -> fingerplay.v1.AdminFraudPairs
+	63, // 77: fingerplay.v1.FingerPlayAdmin.FraudClear:output_type -> fingerplay.v1.AdminFraudClearResult
+	53, // [53:78] is the sub-list for method output_type
+	28, // [28:53] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_fingerplay_proto_init() }
+func file_fingerplay_proto_init() {
+	if File_fingerplay_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_fingerplay_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   65,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_fingerplay_proto_goTypes,
+		DependencyIndexes: file_fingerplay_proto_depIdxs,
+		EnumInfos:         file_fingerplay_proto_enumTypes,
+		MessageInfos:      file_fingerplay_proto_msgTypes,
+	}.Build()
+	File_fingerplay_proto = out.File
+	file_fingerplay_proto_rawDesc = nil
+	file_fingerplay_proto_goTypes = nil
+	file_fingerplay_proto_depIdxs = nil
+}
@@ -0,0 +1,1125 @@
+// gRPC接口，与JSON接口共用同一个LogicImpl。消息与proto.go和admin.go中的结构一一对应，
+// code和msg的含义与JSON接口相同：业务结果放在code里，gRPC状态只用于
+// 认证失败(UNAUTHENTICATED)、请求不符合schema(INVALID_ARGUMENT)和
+// 比赛在其他节点上(FAILED_PRECONDITION)。
+//
+// 修改后重新生成：
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//		--go-grpc_out=. --go-grpc_opt=paths=source_relative fingerplay.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: fingerplay.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FingerPlay_Match_FullMethodName            = "/fingerplay.v1.FingerPlay/Match"
+	FingerPlay_Ready_FullMethodName            = "/fingerplay.v1.FingerPlay/Ready"
+	FingerPlay_ReadyStatus_FullMethodName      = "/fingerplay.v1.FingerPlay/ReadyStatus"
+	FingerPlay_Leave_FullMethodName            = "/fingerplay.v1.FingerPlay/Leave"
+	FingerPlay_Ranking_FullMethodName          = "/fingerplay.v1.FingerPlay/Ranking"
+	FingerPlay_OnlineNumber_FullMethodName     = "/fingerplay.v1.FingerPlay/OnlineNumber"
+	FingerPlay_Stats_FullMethodName            = "/fingerplay.v1.FingerPlay/Stats"
+	FingerPlay_Leaderboard_FullMethodName      = "/fingerplay.v1.FingerPlay/Leaderboard"
+	FingerPlay_WatchMatchEvents_FullMethodName = "/fingerplay.v1.FingerPlay/WatchMatchEvents"
+)
+
+// FingerPlayClient is the client API for FingerPlay service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 玩家接口，与/fingerplay/v1/*相同。WatchMatchEvents只给后台服务使用，
+// 需要在metadata中带上authorization: Bearer <admin_token>
+type FingerPlayClient interface {
+	Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchResponse, error)
+	Ready(ctx context.Context, in *ReadyRequest, opts ...grpc.CallOption) (*ReadyResponse, error)
+	ReadyStatus(ctx context.Context, in *ReadyStatusRequest, opts ...grpc.CallOption) (*ReadyStatusResponse, error)
+	Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error)
+	Ranking(ctx context.Context, in *RankingRequest, opts ...grpc.CallOption) (*RankingResponse, error)
+	OnlineNumber(ctx context.Context, in *OnlineNumberRequest, opts ...grpc.CallOption) (*OnlineNumberResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	Leaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error)
+	// 本节点上比赛的创建、每回合的结算和结束
+	WatchMatchEvents(ctx context.Context, in *WatchMatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchEvent], error)
+}
+
+type fingerPlayClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFingerPlayClient(cc grpc.ClientConnInterface) FingerPlayClient {
+	return &fingerPlayClient{cc}
+}
+
+func (c *fingerPlayClient) Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (*MatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchResponse)
+	err := c.cc.Invoke(ctx, FingerPlay_Match_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayClient) Ready(ctx context.Context, in *ReadyRequest, opts ...grpc.CallOption) (*ReadyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadyResponse)
+	err := c.cc.Invoke(ctx, FingerPlay_Ready_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayClient) ReadyStatus(ctx context.Context, in *ReadyStatusRequest, opts ...grpc.CallOption) (*ReadyStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadyStatusResponse)
+	err := c.cc.Invoke(ctx, FingerPlay_ReadyStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayClient) Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveResponse)
+	err := c.cc.Invoke(ctx, FingerPlay_Leave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayClient) Ranking(ctx context.Context, in *RankingRequest, opts ...grpc.CallOption) (*RankingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RankingResponse)
+	err := c.cc.Invoke(ctx, FingerPlay_Ranking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayClient) OnlineNumber(ctx context.Context, in *OnlineNumberRequest, opts ...grpc.CallOption) (*OnlineNumberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OnlineNumberResponse)
+	err := c.cc.Invoke(ctx, FingerPlay_OnlineNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, FingerPlay_Stats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayClient) Leaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaderboardResponse)
+	err := c.cc.Invoke(ctx, FingerPlay_Leaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayClient) WatchMatchEvents(ctx context.Context, in *WatchMatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FingerPlay_ServiceDesc.Streams[0], FingerPlay_WatchMatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMatchEventsRequest, MatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FingerPlay_WatchMatchEventsClient = grpc.ServerStreamingClient[MatchEvent]
+
+// FingerPlayServer is the server API for FingerPlay service.
+// All implementations must embed UnimplementedFingerPlayServer
+// for forward compatibility.
+//
+// 玩家接口，与/fingerplay/v1/*相同。WatchMatchEvents只给后台服务使用，
+// 需要在metadata中带上authorization: Bearer <admin_token>
+type FingerPlayServer interface {
+	Match(context.Context, *MatchRequest) (*MatchResponse, error)
+	Ready(context.Context, *ReadyRequest) (*ReadyResponse, error)
+	ReadyStatus(context.Context, *ReadyStatusRequest) (*ReadyStatusResponse, error)
+	Leave(context.Context, *LeaveRequest) (*LeaveResponse, error)
+	Ranking(context.Context, *RankingRequest) (*RankingResponse, error)
+	OnlineNumber(context.Context, *OnlineNumberRequest) (*OnlineNumberResponse, error)
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	Leaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error)
+	// 本节点上比赛的创建、每回合的结算和结束
+	WatchMatchEvents(*WatchMatchEventsRequest, grpc.ServerStreamingServer[MatchEvent]) error
+	mustEmbedUnimplementedFingerPlayServer()
+}
+
+// UnimplementedFingerPlayServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFingerPlayServer struct{}
+
+func (UnimplementedFingerPlayServer) Match(context.Context, *MatchRequest) (*MatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Match not implemented")
+}
+func (UnimplementedFingerPlayServer) Ready(context.Context, *ReadyRequest) (*ReadyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ready not implemented")
+}
+func (UnimplementedFingerPlayServer) ReadyStatus(context.Context, *ReadyStatusRequest) (*ReadyStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadyStatus not implemented")
+}
+func (UnimplementedFingerPlayServer) Leave(context.Context, *LeaveRequest) (*LeaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+}
+func (UnimplementedFingerPlayServer) Ranking(context.Context, *RankingRequest) (*RankingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ranking not implemented")
+}
+func (UnimplementedFingerPlayServer) OnlineNumber(context.Context, *OnlineNumberRequest) (*OnlineNumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnlineNumber not implemented")
+}
+func (UnimplementedFingerPlayServer) Stats(context.Context, *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedFingerPlayServer) Leaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leaderboard not implemented")
+}
+func (UnimplementedFingerPlayServer) WatchMatchEvents(*WatchMatchEventsRequest, grpc.ServerStreamingServer[MatchEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMatchEvents not implemented")
+}
+func (UnimplementedFingerPlayServer) mustEmbedUnimplementedFingerPlayServer() {}
+func (UnimplementedFingerPlayServer) testEmbeddedByValue()                    {}
+
+// UnsafeFingerPlayServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FingerPlayServer will
+// result in compilation errors.
+type UnsafeFingerPlayServer interface {
+	mustEmbedUnimplementedFingerPlayServer()
+}
+
+func RegisterFingerPlayServer(s grpc.ServiceRegistrar, srv FingerPlayServer) {
+	// If the following call pancis, it indicates UnimplementedFingerPlayServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FingerPlay_ServiceDesc, srv)
+}
+
+func _FingerPlay_Match_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayServer).Match(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlay_Match_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayServer).Match(ctx, req.(*MatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlay_Ready_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayServer).Ready(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlay_Ready_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayServer).Ready(ctx, req.(*ReadyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlay_ReadyStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadyStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayServer).ReadyStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlay_ReadyStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayServer).ReadyStatus(ctx, req.(*ReadyStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlay_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayServer).Leave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlay_Leave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayServer).Leave(ctx, req.(*LeaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlay_Ranking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RankingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayServer).Ranking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlay_Ranking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayServer).Ranking(ctx, req.(*RankingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlay_OnlineNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnlineNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayServer).OnlineNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlay_OnlineNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayServer).OnlineNumber(ctx, req.(*OnlineNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlay_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlay_Stats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlay_Leaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayServer).Leaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlay_Leaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayServer).Leaderboard(ctx, req.(*LeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlay_WatchMatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FingerPlayServer).WatchMatchEvents(m, &grpc.GenericServerStream[WatchMatchEventsRequest, MatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FingerPlay_WatchMatchEventsServer = grpc.ServerStreamingServer[MatchEvent]
+
+// FingerPlay_ServiceDesc is the grpc.ServiceDesc for FingerPlay service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FingerPlay_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fingerplay.v1.FingerPlay",
+	HandlerType: (*FingerPlayServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Match",
+			Handler:    _FingerPlay_Match_Handler,
+		},
+		{
+			MethodName: "Ready",
+			Handler:    _FingerPlay_Ready_Handler,
+		},
+		{
+			MethodName: "ReadyStatus",
+			Handler:    _FingerPlay_ReadyStatus_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _FingerPlay_Leave_Handler,
+		},
+		{
+			MethodName: "Ranking",
+			Handler:    _FingerPlay_Ranking_Handler,
+		},
+		{
+			MethodName: "OnlineNumber",
+			Handler:    _FingerPlay_OnlineNumber_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _FingerPlay_Stats_Handler,
+		},
+		{
+			MethodName: "Leaderboard",
+			Handler:    _FingerPlay_Leaderboard_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMatchEvents",
+			Handler:       _FingerPlay_WatchMatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "fingerplay.proto",
+}
+
+const (
+	FingerPlayAdmin_ListWaiting_FullMethodName        = "/fingerplay.v1.FingerPlayAdmin/ListWaiting"
+	FingerPlayAdmin_ListMatches_FullMethodName        = "/fingerplay.v1.FingerPlayAdmin/ListMatches"
+	FingerPlayAdmin_GetMatch_FullMethodName           = "/fingerplay.v1.FingerPlayAdmin/GetMatch"
+	FingerPlayAdmin_DisposeMatch_FullMethodName       = "/fingerplay.v1.FingerPlayAdmin/DisposeMatch"
+	FingerPlayAdmin_Kick_FullMethodName               = "/fingerplay.v1.FingerPlayAdmin/Kick"
+	FingerPlayAdmin_RevokeToken_FullMethodName        = "/fingerplay.v1.FingerPlayAdmin/RevokeToken"
+	FingerPlayAdmin_PauseLevel_FullMethodName         = "/fingerplay.v1.FingerPlayAdmin/PauseLevel"
+	FingerPlayAdmin_ResumeLevel_FullMethodName        = "/fingerplay.v1.FingerPlayAdmin/ResumeLevel"
+	FingerPlayAdmin_Robots_FullMethodName             = "/fingerplay.v1.FingerPlayAdmin/Robots"
+	FingerPlayAdmin_Ledger_FullMethodName             = "/fingerplay.v1.FingerPlayAdmin/Ledger"
+	FingerPlayAdmin_Drain_FullMethodName              = "/fingerplay.v1.FingerPlayAdmin/Drain"
+	FingerPlayAdmin_ConfigValidate_FullMethodName     = "/fingerplay.v1.FingerPlayAdmin/ConfigValidate"
+	FingerPlayAdmin_LeaderboardRebuild_FullMethodName = "/fingerplay.v1.FingerPlayAdmin/LeaderboardRebuild"
+	FingerPlayAdmin_FraudAlerts_FullMethodName        = "/fingerplay.v1.FingerPlayAdmin/FraudAlerts"
+	FingerPlayAdmin_FraudPairs_FullMethodName         = "/fingerplay.v1.FingerPlayAdmin/FraudPairs"
+	FingerPlayAdmin_FraudClear_FullMethodName         = "/fingerplay.v1.FingerPlayAdmin/FraudClear"
+)
+
+// FingerPlayAdminClient is the client API for FingerPlayAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 管理接口，与/admin/v1/*相同，所有调用都需要admin_token。
+// 错误通过gRPC状态返回：找不到比赛或等级为NOT_FOUND，比赛在其他节点上为FAILED_PRECONDITION
+type FingerPlayAdminClient interface {
+	ListWaiting(ctx context.Context, in *AdminEmpty, opts ...grpc.CallOption) (*AdminWaitingLists, error)
+	ListMatches(ctx context.Context, in *AdminEmpty, opts ...grpc.CallOption) (*AdminMatches, error)
+	GetMatch(ctx context.Context, in *AdminMatchRequest, opts ...grpc.CallOption) (*AdminMatch, error)
+	DisposeMatch(ctx context.Context, in *AdminMatchRequest, opts ...grpc.CallOption) (*AdminDisposeResult, error)
+	Kick(ctx context.Context, in *AdminKickRequest, opts ...grpc.CallOption) (*AdminKickResult, error)
+	RevokeToken(ctx context.Context, in *AdminRevokeTokenRequest, opts ...grpc.CallOption) (*AdminKickResult, error)
+	PauseLevel(ctx context.Context, in *AdminLevelRequest, opts ...grpc.CallOption) (*AdminEmpty, error)
+	ResumeLevel(ctx context.Context, in *AdminLevelRequest, opts ...grpc.CallOption) (*AdminEmpty, error)
+	Robots(ctx context.Context, in *AdminEmpty, opts ...grpc.CallOption) (*AdminRobotUsage, error)
+	Ledger(ctx context.Context, in *AdminLedgerRequest, opts ...grpc.CallOption) (*AdminLedger, error)
+	Drain(ctx context.Context, in *AdminDrainRequest, opts ...grpc.CallOption) (*AdminDrainResult, error)
+	ConfigValidate(ctx context.Context, in *AdminEmpty, opts ...grpc.CallOption) (*AdminConfigCheck, error)
+	LeaderboardRebuild(ctx context.Context, in *AdminEmpty, opts ...grpc.CallOption) (*AdminLeaderboardRebuild, error)
+	FraudAlerts(ctx context.Context, in *AdminFraudAlertsRequest, opts ...grpc.CallOption) (*AdminFraudAlerts, error)
+	FraudPairs(ctx context.Context, in *AdminFraudPairsRequest, opts ...grpc.CallOption) (*AdminFraudPairs, error)
+	FraudClear(ctx context.Context, in *AdminFraudClearRequest, opts ...grpc.CallOption) (*AdminFraudClearResult, error)
+}
+
+type fingerPlayAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFingerPlayAdminClient(cc grpc.ClientConnInterface) FingerPlayAdminClient {
+	return &fingerPlayAdminClient{cc}
+}
+
+func (c *fingerPlayAdminClient) ListWaiting(ctx context.Context, in *AdminEmpty, opts ...grpc.CallOption) (*AdminWaitingLists, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminWaitingLists)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_ListWaiting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) ListMatches(ctx context.Context, in *AdminEmpty, opts ...grpc.CallOption) (*AdminMatches, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminMatches)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_ListMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) GetMatch(ctx context.Context, in *AdminMatchRequest, opts ...grpc.CallOption) (*AdminMatch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminMatch)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_GetMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) DisposeMatch(ctx context.Context, in *AdminMatchRequest, opts ...grpc.CallOption) (*AdminDisposeResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminDisposeResult)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_DisposeMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) Kick(ctx context.Context, in *AdminKickRequest, opts ...grpc.CallOption) (*AdminKickResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminKickResult)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_Kick_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) RevokeToken(ctx context.Context, in *AdminRevokeTokenRequest, opts ...grpc.CallOption) (*AdminKickResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminKickResult)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_RevokeToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) PauseLevel(ctx context.Context, in *AdminLevelRequest, opts ...grpc.CallOption) (*AdminEmpty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminEmpty)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_PauseLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) ResumeLevel(ctx context.Context, in *AdminLevelRequest, opts ...grpc.CallOption) (*AdminEmpty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminEmpty)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_ResumeLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) Robots(ctx context.Context, in *AdminEmpty, opts ...grpc.CallOption) (*AdminRobotUsage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminRobotUsage)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_Robots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) Ledger(ctx context.Context, in *AdminLedgerRequest, opts ...grpc.CallOption) (*AdminLedger, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminLedger)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_Ledger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) Drain(ctx context.Context, in *AdminDrainRequest, opts ...grpc.CallOption) (*AdminDrainResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminDrainResult)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_Drain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) ConfigValidate(ctx context.Context, in *AdminEmpty, opts ...grpc.CallOption) (*AdminConfigCheck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminConfigCheck)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_ConfigValidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) LeaderboardRebuild(ctx context.Context, in *AdminEmpty, opts ...grpc.CallOption) (*AdminLeaderboardRebuild, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminLeaderboardRebuild)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_LeaderboardRebuild_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) FraudAlerts(ctx context.Context, in *AdminFraudAlertsRequest, opts ...grpc.CallOption) (*AdminFraudAlerts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminFraudAlerts)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_FraudAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) FraudPairs(ctx context.Context, in *AdminFraudPairsRequest, opts ...grpc.CallOption) (*AdminFraudPairs, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminFraudPairs)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_FraudPairs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fingerPlayAdminClient) FraudClear(ctx context.Context, in *AdminFraudClearRequest, opts ...grpc.CallOption) (*AdminFraudClearResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminFraudClearResult)
+	err := c.cc.Invoke(ctx, FingerPlayAdmin_FraudClear_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FingerPlayAdminServer is the server API for FingerPlayAdmin service.
+// All implementations must embed UnimplementedFingerPlayAdminServer
+// for forward compatibility.
+//
+// 管理接口，与/admin/v1/*相同，所有调用都需要admin_token。
+// 错误通过gRPC状态返回：找不到比赛或等级为NOT_FOUND，比赛在其他节点上为FAILED_PRECONDITION
+type FingerPlayAdminServer interface {
+	ListWaiting(context.Context, *AdminEmpty) (*AdminWaitingLists, error)
+	ListMatches(context.Context, *AdminEmpty) (*AdminMatches, error)
+	GetMatch(context.Context, *AdminMatchRequest) (*AdminMatch, error)
+	DisposeMatch(context.Context, *AdminMatchRequest) (*AdminDisposeResult, error)
+	Kick(context.Context, *AdminKickRequest) (*AdminKickResult, error)
+	RevokeToken(context.Context, *AdminRevokeTokenRequest) (*AdminKickResult, error)
+	PauseLevel(context.Context, *AdminLevelRequest) (*AdminEmpty, error)
+	ResumeLevel(context.Context, *AdminLevelRequest) (*AdminEmpty, error)
+	Robots(context.Context, *AdminEmpty) (*AdminRobotUsage, error)
+	Ledger(context.Context, *AdminLedgerRequest) (*AdminLedger, error)
+	Drain(context.Context, *AdminDrainRequest) (*AdminDrainResult, error)
+	ConfigValidate(context.Context, *AdminEmpty) (*AdminConfigCheck, error)
+	LeaderboardRebuild(context.Context, *AdminEmpty) (*AdminLeaderboardRebuild, error)
+	FraudAlerts(context.Context, *AdminFraudAlertsRequest) (*AdminFraudAlerts, error)
+	FraudPairs(context.Context, *AdminFraudPairsRequest) (*AdminFraudPairs, error)
+	FraudClear(context.Context, *AdminFraudClearRequest) (*AdminFraudClearResult, error)
+	mustEmbedUnimplementedFingerPlayAdminServer()
+}
+
+// UnimplementedFingerPlayAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFingerPlayAdminServer struct{}
+
+func (UnimplementedFingerPlayAdminServer) ListWaiting(context.Context, *AdminEmpty) (*AdminWaitingLists, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWaiting not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) ListMatches(context.Context, *AdminEmpty) (*AdminMatches, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) GetMatch(context.Context, *AdminMatchRequest) (*AdminMatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatch not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) DisposeMatch(context.Context, *AdminMatchRequest) (*AdminDisposeResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisposeMatch not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) Kick(context.Context, *AdminKickRequest) (*AdminKickResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Kick not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) RevokeToken(context.Context, *AdminRevokeTokenRequest) (*AdminKickResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeToken not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) PauseLevel(context.Context, *AdminLevelRequest) (*AdminEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseLevel not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) ResumeLevel(context.Context, *AdminLevelRequest) (*AdminEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeLevel not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) Robots(context.Context, *AdminEmpty) (*AdminRobotUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Robots not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) Ledger(context.Context, *AdminLedgerRequest) (*AdminLedger, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ledger not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) Drain(context.Context, *AdminDrainRequest) (*AdminDrainResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Drain not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) ConfigValidate(context.Context, *AdminEmpty) (*AdminConfigCheck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigValidate not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) LeaderboardRebuild(context.Context, *AdminEmpty) (*AdminLeaderboardRebuild, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaderboardRebuild not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) FraudAlerts(context.Context, *AdminFraudAlertsRequest) (*AdminFraudAlerts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FraudAlerts not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) FraudPairs(context.Context, *AdminFraudPairsRequest) (*AdminFraudPairs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FraudPairs not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) FraudClear(context.Context, *AdminFraudClearRequest) (*AdminFraudClearResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FraudClear not implemented")
+}
+func (UnimplementedFingerPlayAdminServer) mustEmbedUnimplementedFingerPlayAdminServer() {}
+func (UnimplementedFingerPlayAdminServer) testEmbeddedByValue()                         {}
+
+// UnsafeFingerPlayAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FingerPlayAdminServer will
+// result in compilation errors.
+type UnsafeFingerPlayAdminServer interface {
+	mustEmbedUnimplementedFingerPlayAdminServer()
+}
+
+func RegisterFingerPlayAdminServer(s grpc.ServiceRegistrar, srv FingerPlayAdminServer) {
+	// If the following call pancis, it indicates UnimplementedFingerPlayAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FingerPlayAdmin_ServiceDesc, srv)
+}
+
+func _FingerPlayAdmin_ListWaiting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminEmpty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).ListWaiting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_ListWaiting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).ListWaiting(ctx, req.(*AdminEmpty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_ListMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminEmpty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).ListMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_ListMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).ListMatches(ctx, req.(*AdminEmpty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_GetMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).GetMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_GetMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).GetMatch(ctx, req.(*AdminMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_DisposeMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).DisposeMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_DisposeMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).DisposeMatch(ctx, req.(*AdminMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_Kick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminKickRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).Kick(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_Kick_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).Kick(ctx, req.(*AdminKickRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_RevokeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRevokeTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).RevokeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_RevokeToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).RevokeToken(ctx, req.(*AdminRevokeTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_PauseLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).PauseLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_PauseLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).PauseLevel(ctx, req.(*AdminLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_ResumeLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).ResumeLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_ResumeLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).ResumeLevel(ctx, req.(*AdminLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_Robots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminEmpty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).Robots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_Robots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).Robots(ctx, req.(*AdminEmpty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_Ledger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminLedgerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).Ledger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_Ledger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).Ledger(ctx, req.(*AdminLedgerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminDrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_Drain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).Drain(ctx, req.(*AdminDrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_ConfigValidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminEmpty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).ConfigValidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_ConfigValidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).ConfigValidate(ctx, req.(*AdminEmpty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_LeaderboardRebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminEmpty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).LeaderboardRebuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_LeaderboardRebuild_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).LeaderboardRebuild(ctx, req.(*AdminEmpty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_FraudAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminFraudAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).FraudAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_FraudAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).FraudAlerts(ctx, req.(*AdminFraudAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_FraudPairs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminFraudPairsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).FraudPairs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_FraudPairs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).FraudPairs(ctx, req.(*AdminFraudPairsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FingerPlayAdmin_FraudClear_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminFraudClearRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FingerPlayAdminServer).FraudClear(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FingerPlayAdmin_FraudClear_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FingerPlayAdminServer).FraudClear(ctx, req.(*AdminFraudClearRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FingerPlayAdmin_ServiceDesc is the grpc.ServiceDesc for FingerPlayAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FingerPlayAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fingerplay.v1.FingerPlayAdmin",
+	HandlerType: (*FingerPlayAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListWaiting",
+			Handler:    _FingerPlayAdmin_ListWaiting_Handler,
+		},
+		{
+			MethodName: "ListMatches",
+			Handler:    _FingerPlayAdmin_ListMatches_Handler,
+		},
+		{
+			MethodName: "GetMatch",
+			Handler:    _FingerPlayAdmin_GetMatch_Handler,
+		},
+		{
+			MethodName: "DisposeMatch",
+			Handler:    _FingerPlayAdmin_DisposeMatch_Handler,
+		},
+		{
+			MethodName: "Kick",
+			Handler:    _FingerPlayAdmin_Kick_Handler,
+		},
+		{
+			MethodName: "RevokeToken",
+			Handler:    _FingerPlayAdmin_RevokeToken_Handler,
+		},
+		{
+			MethodName: "PauseLevel",
+			Handler:    _FingerPlayAdmin_PauseLevel_Handler,
+		},
+		{
+			MethodName: "ResumeLevel",
+			Handler:    _FingerPlayAdmin_ResumeLevel_Handler,
+		},
+		{
+			MethodName: "Robots",
+			Handler:    _FingerPlayAdmin_Robots_Handler,
+		},
+		{
+			MethodName: "Ledger",
+			Handler:    _FingerPlayAdmin_Ledger_Handler,
+		},
+		{
+			MethodName: "Drain",
+			Handler:    _FingerPlayAdmin_Drain_Handler,
+		},
+		{
+			MethodName: "ConfigValidate",
+			Handler:    _FingerPlayAdmin_ConfigValidate_Handler,
+		},
+		{
+			MethodName: "LeaderboardRebuild",
+			Handler:    _FingerPlayAdmin_LeaderboardRebuild_Handler,
+		},
+		{
+			MethodName: "FraudAlerts",
+			Handler:    _FingerPlayAdmin_FraudAlerts_Handler,
+		},
+		{
+			MethodName: "FraudPairs",
+			Handler:    _FingerPlayAdmin_FraudPairs_Handler,
+		},
+		{
+			MethodName: "FraudClear",
+			Handler:    _FingerPlayAdmin_FraudClear_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "fingerplay.proto",
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"strconv"
+)
+
+// 每一局每个玩家产生一条RoundLog
+type RoundLog struct {
+	Uid       int     `bson:"uid" json:"uid"`
+	Level     int     `bson:"level" json:"level"`
+	MatchId   string  `bson:"match_id" json:"match_id"`
+	Round     int     `bson:"round" json:"round"`
+	Operate   int     `bson:"operate" json:"operate"`
+	Result    int     `bson:"result" json:"result"`
+	WinAmount float64 `bson:"win_amount" json:"win_amount"`
+	Ts        int64   `bson:"ts" json:"ts"`
+}
+
+type PlayerStats struct {
+	Uid               int                `bson:"uid"`
+	Rounds            int                `bson:"rounds"`
+	Wins              int                `bson:"wins"`
+	Losses            int                `bson:"losses"`
+	Draws             int                `bson:"draws"`
+	Moves             PlayerMoves        `bson:"moves"`
+	CurrentWinStreak  int                `bson:"current_win_streak"`
+	CurrentLossStreak int                `bson:"current_loss_streak"`
+	LongestWinStreak  int                `bson:"longest_win_streak"`
+	LongestLossStreak int                `bson:"longest_loss_streak"`
+	LevelNet          map[string]float64 `bson:"level_net"`
+	FirstPlayed       int64              `bson:"first_played"`
+	LastPlayed        int64              `bson:"last_played"`
+}
+
+type PlayerMoves struct {
+	Stone    int `bson:"stone" json:"stone"`
+	Paper    int `bson:"paper" json:"paper"`
+	Scissors int `bson:"scissors" json:"scissors"`
+}
+
+// 出现次数最多的出拳，没有记录时返回-1
+func (pm PlayerMoves) Favourite() int {
+	op, n := -1, 0
+	for _, m := range []struct{ op, n int }{{Stone, pm.Stone}, {Paper, pm.Paper}, {Scissors, pm.Scissors}} {
+		if m.n > n {
+			op, n = m.op, m.n
+		}
+	}
+	return op
+}
+
+// PlayerStatsManager异步累加玩家统计，写入失败的回合经磁盘队列回放
+type PlayerStatsManager struct {
+	store PlayerStatsStore
+	q     *SpoolQueue
+}
+
+func NewPlayerStatsManager(store PlayerStatsStore, spoolDir string) *PlayerStatsManager {
+	var (
+		err error
+	)
+
+	psm := &PlayerStatsManager{}
+	psm.store = store
+	if psm.q, err = NewSpoolQueue("player rounds", spoolDir, psm.applyRounds, decodeRoundLog); err != nil {
+		panic(err)
+	}
+	psm.q.Start()
+	return psm
+}
+
+// ApplyRound不是幂等的，写入失败的回合之前的部分不再重试
+func (psm *PlayerStatsManager) applyRounds(items []interface{}) (n int, err error) {
+	for _, item := range items {
+		if err = psm.store.ApplyRound(item.(*RoundLog)); err != nil {
+			return
+		}
+		n++
+	}
+	return
+}
+
+func (psm *PlayerStatsManager) OnRound(rl *RoundLog) (err error) {
+	psm.q.Push(rl)
+	return
+}
+
+// 写入剩余的回合，用于停机
+func (psm *PlayerStatsManager) Close() {
+	psm.q.Close()
+}
+
+func (psm *PlayerStatsManager) Metrics() *SpoolQueueMetrics {
+	return psm.q.Metrics()
+}
+
+func (psm *PlayerStatsManager) Get(uid int) (ps *PlayerStats, err error) {
+	return psm.store.PlayerStats(uid)
+}
+
+// 内存和SQL存储使用的累加逻辑，与mongo的原子更新保持一致
+func (ps *PlayerStats) apply(rl *RoundLog) {
+	if ps.Rounds == 0 || rl.Ts < ps.FirstPlayed {
+		ps.FirstPlayed = rl.Ts
+	}
+	if rl.Ts > ps.LastPlayed {
+		ps.LastPlayed = rl.Ts
+	}
+
+	ps.Rounds++
+
+	if ps.LevelNet == nil {
+		ps.LevelNet = make(map[string]float64)
+	}
+	ps.LevelNet[strconv.Itoa(rl.Level)] += rl.WinAmount
+
+	switch rl.Operate {
+	case Stone:
+		ps.Moves.Stone++
+	case Paper:
+		ps.Moves.Paper++
+	default:
+		ps.Moves.Scissors++
+	}
+
+	switch rl.Result {
+	case Won:
+		ps.Wins++
+		ps.CurrentWinStreak++
+		ps.CurrentLossStreak = 0
+	case Lost:
+		ps.Losses++
+		ps.CurrentLossStreak++
+		ps.CurrentWinStreak = 0
+	default:
+		ps.Draws++
+	}
+
+	if ps.CurrentWinStreak > ps.LongestWinStreak {
+		ps.LongestWinStreak = ps.CurrentWinStreak
+	}
+	if ps.CurrentLossStreak > ps.LongestLossStreak {
+		ps.LongestLossStreak = ps.CurrentLossStreak
+	}
+}
+
+func getOperateField(op int) string {
+	switch op {
+	case Stone:
+		return "stone"
+	case Paper:
+		return "paper"
+	default:
+		return "scissors"
+	}
+}
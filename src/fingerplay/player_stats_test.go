@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPlayerStatsApply(t *testing.T) {
+	ps := &PlayerStats{Uid: 100001}
+	for _, rl := range []*RoundLog{
+		{Level: 100, Operate: Stone, Result: Won, WinAmount: 90, Ts: 200},
+		// 乱序到达的回合只更新FirstPlayed
+		{Level: 100, Operate: Stone, Result: Won, WinAmount: 90, Ts: 100},
+		// 平局不打断连胜
+		{Level: 500, Operate: Paper, Result: Draw, Ts: 300},
+		{Level: 500, Operate: Scissors, Result: Lost, WinAmount: -500, Ts: 400},
+		{Level: 100, Operate: Stone, Result: Lost, WinAmount: -100, Ts: 500},
+		{Level: 100, Operate: Paper, Result: Won, WinAmount: 90, Ts: 600},
+	} {
+		ps.apply(rl)
+	}
+
+	want := &PlayerStats{
+		Uid:               100001,
+		Rounds:            6,
+		Wins:              3,
+		Losses:            2,
+		Draws:             1,
+		Moves:             PlayerMoves{Stone: 3, Paper: 2, Scissors: 1},
+		CurrentWinStreak:  1,
+		CurrentLossStreak: 0,
+		LongestWinStreak:  2,
+		LongestLossStreak: 2,
+		LevelNet:          map[string]float64{"100": 170, "500": -500},
+		FirstPlayed:       100,
+		LastPlayed:        600,
+	}
+	if !reflect.DeepEqual(ps, want) {
+		t.Errorf("stats = %+v, want %+v", ps, want)
+	}
+	if op := ps.Moves.Favourite(); op != Stone {
+		t.Errorf("Favourite() = %d, want stone", op)
+	}
+}
+
+func TestPlayerMovesFavourite(t *testing.T) {
+	for _, c := range []struct {
+		moves PlayerMoves
+		want  int
+	}{
+		{PlayerMoves{}, -1},
+		{PlayerMoves{Scissors: 1}, Scissors},
+		{PlayerMoves{Paper: 2, Scissors: 3}, Scissors},
+		// 次数相同时取先出现的
+		{PlayerMoves{Stone: 2, Paper: 2}, Stone},
+	} {
+		if op := c.moves.Favourite(); op != c.want {
+			t.Errorf("%+v.Favourite() = %d, want %d", c.moves, op, c.want)
+		}
+	}
+}
+
+// left用完后ApplyRound失败
+type failingStatsStore struct {
+	*MemoryStore
+	left int32
+}
+
+func (s *failingStatsStore) ApplyRound(rl *RoundLog) error {
+	if atomic.AddInt32(&s.left, -1) < 0 {
+		return errors.New("store down")
+	}
+	return s.MemoryStore.ApplyRound(rl)
+}
+
+func TestPlayerStatsSpillsFailedRounds(t *testing.T) {
+	store := &failingStatsStore{MemoryStore: NewMemoryStore(), left: 1}
+	dir := t.TempDir()
+	psm := NewPlayerStatsManager(store, dir)
+	for _, result := range []int{Won, Won, Lost} {
+		psm.OnRound(&RoundLog{Uid: 100001, Level: 100, Operate: Stone, Result: result, WinAmount: 90, Ts: 1000})
+	}
+	psm.Close()
+
+	// 第一个回合写入后存储不可用，剩下的回合只写入磁盘队列一次
+	if m := psm.Metrics(); m.Applied != 1 || m.Spilled != 2 || m.Dropped != 0 {
+		t.Fatalf("metrics = %+v, want 1 applied and 2 spilled", m)
+	}
+
+	atomic.StoreInt32(&store.left, 10)
+	psm = NewPlayerStatsManager(store, dir)
+	psm.Close()
+	ps, err := psm.Get(100001)
+	if err != nil || ps.Rounds != 3 || ps.Wins != 2 || ps.CurrentLossStreak != 1 {
+		t.Errorf("stats = %+v, %v, want 3 rounds after the replay", ps, err)
+	}
+}
@@ -2,10 +2,14 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
 	log "code.google.com/p/log4go"
 )
 
@@ -28,7 +32,27 @@ func Post(url string, arg interface{}, reply interface{}) (err error) {
 
 	buf = bytes.NewBuffer(body)
 
-	if response, err = http.Post(url, "application/json; charset=utf-8", buf); err != nil {
+	var (
+		request *http.Request
+	)
+
+	if request, err = http.NewRequest("POST", url, buf); err != nil {
+		return
+	}
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	// 透传请求id，账户服务的日志可以据此关联
+	if t, ok := arg.(interface{ GetRequestId() string }); ok && t.GetRequestId() != "" {
+		request.Header.Set(HeaderRequestId, t.GetRequestId())
+	}
+
+	// 带上trace上下文，账户服务的span可以接在调用方下面
+	if t, ok := arg.(interface{ Context() context.Context }); ok {
+		request = request.WithContext(t.Context())
+		otel.GetTextMapPropagator().Inject(t.Context(), propagation.HeaderCarrier(request.Header))
+	}
+
+	if response, err = http.DefaultClient.Do(request); err != nil {
 		return
 	}
 
@@ -3,7 +3,6 @@ package main
 import (
 	"encoding/json"
 	"sync/atomic"
-	"time"
 
 	log "code.google.com/p/log4go"
 )
@@ -51,8 +50,11 @@ func (response *OnlineNumberResponse) JSON() []byte {
 }
 
 type LeaveRequest struct {
-	AccessToken string `json:"access_token"`
-	MatchId     string `json:"match_id"`
+	Traced
+
+	AccessToken   string `json:"access_token" schema:"required"`
+	MatchId       string `json:"match_id" schema:"required"`
+	SessionTicket string `json:"session_ticket" doc:"from the match response, required when require_session_ticket is on"`
 }
 
 type LeaveResponse struct {
@@ -66,6 +68,8 @@ func (response *LeaveResponse) JSON() []byte {
 }
 
 type RankingRequest struct {
+	Traced
+
 	AccessToken string `json:"access_token"`
 }
 
@@ -96,9 +100,80 @@ func (rrd RankingResponseData) Less(i, j int) bool {
 	return rrd.Results[i].WinAmount < rrd.Results[j].WinAmount
 }
 
+type LeaderboardRequest struct {
+	Traced
+
+	AccessToken string `json:"access_token" doc:"optional, adds the caller's rank and neighbours"`
+	Window      string `json:"window" schema:"enum=daily|weekly|monthly|all" doc:"defaults to all"`
+	Level       int    `json:"level" schema:"min=0" doc:"0 for all levels"`
+	Period      string `json:"period" doc:"e.g. 2024-05-01, 2024-W18, 2024-05; defaults to the current period"`
+	Offset      int    `json:"offset" schema:"min=0"`
+	Limit       int    `json:"limit" schema:"min=0,max=100"`
+	Neighbours  int    `json:"neighbours" schema:"min=0,max=10" doc:"players ranked right above and below the caller"`
+}
+
+type LeaderboardResponse struct {
+	Code int                     `json:"code"`
+	Msg  string                  `json:"msg"`
+	Data LeaderboardResponseData `json:"data"`
+}
+
+func (response *LeaderboardResponse) JSON() []byte {
+	v, _ := json.Marshal(response)
+	return v
+}
+
+type LeaderboardResponseData struct {
+	Window     string              `json:"window"`
+	Period     string              `json:"period"`
+	Level      int                 `json:"level"`
+	Total      int                 `json:"total"`
+	Entries    []*LeaderboardEntry `json:"entries"`
+	Me         *LeaderboardEntry   `json:"me"`
+	Neighbours []*LeaderboardEntry `json:"neighbours"`
+}
+
+type StatsRequest struct {
+	Traced
+
+	AccessToken string `json:"access_token" doc:"used when uid is 0"`
+	Uid         int    `json:"uid" schema:"min=0" doc:"0 for the caller"`
+}
+
+type StatsResponse struct {
+	Code int               `json:"code"`
+	Msg  string            `json:"msg"`
+	Data StatsResponseData `json:"data"`
+}
+
+func (response *StatsResponse) JSON() []byte {
+	v, _ := json.Marshal(response)
+	return v
+}
+
+type StatsResponseData struct {
+	Uid               int             `json:"uid"`
+	Rounds            int             `json:"rounds"`
+	Wins              int             `json:"wins"`
+	Losses            int             `json:"losses"`
+	Draws             int             `json:"draws"`
+	WinRate           float64         `json:"win_rate"`
+	Moves             PlayerMoves     `json:"moves"`
+	FavouriteMove     int             `json:"favourite_move"`
+	LongestWinStreak  int             `json:"longest_win_streak"`
+	LongestLossStreak int             `json:"longest_loss_streak"`
+	Net               float64         `json:"net"`
+	LevelNet          map[int]float64 `json:"level_net"`
+	FirstPlayed       int64           `json:"first_played"`
+	LastPlayed        int64           `json:"last_played"`
+}
+
 type ReadyStatusRequest struct {
-	AccessToken string `json:"access_token"`
-	MatchId     string `json:"match_id"`
+	Traced
+
+	AccessToken   string `json:"access_token" schema:"required"`
+	MatchId       string `json:"match_id" schema:"required"`
+	SessionTicket string `json:"session_ticket" doc:"from the match response, required when require_session_ticket is on"`
 }
 
 type ReadyStatusResponse struct {
@@ -117,10 +192,17 @@ type ReadyStatusResponseData struct {
 }
 
 type MatchRequest struct {
-	Level       int    `json:"level"`
-	AccessToken string `json:"access_token"`
+	Traced
+
+	Level       int    `json:"level" schema:"required,min=1"`
+	AccessToken string `json:"access_token" schema:"required"`
+	DeviceId    string `json:"device_id" doc:"optional, a stable id of the device, used to detect collusion"`
+	// 由接口层填写，用于发现同一个ip上的串通
+	ClientIp string `json:"-"`
 }
 
+func (request *MatchRequest) SetClientIp(ip string) { request.ClientIp = ip }
+
 type MatchResponse struct {
 	Code int               `json:"code"`
 	Msg  string            `json:"msg"`
@@ -134,6 +216,8 @@ type MatchResponseData struct {
 	Round           int           `json:"round"`
 	Competitors     []*Competitor `json:"competitors"`
 	TimeoutSecond   int           `json:"timeout_second"`
+	// 每个选手各不相同，比赛中的请求需要带上
+	SessionTicket string `json:"session_ticket,omitempty"`
 }
 
 type Competitor struct {
@@ -145,19 +229,27 @@ type Competitor struct {
 
 	// DO NOT EDIT THESE FIELD!
 	uid         int                 `json:"-"`
+	man         bool                `json:"-"`
 	operate     int                 `json:"-"`
 	readyCh     chan *ReadyResponse `json:"-"`
 	status      int64               `json:"-"`
 	accessToken string              `json:"-"`
 	keepAliveTs int64               `json:"-"`
+	ticket      string              `json:"-"`
+	// 本回合出拳的时间，毫秒
+	readyMs int64 `json:"-"`
+}
+
+// 不大于max_robot_uid的是机器人，单机和集群的匹配都按这里判断
+func (cfg *Config) isMan(uid int) bool {
+	return uid > cfg.MaxRobotUid
 }
 
 func (cp *Competitor) IsMan() bool {
-	return cp.uid > Conf.MaxRobotUid
+	return cp.man
 }
 
-func (cp *Competitor) KeepAlive() {
-	ts := time.Now().Unix()
+func (cp *Competitor) KeepAlive(ts int64) {
 	atomic.StoreInt64(&(cp.keepAliveTs), ts)
 }
 
@@ -169,10 +261,11 @@ func (cp *Competitor) GetKeepAliveTs() int64 {
 	return atomic.LoadInt64(&(cp.keepAliveTs))
 }
 
-func (cp *Competitor) Ready(op int) bool {
+func (cp *Competitor) Ready(op int, ms int64) bool {
 	ok := atomic.CompareAndSwapInt64(&(cp.status), CompetitorStatusIdle, CompetitorStatusReady)
 	if ok {
 		cp.operate = op
+		cp.readyMs = ms
 	}
 	return ok
 }
@@ -217,10 +310,13 @@ func (response *MatchResponse) JSON() []byte {
 }
 
 type ReadyRequest struct {
-	Operate     int    `json:"operate"`
-	MatchId     string `json:"match_id"`
-	Round       int    `json:"round"`
-	AccessToken string `json:"access_token"`
+	Traced
+
+	Operate       int    `json:"operate" schema:"enum=0|1|2" doc:"0 stone, 1 paper, 2 scissors"`
+	MatchId       string `json:"match_id" schema:"required"`
+	Round         int    `json:"round" schema:"min=0"`
+	AccessToken   string `json:"access_token" schema:"required"`
+	SessionTicket string `json:"session_ticket" doc:"from the match response, required when require_session_ticket is on"`
 }
 
 type ReadyResponse struct {
@@ -237,7 +333,8 @@ type ReadyResponseData struct {
 }
 
 type Result struct {
-	AccessToken string  `json:"access_token"`
+	// 增量格式中省略，客户端按匹配结果中选手的顺序区分自己
+	AccessToken string  `json:"access_token" delta:"omit"`
 	Operate     int     `json:"operate"`
 	Status      int     `json:"status"`
 	Balance     float64 `json:"balance"`
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "code.google.com/p/log4go"
+)
+
+const (
+	// rate_limit中没有单独配置的路由使用这一项，也没有时不限制
+	RateLimitDefault = "default"
+
+	RateLimitStoreLocal = "local"
+	RateLimitStoreRedis = "redis"
+
+	// 限流的维度，也是监控指标的标签
+	RateLimitByIp    = "ip"
+	RateLimitByToken = "token"
+	RateLimitByUid   = "uid"
+)
+
+// 一个路由的预算，每个ip、access_token和uid各有一个令牌桶。
+// 同一个ip后面可能有很多玩家，所以ip单独配置，速率为0时不限制这个维度。
+type RateLimit struct {
+	Rate    float64 `toml:"rate" json:"rate"`
+	Burst   int     `toml:"burst" json:"burst"`
+	IpRate  float64 `toml:"ip_rate" json:"ip_rate"`
+	IpBurst int     `toml:"ip_burst" json:"ip_burst"`
+}
+
+func (rl *RateLimiter) getRateLimit(route string) *RateLimit {
+	limits := rl.conf.Get().RateLimits
+	if rl, ok := limits[route]; ok {
+		return rl
+	}
+	return limits[RateLimitDefault]
+}
+
+type tokenBucket struct {
+	tokens float64
+	ts     time.Time
+	// 之后桶已经装满，可以删除
+	fullTs time.Time
+}
+
+// 按路由限制请求频率，rate_limit_store为redis时所有节点共享令牌桶，
+// redis出错时放行，不影响正常的请求
+type RateLimiter struct {
+	accounts Accounts
+	cluster  *Cluster
+	conf     *ConfigValue
+	clock    Clock
+	mux      sync.Mutex
+	buckets  map[string]*tokenBucket
+	sweepTs  time.Time
+}
+
+func NewRateLimiter(accounts Accounts) *RateLimiter {
+	rl := &RateLimiter{}
+	rl.accounts = accounts
+	rl.conf = confValue
+	rl.clock = DefaultClock
+	rl.buckets = make(map[string]*tokenBucket)
+	return rl
+}
+
+// 依次检查ip、access_token和token对应的uid，返回需要等待的时间，0表示放行。
+// ip为空时不检查ip，uid只在token验证过并且还在缓存中时检查。
+func (rl *RateLimiter) Allow(route, ip, accessToken string) (wait time.Duration) {
+	limit := rl.getRateLimit(route)
+	if limit == nil {
+		return
+	}
+
+	if ip != "" && limit.IpRate > 0 {
+		if wait = rl.take(route, RateLimitByIp, ip, limit.IpRate, limit.IpBurst); wait > 0 {
+			return
+		}
+	}
+
+	if accessToken == "" || limit.Rate <= 0 {
+		return
+	}
+	if wait = rl.take(route, RateLimitByToken, accessToken, limit.Rate, limit.Burst); wait > 0 {
+		return
+	}
+	if rl.accounts == nil {
+		return
+	}
+	if uid := rl.accounts.CachedUid(accessToken); uid > 0 {
+		wait = rl.take(route, RateLimitByUid, strconv.Itoa(uid), limit.Rate, limit.Burst)
+	}
+	return
+}
+
+func (rl *RateLimiter) take(route, by, value string, rate float64, burst int) (wait time.Duration) {
+	var (
+		err error
+		key = route + ":" + by + ":" + value
+		now = rl.clock.Now()
+	)
+
+	if rl.conf.Get().RateLimitStore == RateLimitStoreRedis && rl.cluster.Enabled() {
+		if wait, err = rl.cluster.TakeToken(key, rate, burst, now); err != nil {
+			log.Error("RateLimiter take %s:%s failed: %s", route, by, err)
+			return 0
+		}
+	} else {
+		wait = rl.takeLocal(key, rate, burst, now)
+	}
+
+	if wait > 0 {
+		observeRateLimited(route, by)
+	}
+	return
+}
+
+func (rl *RateLimiter) takeLocal(key string, rate float64, burst int, now time.Time) (wait time.Duration) {
+	rl.mux.Lock()
+	defer rl.mux.Unlock()
+
+	rl.sweep(now)
+
+	b := rl.buckets[key]
+	if b == nil {
+		b = &tokenBucket{tokens: float64(burst), ts: now}
+		rl.buckets[key] = b
+	}
+	if now.After(b.ts) {
+		b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.ts).Seconds()*rate)
+		b.ts = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+	} else {
+		wait = time.Duration(math.Ceil((1 - b.tokens) / rate * float64(time.Second)))
+	}
+	b.fullTs = b.ts.Add(time.Duration((float64(burst) - b.tokens) / rate * float64(time.Second)))
+	return
+}
+
+// 每分钟清理一次已经装满的桶，调用者需要持有rl.mux
+func (rl *RateLimiter) sweep(now time.Time) {
+	if now.Sub(rl.sweepTs) < time.Minute {
+		return
+	}
+	rl.sweepTs = now
+	for k, b := range rl.buckets {
+		if !now.Before(b.fullTs) {
+			delete(rl.buckets, k)
+		}
+	}
+}
+
+func (rl *RateLimiter) Len() (n int) {
+	rl.mux.Lock()
+	n = len(rl.buckets)
+	rl.mux.Unlock()
+	return
+}
+
+// Retry-After的秒数，不足一秒按一秒
+func retryAfterSecond(wait time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(wait.Seconds())))
+}
+
+// 配置了client_ip_header时从请求头中取客户端ip，只有负载均衡会覆盖这个请求头时才能配置。
+// X-Forwarded-For这类逐级追加的请求头取最后一个，即负载均衡看到的地址。
+func clientIp(name, remoteAddr string, header func(string) string) string {
+	if name != "" {
+		if v := header(name); v != "" {
+			if i := strings.LastIndexByte(v, ','); i >= 0 {
+				v = v[i+1:]
+			}
+			return strings.TrimSpace(v)
+		}
+	}
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return host
+	}
+	return remoteAddr
+}
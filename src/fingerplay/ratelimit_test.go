@@ -0,0 +1,217 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// CachedUid返回uids中的uid，其他方法不会被调用
+type uidAccounts struct {
+	Accounts
+	uids map[string]int
+}
+
+func (a *uidAccounts) CachedUid(accessToken string) int {
+	return a.uids[accessToken]
+}
+
+func newTestLimiter(t *testing.T, limits map[string]*RateLimit, accounts Accounts) (*RateLimiter, *ManualClock) {
+	cfg := newTestConfig(t)
+	cfg.RateLimits = limits
+	rl := NewRateLimiter(accounts)
+	rl.conf = NewConfigValue(cfg)
+	clock := NewManualClock(time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local))
+	rl.clock = clock
+	return rl, clock
+}
+
+func TestRateLimitBurstAndRefill(t *testing.T) {
+	rl, clock := newTestLimiter(t, map[string]*RateLimit{"ready": {Rate: 2, Burst: 3}}, nil)
+
+	for i := 0; i < 3; i++ {
+		if wait := rl.Allow("ready", "", "player-1"); wait != 0 {
+			t.Fatalf("request %d waits %s, want the burst allowed", i+1, wait)
+		}
+	}
+	// 每秒2个令牌，下一个令牌在500ms后
+	if wait := rl.Allow("ready", "", "player-1"); wait != 500*time.Millisecond {
+		t.Errorf("wait = %s after the burst, want 500ms", wait)
+	}
+	clock.Advance(250 * time.Millisecond)
+	if wait := rl.Allow("ready", "", "player-1"); wait != 250*time.Millisecond {
+		t.Errorf("wait = %s after 250ms, want 250ms", wait)
+	}
+	clock.Advance(250 * time.Millisecond)
+	if wait := rl.Allow("ready", "", "player-1"); wait != 0 {
+		t.Errorf("wait = %s after the refill, want 0", wait)
+	}
+
+	// 很久不请求也只补满burst
+	clock.Advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		rl.Allow("ready", "", "player-1")
+	}
+	if wait := rl.Allow("ready", "", "player-1"); wait == 0 {
+		t.Errorf("more than burst requests allowed after an idle hour")
+	}
+
+	for _, c := range []struct {
+		wait time.Duration
+		want string
+	}{
+		{250 * time.Millisecond, "1"},
+		{time.Second, "1"},
+		{1500 * time.Millisecond, "2"},
+	} {
+		if s := retryAfterSecond(c.wait); s != c.want {
+			t.Errorf("retryAfterSecond(%s) = %s, want %s", c.wait, s, c.want)
+		}
+	}
+}
+
+func TestRateLimitBudgets(t *testing.T) {
+	accounts := &uidAccounts{uids: map[string]int{"player-1": 100001, "player-1b": 100001}}
+	rl, _ := newTestLimiter(t, map[string]*RateLimit{"ready": {Rate: 1, Burst: 1, IpRate: 1, IpBurst: 2}}, accounts)
+
+	for _, c := range []struct {
+		ip, token string
+		limited   bool
+	}{
+		{"10.0.0.1", "player-1", false},
+		// 同一个token换了ip
+		{"10.0.0.2", "player-1", true},
+		// 同一个uid换了token
+		{"10.0.0.3", "player-1b", true},
+		{"10.0.0.1", "player-2", false},
+		// 10.0.0.1的两个令牌已经用完
+		{"10.0.0.1", "player-3", true},
+		// 不检查ip时只有token和uid
+		{"", "player-3", false},
+		{"10.0.0.4", "", false},
+		{"10.0.0.4", "", false},
+		{"10.0.0.4", "", true},
+	} {
+		if wait := rl.Allow("ready", c.ip, c.token); (wait > 0) != c.limited {
+			t.Errorf("Allow(%q, %q) waits %s, want limited %v", c.ip, c.token, wait, c.limited)
+		}
+	}
+}
+
+func TestRateLimitDefaultRoute(t *testing.T) {
+	rl, _ := newTestLimiter(t, map[string]*RateLimit{
+		RateLimitDefault: {Rate: 1, Burst: 1},
+		// 单独配置为0时不限制，不使用default
+		"match": {},
+	}, nil)
+
+	for _, route := range []string{"ready", "leave"} {
+		rl.Allow(route, "", "player-1")
+		if wait := rl.Allow(route, "", "player-1"); wait == 0 {
+			t.Errorf("route %s not limited by the default", route)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if wait := rl.Allow("match", "", "player-1"); wait != 0 {
+			t.Errorf("route match waits %s, want its own unlimited entry", wait)
+		}
+	}
+
+	// 没有default时不限制
+	rl, _ = newTestLimiter(t, nil, nil)
+	for i := 0; i < 3; i++ {
+		if wait := rl.Allow("ready", "10.0.0.1", "player-1"); wait != 0 {
+			t.Errorf("waits %s without any rate_limit, want 0", wait)
+		}
+	}
+}
+
+func TestRateLimitSweepsFullBuckets(t *testing.T) {
+	rl, clock := newTestLimiter(t, map[string]*RateLimit{
+		"ready": {Rate: 1, Burst: 2},
+		// 100秒才补满一个令牌
+		"slow": {Rate: 0.01, Burst: 1},
+	}, nil)
+
+	rl.Allow("ready", "", "player-1")
+	rl.Allow("ready", "", "player-2")
+	rl.Allow("slow", "", "player-1")
+	if n := rl.Len(); n != 3 {
+		t.Fatalf("Len() = %d, want 3 buckets", n)
+	}
+
+	// 每分钟清理一次，只删除已经装满的桶
+	clock.Advance(30 * time.Second)
+	rl.Allow("ready", "", "player-3")
+	if n := rl.Len(); n != 4 {
+		t.Errorf("Len() = %d within a minute, want nothing swept", n)
+	}
+	clock.Advance(31 * time.Second)
+	rl.Allow("ready", "", "player-4")
+	if n := rl.Len(); n != 2 {
+		t.Errorf("Len() = %d, want only the slow bucket and the new one", n)
+	}
+}
+
+func TestClientIp(t *testing.T) {
+	headers := map[string]string{
+		"X-Forwarded-For": "1.1.1.1, 10.0.0.2 ,203.0.113.7 ",
+		"X-Real-Ip":       "198.51.100.1",
+	}
+	header := func(name string) string { return headers[name] }
+
+	for _, c := range []struct {
+		name, remoteAddr, want string
+	}{
+		// 取最后一跳，前面的可以被客户端伪造
+		{"X-Forwarded-For", "10.0.0.1:5000", "203.0.113.7"},
+		{"X-Real-Ip", "10.0.0.1:5000", "198.51.100.1"},
+		// 没有配置或者没有这个请求头时使用连接的地址
+		{"", "10.0.0.1:5000", "10.0.0.1"},
+		{"X-Client-Ip", "[2001:db8::1]:5000", "2001:db8::1"},
+		{"", "10.0.0.1", "10.0.0.1"},
+	} {
+		if ip := clientIp(c.name, c.remoteAddr, header); ip != c.want {
+			t.Errorf("clientIp(%q, %q) = %q, want %q", c.name, c.remoteAddr, ip, c.want)
+		}
+	}
+}
+
+func TestClusterRateLimit(t *testing.T) {
+	tc := newTestCluster(t)
+	var limiters []*RateLimiter
+	for _, s := range tc.nodes {
+		rl, _ := newTestLimiter(t, map[string]*RateLimit{"ready": {Rate: 1, Burst: 2}}, nil)
+		rl.conf.Get().RateLimitStore = RateLimitStoreRedis
+		rl.clock = tc.clock
+		rl.cluster = s.Cluster
+		limiters = append(limiters, rl)
+	}
+
+	// 两个节点共用一个令牌桶
+	if limiters[0].Allow("ready", "", "player-1") != 0 || limiters[1].Allow("ready", "", "player-1") != 0 {
+		t.Fatalf("the burst was not allowed across nodes")
+	}
+	if wait := limiters[0].Allow("ready", "", "player-1"); wait != time.Second {
+		t.Errorf("wait = %s after the shared burst, want 1s", wait)
+	}
+	if n := limiters[0].Len() + limiters[1].Len(); n != 0 {
+		t.Errorf("%d local buckets, want all of them in redis", n)
+	}
+
+	key := rateKey("ready:" + RateLimitByToken + ":player-1")
+	if ttl := tc.redis.TTL(key); ttl != 3*time.Second {
+		t.Errorf("bucket ttl = %s, want the time to refill plus a second", ttl)
+	}
+
+	tc.clock.Advance(500 * time.Millisecond)
+	if wait := limiters[1].Allow("ready", "", "player-1"); wait != 500*time.Millisecond {
+		t.Errorf("wait = %s after 500ms, want 500ms", wait)
+	}
+
+	// redis出错时放行
+	tc.redis.SetError("server down")
+	defer tc.redis.SetError("")
+	if wait := limiters[0].Allow("ready", "", "player-1"); wait != 0 {
+		t.Errorf("wait = %s with redis down, want the request allowed", wait)
+	}
+}
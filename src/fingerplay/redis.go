@@ -0,0 +1,64 @@
+package main
+
+import (
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+)
+
+type RedisManager struct {
+	addr string
+	pool *redis.Pool
+}
+
+func NewRedisManager(addr string) (rm *RedisManager, err error) {
+	rm = &RedisManager{}
+	rm.addr = addr
+
+	rm.pool = &redis.Pool{
+		MaxIdle:     64,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", rm.addr,
+				redis.DialConnectTimeout(3*time.Second),
+				redis.DialReadTimeout(5*time.Second),
+				redis.DialWriteTimeout(5*time.Second))
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			if time.Since(t) < time.Minute {
+				return nil
+			}
+			_, err := c.Do("PING")
+			return err
+		},
+	}
+
+	return
+}
+
+// 调用者负责Close
+func (rm *RedisManager) GetSession() (conn redis.Conn, err error) {
+	conn = rm.pool.Get()
+	if err = conn.Err(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return
+}
+
+// 订阅使用独立连接，不设置读超时
+func (rm *RedisManager) Subscribe() (psc redis.PubSubConn, err error) {
+	var (
+		conn redis.Conn
+	)
+
+	if conn, err = redis.Dial("tcp", rm.addr, redis.DialConnectTimeout(3*time.Second)); err != nil {
+		return
+	}
+	psc = redis.PubSubConn{Conn: conn}
+	return
+}
+
+func (rm *RedisManager) Close() {
+	rm.pool.Close()
+}
@@ -22,4 +22,10 @@ const (
 	ResponseCodeSmsCodeTimesLimit     = -18
 	ResponseCodeSmsCodeTimeout        = -19
 	ResponseCodeSmsCodeIncorrect      = -20
+	ResponseCodeMaintenance           = -21
+	ResponseCodeBadSignature          = -22
+	ResponseCodeRateLimited           = -23
+
+	// 最后一个返回码，新增返回码时同时修改
+	ResponseCodeMin = ResponseCodeRateLimited
 )
@@ -1,38 +1,60 @@
 package main
 
 import (
+	"context"
 	"sync"
 	"time"
 
-	"gopkg.in/mgo.v2/bson"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	log "code.google.com/p/log4go"
 )
 
-var (
-	Collection = "risk_control"
-)
-
 type RiskController struct {
-	mux sync.RWMutex
-	ctx *Context
+	mux   sync.RWMutex
+	store RiskStore
+	conf  *ConfigValue
 }
 
 type RiskConfig struct {
 	BonusPool float64 `bson:"bonus_pool"`
 }
 
-func NewRiskController(ctx *Context) *RiskController {
+func NewRiskController(store RiskStore) *RiskController {
 	rc := &RiskController{}
-	rc.ctx = ctx
+	rc.store = store
+	rc.conf = confValue
 	return rc
 }
 
-func (rc *RiskController) Judge(lv float64, cp1, cp2 *Competitor) int {
+// 奖池存储调用的span，挂在当前回合的trace下
+func (rc *RiskController) storeSpan(ctx context.Context, op string) trace.Span {
+	_, span := startSpan(ctx, "RiskStore."+op, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", rc.conf.Get().StoreDriver), attribute.String("db.operation", op)))
+	return span
+}
+
+func (rc *RiskController) bonusPool(ctx context.Context) (pool float64, err error) {
+	span := rc.storeSpan(ctx, "BonusPool")
+	defer func() { finishSpan(span, err) }()
+	return rc.store.BonusPool()
+}
+
+func (rc *RiskController) addBonusPool(ctx context.Context, delta float64) (pool float64, err error) {
+	span := rc.storeSpan(ctx, "AddBonusPool")
+	defer func() { finishSpan(span, err) }()
+	return rc.store.AddBonusPool(delta)
+}
+
+func (rc *RiskController) Judge(ctx context.Context, lv float64, cp1, cp2 *Competitor) int {
 	bonusPool := float64(0)
-	riskConfig := &RiskConfig{}
 	begin := time.Now()
+
+	// 所有机器人对局共用一把锁，单独记录等锁的时间
+	_, lock := startSpan(ctx, "RiskController.lock")
 	rc.mux.Lock()
+	lock.End()
 	defer rc.mux.Unlock()
 	defer func() {
 		log.Debug("Judge cost %2fs, bonus pool: ksh %2f", time.Now().Sub(begin).Seconds(), bonusPool)
@@ -41,7 +63,7 @@ func (rc *RiskController) Judge(lv float64, cp1, cp2 *Competitor) int {
 		}
 	}()
 
-	session, err := rc.ctx.GetMongoSession()
+	pool, err := rc.bonusPool(ctx)
 	if err != nil {
 		log.Error("Judge failed: %s", err)
 		if !cp1.IsMan() {
@@ -53,34 +75,9 @@ func (rc *RiskController) Judge(lv float64, cp1, cp2 *Competitor) int {
 		}
 	}
 
-	if session == nil {
-		log.Error("Judge failed: mongodb not connected")
-		if !cp1.IsMan() {
-			cp1.UpdateOperate(getWonOperate(cp2.GetOperate()))
-			return Won
-		} else {
-			cp2.UpdateOperate(getWonOperate(cp1.GetOperate()))
-			return Lost
-		}
-	}
-
-	defer session.Close()
-
-	if err := session.DB(Conf.MongoDb).C(Collection).Find(nil).One(riskConfig); err != nil {
-		log.Error("Judge failed: %s", err)
-		if !cp1.IsMan() {
-			cp1.UpdateOperate(getWonOperate(cp2.GetOperate()))
-			return Won
-		} else {
-			cp2.UpdateOperate(getWonOperate(cp1.GetOperate()))
-			return Lost
-		}
-	}
-
-	if riskConfig.BonusPool < lv {
-		bonusPool = riskConfig.BonusPool + lv
-		if err := session.DB(Conf.MongoDb).C(Collection).Update(nil, bson.M{"$set": bson.M{"bonus_pool": bonusPool}}); err != nil {
-			log.Error("Judge failed, update bonus pool %2f failed: %s", bonusPool, err)
+	if pool < lv {
+		if bonusPool, err = rc.addBonusPool(ctx, lv); err != nil {
+			log.Error("Judge failed, update bonus pool %2f failed: %s", pool+lv, err)
 		} else {
 			log.Debug("update bonus pool %2f succeed", bonusPool)
 		}
@@ -116,22 +113,23 @@ func (rc *RiskController) Judge(lv float64, cp1, cp2 *Competitor) int {
 			break
 		}
 
+		delta := float64(0)
 		if !cp1.IsMan() {
 			if result == Won {
-				bonusPool = riskConfig.BonusPool + lv
+				delta = lv
 			} else {
-				bonusPool = riskConfig.BonusPool - lv
+				delta = -lv
 			}
 		} else {
 			if result == Won {
-				bonusPool = riskConfig.BonusPool - lv
+				delta = -lv
 			} else {
-				bonusPool = riskConfig.BonusPool + lv
+				delta = lv
 			}
 		}
 
-		if err := session.DB(Conf.MongoDb).C(Collection).Update(nil, bson.M{"$set": bson.M{"bonus_pool": bonusPool}}); err != nil {
-			log.Error("Judge failed, update bonus pool %2f failed: %s", bonusPool, err)
+		if bonusPool, err = rc.addBonusPool(ctx, delta); err != nil {
+			log.Error("Judge failed, update bonus pool %2f failed: %s", pool+delta, err)
 		} else {
 			log.Debug("update bonus pool %2f succeed", bonusPool)
 		}
@@ -139,7 +137,3 @@ func (rc *RiskController) Judge(lv float64, cp1, cp2 *Competitor) int {
 		return result
 	}
 }
-
-var (
-	DefaultRiskController *RiskController
-)
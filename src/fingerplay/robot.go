@@ -1,7 +1,6 @@
 package main
 
 import (
-	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -9,29 +8,43 @@ import (
 	log "code.google.com/p/log4go"
 )
 
-var (
-	RobotLifetimeSecond int64 = 300
-)
+// 机器人对手，等待超时的真人玩家由机器人补上
+type Robots interface {
+	GoGoGo(lv int, balance float64)
+	nextRobotAvatar() *RobotAvatar
+	Usage() (idle int, playing map[int]int)
+}
 
 type RobotManager struct {
-	mux  sync.RWMutex
-	uid  int
-	idle []*Robot
-	idx  int64
+	mux            sync.RWMutex
+	clock          Clock
+	conf           *ConfigValue
+	accounts       Accounts
+	logic          Logic
+	uid            int
+	lifetimeSecond int64
+	idle           []*Robot
+	playing        map[*Robot]int
+	idx            int64
 }
 
-func NewRobotManager(uid int, lifetimeSecond int64) *RobotManager {
+// 机器人和真人一样通过logic匹配和出拳，通过accounts登录机器人账号
+func NewRobotManager(accounts Accounts, logic Logic, uid int, lifetimeSecond int64) *RobotManager {
 	rm := &RobotManager{}
+	rm.clock = DefaultClock
+	rm.conf = confValue
+	rm.accounts = accounts
+	rm.logic = logic
 	rm.uid = uid
+	rm.lifetimeSecond = lifetimeSecond
 	rm.idx = -1
-
-	RobotLifetimeSecond = lifetimeSecond
-
+	rm.playing = make(map[*Robot]int)
 	return rm
 }
 
 func (rm *RobotManager) nextRobotAvatar() *RobotAvatar {
-	return Conf.Robots[int(atomic.AddInt64(&(rm.idx), int64(1))%int64(len(Conf.Robots)))]
+	robots := rm.conf.Get().Robots
+	return robots[int(atomic.AddInt64(&(rm.idx), int64(1))%int64(len(robots)))]
 }
 
 func (rm *RobotManager) GoGoGo(lv int, balance float64) {
@@ -57,12 +70,14 @@ func (rm *RobotManager) NextRobot(lv int) (robot *Robot) {
 		robot = rm.idle[0]
 		rm.idle = rm.idle[1:]
 	} else {
-		robot = NewRobot()
+		robot = NewRobot(rm.clock, rm.accounts, rm.logic)
 		robot.Uid = rm.uid
+		robot.lifetimeSecond = rm.lifetimeSecond
 	}
 
 	robot.Reset()
 	robot.Level = lv
+	rm.playing[robot] = lv
 
 	rm.mux.Unlock()
 
@@ -71,23 +86,44 @@ func (rm *RobotManager) NextRobot(lv int) (robot *Robot) {
 
 func (rm *RobotManager) Idle(robot *Robot) {
 	rm.mux.Lock()
+	delete(rm.playing, robot)
 	rm.idle = append(rm.idle, robot)
 	rm.mux.Unlock()
 }
 
+// 空闲的机器人数量和各等级正在对局的机器人数量
+func (rm *RobotManager) Usage() (idle int, playing map[int]int) {
+	playing = make(map[int]int)
+	rm.mux.RLock()
+	idle = len(rm.idle)
+	for _, lv := range rm.playing {
+		playing[lv]++
+	}
+	rm.mux.RUnlock()
+	return
+}
+
 type Robot struct {
-	rand         *rand.Rand
-	AccessToken  string  `json:"access_token"`
-	Level        int     `json:"level"`
-	MatchId      string  `json:"match_id"`
-	Round        int     `json:"round"`
-	Uid          int     `json:"uid"`
-	BeginBalance float64 `json:"begin_balance"`
-	EndBalance   float64 `json:"end_balance"`
+	clock          Clock
+	rand           Rand
+	accounts       Accounts
+	logic          Logic
+	lifetimeSecond int64
+	AccessToken    string  `json:"access_token"`
+	SessionTicket  string  `json:"-"`
+	Level          int     `json:"level"`
+	MatchId        string  `json:"match_id"`
+	Round          int     `json:"round"`
+	Uid            int     `json:"uid"`
+	BeginBalance   float64 `json:"begin_balance"`
+	EndBalance     float64 `json:"end_balance"`
 }
 
-func NewRobot() *Robot {
+func NewRobot(clock Clock, accounts Accounts, logic Logic) *Robot {
 	robot := &Robot{}
+	robot.clock = clock
+	robot.accounts = accounts
+	robot.logic = logic
 	return robot
 }
 
@@ -103,7 +139,7 @@ func (r *Robot) PlayLoop(level, balance float64) (err error) {
 
 	response := &LoginAIResponse{}
 
-	if err = DefaultAccountManager.LoginAI(request, response); err != nil {
+	if err = r.accounts.LoginAI(request, response); err != nil {
 		log.Error("LoginAI(%#v, %#v) failed: %s", request, response, err)
 		return
 	}
@@ -118,21 +154,21 @@ func (r *Robot) PlayLoop(level, balance float64) (err error) {
 	r.AccessToken = response.Data.AccessToken
 
 	if err = r.Match(); err != nil {
-		log.Error("Robot %s match failed: %s", r.AccessToken, err)
+		log.Error("Robot %s match failed: %s", redactToken(r.AccessToken), err)
 		return
 	}
 
-	begin := time.Now().Unix()
+	begin := r.clock.Now().Unix()
 
 	for {
-		time.Sleep(r.readyWaitTime())
+		r.clock.Sleep(r.readyWaitTime())
 		if err = r.Ready(); err != nil {
-			log.Error("Robot %s ready failed: %s", r.AccessToken, err)
+			log.Error("Robot %s ready failed: %s", redactToken(r.AccessToken), err)
 			break
 		}
 
-		if time.Now().Unix()-begin >= RobotLifetimeSecond {
-			log.Debug("Robot %s exit because of the lifetime is overload", r.AccessToken)
+		if r.clock.Now().Unix()-begin >= r.lifetimeSecond {
+			log.Debug("Robot %s exit because of the lifetime is overload", redactToken(r.AccessToken))
 			break
 		}
 	}
@@ -147,7 +183,7 @@ func (r *Robot) Match() (err error) {
 
 	response := &MatchResponse{}
 
-	if err = DefaultLogicImpl.Match(request, response); err != nil {
+	if err = r.logic.Match(request, response); err != nil {
 		return
 	}
 
@@ -159,6 +195,7 @@ func (r *Robot) Match() (err error) {
 
 	r.MatchId = response.Data.MatchId
 	r.Round = response.Data.Round
+	r.SessionTicket = response.Data.SessionTicket
 
 	for _, cp := range response.Data.Competitors {
 		if cp.AccessToken != "" {
@@ -171,7 +208,7 @@ func (r *Robot) Match() (err error) {
 func (r *Robot) Logout() (err error) {
 	request := &LogoutAIRequest{AccessToken: r.AccessToken}
 	response := &LogoutAIResponse{}
-	if err = DefaultAccountManager.LogoutAI(request, response); err != nil {
+	if err = r.accounts.LogoutAI(request, response); err != nil {
 		log.Error("LogoutAI failed: %s", err)
 	}
 	return
@@ -181,11 +218,12 @@ func (r *Robot) Leave() (err error) {
 	request := &LeaveRequest{}
 	request.MatchId = r.MatchId
 	request.AccessToken = r.AccessToken
+	request.SessionTicket = r.SessionTicket
 
 	response := &LeaveResponse{}
 
-	if err = DefaultLogicImpl.Leave(request, response); err != nil {
-		log.Error("Robot %s leave failed: %s", r.AccessToken, err)
+	if err = r.logic.Leave(request, response); err != nil {
+		log.Error("Robot %s leave failed: %s", redactToken(r.AccessToken), err)
 		return
 	}
 
@@ -202,10 +240,11 @@ func (r *Robot) Ready() (err error) {
 	request.MatchId = r.MatchId
 	request.Round = r.Round
 	request.AccessToken = r.AccessToken
+	request.SessionTicket = r.SessionTicket
 
 	response := &ReadyResponse{}
 
-	if err = DefaultLogicImpl.Ready(request, response); err != nil {
+	if err = r.logic.Ready(request, response); err != nil {
 		return
 	}
 
@@ -238,8 +277,9 @@ func (r *Robot) NextOperate() int {
 }
 
 func (r *Robot) Reset() {
-	r.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	r.rand = newRand(r.clock)
 	r.AccessToken = ""
+	r.SessionTicket = ""
 	r.Level = 0
 	r.MatchId = ""
 	r.Round = 0
@@ -252,7 +292,3 @@ type RobotAvatar struct {
 	Avatar   string `toml:"avatar"`
 	Nickname string `toml:"nickname"`
 }
-
-var (
-	DefaultRobotManager *RobotManager
-)
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 匹配和出拳都立即成功的Logic，只用来驱动机器人的循环
+type instantLogic struct {
+	Logic
+	readies int32
+}
+
+func (l *instantLogic) Match(request *MatchRequest, response *MatchResponse) (err error) {
+	response.Data.MatchId = "m1"
+	return
+}
+
+func (l *instantLogic) Ready(request *ReadyRequest, response *ReadyResponse) (err error) {
+	atomic.AddInt32(&l.readies, 1)
+	response.Data.Round = request.Round + 1
+	return
+}
+
+func (l *instantLogic) Leave(request *LeaveRequest, response *LeaveResponse) (err error) {
+	return
+}
+
+func TestRobotLeavesAfterLifetime(t *testing.T) {
+	clock := NewManualClock(time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local))
+	logic := &instantLogic{}
+	rm := NewRobotManager(NewAccountManager("", "", ""), logic, 1000, 20)
+	rm.clock = clock
+
+	playing := func() int {
+		_, playing := rm.Usage()
+		return playing[100]
+	}
+
+	rm.GoGoGo(100, 1000)
+	clock.BlockUntil(1)
+
+	// 每次出拳前等待4到7秒，超过robot_lifetime_second后的第一次出拳之后离开
+	elapsed := 0
+	for ; elapsed < 30 && playing() > 0; elapsed++ {
+		if elapsed < 20 && playing() != 1 {
+			t.Fatalf("robot left after %ds, want it to play for robot_lifetime_second", elapsed)
+		}
+		clock.Advance(time.Second)
+		waitFor(t, "robot sleeping or gone", func() bool { return clock.Sleepers() == 1 || playing() == 0 })
+	}
+
+	if elapsed < 20 || elapsed > 27 {
+		t.Errorf("robot left after %ds, want between 20s and 27s", elapsed)
+	}
+	if n := atomic.LoadInt32(&logic.readies); n < 3 {
+		t.Errorf("robot readied %d times in its lifetime", n)
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	log "code.google.com/p/log4go"
+)
+
+const (
+	ApiPathPrefix = "/fingerplay/v"
+
+	// v1：所有结果都是http 200，通过code区分，保持与老客户端兼容
+	ApiV1 = 1
+	// v2：请求按schema校验，http状态码与code对应
+	ApiV2 = 2
+)
+
+var (
+	ApiVersions = []int{ApiV1, ApiV2}
+)
+
+// 公开接口的声明，每个版本都提供同样的路由，只是响应的方式不同
+type Route struct {
+	// Logic的方法名，用于日志和OpenAPI的operationId
+	Name    string
+	Path    string
+	Summary string
+	// 请求和响应的原型，每次请求按类型新建
+	Request  interface{}
+	Response interface{}
+	// 比赛可能在其他节点上，需要转发
+	Forward bool
+	// 比赛中匹配结果之后的消息，客户端可以要求增量格式
+	Delta bool
+
+	call func(logic Logic, request, response interface{}) error
+}
+
+var (
+	Routes = []*Route{
+		{Name: "Match", Path: "/match", Summary: "Wait for an opponent at a level, a robot joins after match_wait_second",
+			Request: &MatchRequest{}, Response: &MatchResponse{},
+			call: func(logic Logic, request, response interface{}) error {
+				return logic.Match(request.(*MatchRequest), response.(*MatchResponse))
+			}},
+		{Name: "Ready", Path: "/ready", Summary: "Submit the move of a round and wait for the result",
+			Request: &ReadyRequest{}, Response: &ReadyResponse{}, Forward: true, Delta: true,
+			call: func(logic Logic, request, response interface{}) error {
+				return logic.Ready(request.(*ReadyRequest), response.(*ReadyResponse))
+			}},
+		{Name: "ReadyStatus", Path: "/ready/status", Summary: "Poll the opponent status, also keeps the competitor alive",
+			Request: &ReadyStatusRequest{}, Response: &ReadyStatusResponse{}, Forward: true, Delta: true,
+			call: func(logic Logic, request, response interface{}) error {
+				return logic.ReadyStatus(request.(*ReadyStatusRequest), response.(*ReadyStatusResponse))
+			}},
+		{Name: "Leave", Path: "/leave", Summary: "Leave a match",
+			Request: &LeaveRequest{}, Response: &LeaveResponse{}, Forward: true, Delta: true,
+			call: func(logic Logic, request, response interface{}) error {
+				return logic.Leave(request.(*LeaveRequest), response.(*LeaveResponse))
+			}},
+		{Name: "Ranking", Path: "/ranking", Summary: "Top winners of all time",
+			Request: &RankingRequest{}, Response: &RankingResponse{},
+			call: func(logic Logic, request, response interface{}) error {
+				return logic.Ranking(request.(*RankingRequest), response.(*RankingResponse))
+			}},
+		{Name: "OnlineNumber", Path: "/online/number", Summary: "Number of players online by room",
+			Request: &OnlineNumberRequest{}, Response: &OnlineNumberResponse{},
+			call: func(logic Logic, request, response interface{}) error {
+				return logic.OnlineNumber(request.(*OnlineNumberRequest), response.(*OnlineNumberResponse))
+			}},
+		{Name: "Stats", Path: "/stats", Summary: "Lifetime statistics of a player",
+			Request: &StatsRequest{}, Response: &StatsResponse{},
+			call: func(logic Logic, request, response interface{}) error {
+				return logic.Stats(request.(*StatsRequest), response.(*StatsResponse))
+			}},
+		{Name: "Leaderboard", Path: "/leaderboard", Summary: "Leaderboard of a window and period, with the caller's rank",
+			Request: &LeaderboardRequest{}, Response: &LeaderboardResponse{},
+			call: func(logic Logic, request, response interface{}) error {
+				return logic.Leaderboard(request.(*LeaderboardRequest), response.(*LeaderboardResponse))
+			}},
+	}
+
+	// 完整路径到路由和版本
+	routeIndex = make(map[string]*versionedRoute)
+	// Logic的方法名到路由，gRPC接口按方法名调用
+	routeNames = make(map[string]*Route)
+)
+
+// 404、405等不属于任何接口的错误
+type ApiResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+type versionedRoute struct {
+	*Route
+	Version int
+}
+
+func init() {
+	for _, route := range Routes {
+		routeNames[route.Name] = route
+	}
+	for _, version := range ApiVersions {
+		for _, route := range Routes {
+			routeIndex[apiPath(version, route.Path)] = &versionedRoute{route, version}
+		}
+	}
+}
+
+func apiPath(version int, path string) string {
+	return ApiPathPrefix + strconv.Itoa(version) + path
+}
+
+func lookupRoute(path string) *versionedRoute {
+	return routeIndex[path]
+}
+
+// v2中code对应的http状态码
+func httpStatus(code int) int {
+	switch code {
+	case ResponseCodeOK:
+		return fasthttp.StatusOK
+	case ResponseCodeBadRequestFormat, ResponseCodeBadOperate, ResponseCodeBadRound, ResponseCodeBadLevel, ResponseCodeBadUid:
+		return fasthttp.StatusBadRequest
+	case ResponseCodeBadAccessToken, ResponseCodeBadSession, ResponseCodeBadSignature:
+		return fasthttp.StatusUnauthorized
+	case ResponseCodeBadMatchId:
+		return fasthttp.StatusNotFound
+	case ResponseCodeWaitReadyTimeout, ResponseCodeWaitMatchTimeout:
+		return fasthttp.StatusRequestTimeout
+	case ResponseCodeBadMatchStatus, ResponseCodeBadReadyStatus, ResponseCodeBadAccountStatus, ResponseCodeKickOut, ResponseCodeInsufficientBalance:
+		return fasthttp.StatusConflict
+	case ResponseCodeRateLimited:
+		return fasthttp.StatusTooManyRequests
+	case ResponseCodeMaintenance:
+		return fasthttp.StatusServiceUnavailable
+	default:
+		return fasthttp.StatusInternalServerError
+	}
+}
+
+// 各个响应都有Code和Msg字段
+func responseFields(response interface{}) (code *int, msg *string) {
+	v := reflect.ValueOf(response).Elem()
+	code = v.FieldByName("Code").Addr().Interface().(*int)
+	if f := v.FieldByName("Msg"); f.IsValid() {
+		msg = f.Addr().Interface().(*string)
+	}
+	return
+}
+
+// 请求中的access_token，没有这个字段时为空
+func requestAccessToken(request interface{}) string {
+	if f := reflect.ValueOf(request).Elem().FieldByName("AccessToken"); f.IsValid() {
+		return f.String()
+	}
+	return ""
+}
+
+func (api *HttpApi) clientIp(ctx *fasthttp.RequestCtx) string {
+	return clientIp(api.conf.Get().ClientIpHeader, ctx.RemoteAddr().String(), func(name string) string { return string(ctx.Request.Header.Peek(name)) })
+}
+
+// 转发来的请求已经在第一个节点上检查过；没有sign_secret时转发头可以伪造，
+// 所以只跳过按ip的检查，此时的ip是转发节点的地址
+func (api *HttpApi) rateLimit(ctx *fasthttp.RequestCtx, route *versionedRoute, request interface{}, ip string) time.Duration {
+	if len(ctx.Request.Header.Peek(HeaderForwardedBy)) > 0 {
+		if api.conf.Get().SignSecret != "" {
+			return 0
+		}
+		ip = ""
+	}
+	return api.limiter.Allow(route.Name, ip, requestAccessToken(request))
+}
+
+func (api *HttpApi) serveRoute(ctx *fasthttp.RequestCtx, route *versionedRoute) {
+	var (
+		request   = reflect.New(reflect.TypeOf(route.Request).Elem()).Interface()
+		response  = reflect.New(reflect.TypeOf(route.Response).Elem()).Interface()
+		code, msg = responseFields(response)
+		ip        = api.clientIp(ctx)
+		err       error
+	)
+
+	// 签名针对原始的body，在补全空body之前检查
+	if *code, err = api.verifySignature(ctx); err != nil {
+		if route.Version >= ApiV2 {
+			*msg = err.Error()
+		}
+		goto out
+	}
+
+	// v2允许没有参数的接口不带body
+	if route.Version >= ApiV2 && len(ctx.PostBody()) == 0 {
+		if isMsgpack(ctx.Request.Header.ContentType()) {
+			ctx.Request.SetBody(msgpackEmptyMap)
+		} else {
+			ctx.Request.SetBodyString("{}")
+		}
+	}
+
+	if err = parse(request, ctx); err != nil {
+		*code = ResponseCodeBadRequestFormat
+		if route.Version >= ApiV2 {
+			*msg = err.Error()
+		}
+		goto out
+	}
+
+	if route.Version >= ApiV2 {
+		if err = validateRequest(request); err != nil {
+			*code = ResponseCodeBadRequestFormat
+			*msg = err.Error()
+			goto out
+		}
+	}
+
+	if t, ok := request.(interface{ SetClientIp(string) }); ok {
+		t.SetClientIp(ip)
+	}
+
+	if wait := api.rateLimit(ctx, route, request, ip); wait > 0 {
+		*code = ResponseCodeRateLimited
+		ctx.Response.Header.Set("Retry-After", retryAfterSecond(wait))
+		goto out
+	}
+
+	if err = route.call(api.logic, request, response); err != nil {
+		if route.Forward && api.forward(ctx, err, code) {
+			return
+		}
+		log.Error("Logic.%s failed: %s, request: %#v", route.Name, err, request)
+	}
+
+out:
+	if route.Version >= ApiV2 {
+		ctx.SetStatusCode(httpStatus(*code))
+		if msg != nil && *msg == "" && *code != ResponseCodeOK {
+			*msg = getCodeDescription(*code)
+		}
+	}
+
+	replyEncoded(ctx, *code, response, negotiateEncoding(ctx, route))
+}
+
+// 路径中的版本号，不是接口路径或者版本号不对时按v1处理
+func pathVersion(path string) int {
+	if !strings.HasPrefix(path, ApiPathPrefix) {
+		return ApiV1
+	}
+	v := path[len(ApiPathPrefix):]
+	if i := strings.IndexByte(v, '/'); i >= 0 {
+		v = v[:i]
+	}
+	if version, err := strconv.Atoi(v); err == nil && version >= ApiV2 {
+		return version
+	}
+	return ApiV1
+}
+
+// 未知的路径和错误的方法。v2返回404和405；v1保持原来的行为：
+// GET返回200和"Method Not Allowed"，未知的路径返回空的200，其他方法照常处理
+func rejectRequest(ctx *fasthttp.RequestCtx, route *versionedRoute) bool {
+	version := pathVersion(string(ctx.Path()))
+	if route != nil {
+		version = route.Version
+	}
+
+	if version < ApiV2 {
+		if bytes.Equal(ctx.Method(), GET) {
+			reply(ctx, ResponseCodeBadRequestFormat, []byte("Method Not Allowed"))
+			return true
+		}
+		if route == nil {
+			log.Error("unknown url: %s", ctx.Path())
+			ctx.SetContentType("application/json")
+			reply(ctx, ResponseCodeBadRequestFormat, nil)
+			return true
+		}
+		return false
+	}
+
+	if route == nil {
+		replyNotFound(ctx)
+		return true
+	}
+	if !bytes.Equal(ctx.Method(), POST) {
+		replyMethodNotAllowed(ctx)
+		return true
+	}
+	return false
+}
+
+func replyNotFound(ctx *fasthttp.RequestCtx) {
+	ctx.SetStatusCode(fasthttp.StatusNotFound)
+	ctx.SetContentType("application/json")
+	v, _ := json.Marshal(&ApiResponse{Code: ResponseCodeBadRequestFormat, Msg: "not found"})
+	reply(ctx, ResponseCodeBadRequestFormat, v)
+}
+
+func replyMethodNotAllowed(ctx *fasthttp.RequestCtx) {
+	ctx.SetStatusCode(fasthttp.StatusMethodNotAllowed)
+	ctx.Response.Header.Set("Allow", "POST, OPTIONS")
+	ctx.SetContentType("application/json")
+	v, _ := json.Marshal(&ApiResponse{Code: ResponseCodeBadRequestFormat, Msg: "method not allowed"})
+	reply(ctx, ResponseCodeBadRequestFormat, v)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestRejectRequest(t *testing.T) {
+	tests := []struct {
+		method, path string
+		rejected     bool
+		status       int
+		body         string
+	}{
+		// v1保持原来的行为，全部是http 200
+		{"POST", "/fingerplay/v1/unknown", true, fasthttp.StatusOK, ""},
+		{"GET", "/fingerplay/v1/match", true, fasthttp.StatusOK, "Method Not Allowed"},
+		{"GET", "/unknown", true, fasthttp.StatusOK, "Method Not Allowed"},
+		{"PUT", "/fingerplay/v1/match", false, fasthttp.StatusOK, ""},
+		{"POST", "/fingerplay/v1/match", false, fasthttp.StatusOK, ""},
+		// v2返回404和405
+		{"POST", "/fingerplay/v2/unknown", true, fasthttp.StatusNotFound, `"msg":"not found"`},
+		{"GET", "/fingerplay/v2/unknown", true, fasthttp.StatusNotFound, `"msg":"not found"`},
+		{"GET", "/fingerplay/v2/match", true, fasthttp.StatusMethodNotAllowed, `"msg":"method not allowed"`},
+		{"POST", "/fingerplay/v2/match", false, fasthttp.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		req := &fasthttp.Request{}
+		req.Header.SetMethod(tt.method)
+		req.SetRequestURI(tt.path)
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Init(req, &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}, nil)
+
+		rejected := rejectRequest(ctx, lookupRoute(tt.path))
+		if rejected != tt.rejected {
+			t.Errorf("%s %s: rejected = %v, want %v", tt.method, tt.path, rejected, tt.rejected)
+			continue
+		}
+		if status := ctx.Response.StatusCode(); status != tt.status {
+			t.Errorf("%s %s: status = %d, want %d", tt.method, tt.path, status, tt.status)
+		}
+		body := string(ctx.Response.Body())
+		if (tt.body == "" && body != "") || !strings.Contains(body, tt.body) {
+			t.Errorf("%s %s: body = %q, want %q", tt.method, tt.path, body, tt.body)
+		}
+		if tt.status == fasthttp.StatusMethodNotAllowed && string(ctx.Response.Header.Peek("Allow")) != "POST, OPTIONS" {
+			t.Errorf("%s %s: Allow = %q", tt.method, tt.path, ctx.Response.Header.Peek("Allow"))
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// 请求字段的约束写在schema标签里，OpenAPI文档和v2的请求校验使用同一份定义：
+//
+//	Level int `json:"level" schema:"required,min=1" doc:"bet level"`
+//
+// required表示不能为零值，min和max用于数字，enum的取值用|分隔
+type fieldRule struct {
+	required bool
+	min, max *float64
+	enum     []string
+}
+
+func parseFieldRule(tag string) (rule *fieldRule) {
+	rule = &fieldRule{}
+	for _, part := range strings.Split(tag, ",") {
+		name, value := part, ""
+		if i := strings.Index(part, "="); i >= 0 {
+			name, value = part[:i], part[i+1:]
+		}
+
+		switch name {
+		case "required":
+			rule.required = true
+		case "min", "max":
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				panic(fmt.Sprintf("bad schema tag %q", tag))
+			}
+			if name == "min" {
+				rule.min = &f
+			} else {
+				rule.max = &f
+			}
+		case "enum":
+			rule.enum = strings.Split(value, "|")
+		case "":
+		default:
+			panic(fmt.Sprintf("bad schema tag %q", tag))
+		}
+	}
+	return
+}
+
+// json中的字段名，不序列化的字段返回空
+func jsonFieldName(f reflect.StructField) string {
+	if f.PkgPath != "" {
+		return ""
+	}
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		name = f.Name
+	}
+	return name
+}
+
+// 按json的规则展开嵌入的结构体
+func eachJsonField(t reflect.Type, fn func(name string, f reflect.StructField, index []int)) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			eachJsonField(f.Type, func(name string, sf reflect.StructField, index []int) {
+				fn(name, sf, append([]int{i}, index...))
+			})
+			continue
+		}
+		if name := jsonFieldName(f); name != "" {
+			fn(name, f, []int{i})
+		}
+	}
+}
+
+type SchemaError struct {
+	Errors []string
+}
+
+func (e *SchemaError) Error() string {
+	return strings.Join(e.Errors, "; ")
+}
+
+func (e *SchemaError) add(field, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, field+": "+fmt.Sprintf(format, args...))
+}
+
+// 按schema标签检查请求，返回*SchemaError
+func validateRequest(request interface{}) (err error) {
+	v := reflect.Indirect(reflect.ValueOf(request))
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	se := &SchemaError{}
+	eachJsonField(v.Type(), func(name string, f reflect.StructField, index []int) {
+		tag, ok := f.Tag.Lookup("schema")
+		if !ok {
+			return
+		}
+		rule := parseFieldRule(tag)
+		fv := v.FieldByIndex(index)
+
+		if fv.IsZero() {
+			if rule.required {
+				se.add(name, "is required")
+			}
+			return
+		}
+
+		var (
+			n       float64
+			numeric = true
+		)
+		switch fv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = float64(fv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = float64(fv.Uint())
+		case reflect.Float32, reflect.Float64:
+			n = fv.Float()
+		default:
+			numeric = false
+		}
+		if numeric && rule.min != nil && n < *rule.min {
+			se.add(name, "must be at least %v, got %v", *rule.min, n)
+		}
+		if numeric && rule.max != nil && n > *rule.max {
+			se.add(name, "must be at most %v, got %v", *rule.max, n)
+		}
+
+		if len(rule.enum) > 0 {
+			s := fmt.Sprint(fv.Interface())
+			for _, e := range rule.enum {
+				if s == e {
+					return
+				}
+			}
+			se.add(name, "must be one of %s, got %q", strings.Join(rule.enum, ", "), s)
+		}
+	})
+
+	if len(se.Errors) > 0 {
+		return se
+	}
+	return
+}
+
+// OpenAPI 3.0的Schema对象，只包含用到的部分
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+}
+
+const (
+	SchemaRefPrefix = "#/components/schemas/"
+)
+
+// 由Go类型生成schema，有名字的结构体放到components中并返回引用
+func schemaOf(t reflect.Type, components map[string]*Schema) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem(), components)
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: schemaOf(t.Elem(), components)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaOf(t.Elem(), components)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return structSchema(t, components)
+		}
+		if _, ok := components[t.Name()]; !ok {
+			// 先占位，结构体引用自身时不会无限递归
+			components[t.Name()] = &Schema{}
+			*components[t.Name()] = *structSchema(t, components)
+		}
+		return &Schema{Ref: SchemaRefPrefix + t.Name()}
+	default:
+		return &Schema{}
+	}
+}
+
+func structSchema(t reflect.Type, components map[string]*Schema) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	eachJsonField(t, func(name string, f reflect.StructField, index []int) {
+		fs := schemaOf(f.Type, components)
+		if doc := f.Tag.Get("doc"); doc != "" {
+			// $ref不能和其他属性并列，需要说明时包一层
+			if fs.Ref != "" {
+				fs = &Schema{AllOf: []*Schema{fs}}
+			}
+			fs.Description = doc
+		}
+
+		if tag, ok := f.Tag.Lookup("schema"); ok {
+			rule := parseFieldRule(tag)
+			if rule.required {
+				s.Required = append(s.Required, name)
+			}
+			fs.Minimum = rule.min
+			fs.Maximum = rule.max
+			for _, e := range rule.enum {
+				if fs.Type == "integer" {
+					n, _ := strconv.Atoi(e)
+					fs.Enum = append(fs.Enum, n)
+				} else {
+					fs.Enum = append(fs.Enum, e)
+				}
+			}
+		}
+		s.Properties[name] = fs
+	})
+	return s
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"path/filepath"
+	"time"
+
+	log "code.google.com/p/log4go"
+)
+
+// 一个完整的游戏节点，持有所有组件和自己的配置，组件之间的引用和配置在Start时
+// 按这里的字段连接，不读取包级别的变量，同一个进程中可以运行多个互相隔离的Server。
+//
+// NewServer按配置创建默认的组件，Start之前可以替换导出的字段，例如：
+//
+//	s, _ := NewServer(cfg)
+//	s.Accounts = fakeAccounts
+//	s.Clock = NewManualClock(begin)
+//	s.Start()
+type Server struct {
+	conf *ConfigValue
+
+	// 匹配、超时、机器人、限流、签名和榜单归档使用的时钟
+	Clock Clock
+
+	Context     *Context
+	Store       Store
+	Accounts    Accounts
+	Logic       *LogicImpl
+	Robots      Robots
+	Cluster     *Cluster // 没有配置redis时为nil
+	Statistics  *StatisticsManager
+	PlayerStats *PlayerStatsManager
+	History     *HistoryManager
+	Risk        *RiskController
+	Events      *EventHub
+	Limiter     *RateLimiter
+	Fraud       *FraudDetector
+	Http        *HttpApi
+	Admin       *AdminApi // 没有配置admin_bind_addr时为nil
+	Grpc        *GrpcApi  // 没有配置grpc_bind_addr时为nil
+}
+
+func NewServer(conf *Config) (s *Server, err error) {
+	s = &Server{}
+	s.conf = NewConfigValue(conf)
+	s.Clock = DefaultClock
+	s.Context = NewContext()
+
+	if s.Store, err = NewStore(conf, s.Context); err != nil {
+		return nil, err
+	}
+
+	s.Accounts = NewAccountManager(conf.EndpointDescribeUser, conf.EndpointTransfer, conf.EndpointLoginAI)
+	s.Statistics = NewStatisticsManager(s.Store, conf.StatisticsSpoolDir)
+	s.PlayerStats = NewPlayerStatsManager(s.Store, filepath.Join(conf.HistorySpoolDir, "player_stats"))
+	s.History = NewHistoryManager(s.Store, s.Store, conf.HistorySpoolDir)
+	s.Risk = NewRiskController(s.Store)
+	s.Events = NewEventHub()
+	s.Limiter = NewRateLimiter(s.Accounts)
+	s.Fraud = NewFraudDetector()
+	s.Logic = NewLogicImpl(s.Accounts, conf.Levels, conf.OperateTimeoutSecond, conf.MatchWaitSecond)
+	s.Robots = NewRobotManager(s.Accounts, s.Logic, conf.RobotUid, conf.RobotLifetimeSecond)
+
+	if conf.RedisAddr != "" {
+		if s.Context.rm, err = NewRedisManager(conf.RedisAddr); err != nil {
+			return nil, err
+		}
+		s.Cluster = NewCluster(s.Context, s.Logic, getNodeId(conf), getAdvertiseAddr(conf))
+	}
+
+	s.Http = NewHttpApi(conf.HttpBindAddr, s.Logic)
+	if conf.AdminBindAddr != "" {
+		s.Admin = NewAdminApi(conf.AdminBindAddr, s)
+	}
+	if conf.GrpcBindAddr != "" {
+		s.Grpc = NewGrpcApi(conf.GrpcBindAddr, s)
+	}
+	return
+}
+
+// 把替换后的组件连接起来
+func (s *Server) wire() {
+	impl := s.Logic
+	impl.conf = s.conf
+	impl.setClock(s.Clock)
+	impl.accountManager = s.Accounts
+	impl.robots = s.Robots
+	impl.cluster = s.Cluster
+	impl.statistics = s.Statistics
+	impl.playerStats = s.PlayerStats
+	impl.history = s.History
+	impl.risk = s.Risk
+	impl.events = s.Events
+	impl.fraud = s.Fraud
+
+	if am, ok := s.Accounts.(*AccountManager); ok {
+		am.conf = s.conf
+		am.clock = s.Clock
+		am.rand = newRand(s.Clock)
+	}
+
+	if rm, ok := s.Robots.(*RobotManager); ok {
+		rm.conf = s.conf
+		rm.clock = s.Clock
+		rm.accounts = s.Accounts
+		rm.logic = impl
+	}
+
+	if s.Cluster != nil {
+		s.Cluster.impl = impl
+	}
+
+	s.Statistics.clock = s.Clock
+	s.Statistics.Leaderboard().clock = s.Clock
+
+	s.Risk.conf = s.conf
+	s.Fraud.conf = s.conf
+	s.Fraud.clock = s.Clock
+	s.Fraud.cluster = s.Cluster
+	s.Limiter.conf = s.conf
+	s.Limiter.clock = s.Clock
+	s.Limiter.accounts = s.Accounts
+	s.Limiter.cluster = s.Cluster
+
+	s.Http.conf = s.conf
+	s.Http.clock = s.Clock
+	s.Http.cluster = s.Cluster
+	s.Http.limiter = s.Limiter
+	s.Http.metrics = newMetricsHandler(s)
+	s.Http.readyz = s.handleReadyz
+}
+
+// 启动匹配循环、集群、http和gRPC接口。http_bind_addr为空时不监听，
+// 测试可以直接调用s.Http的handler。
+func (s *Server) Start() (err error) {
+	s.wire()
+
+	if !s.conf.Get().RequireSessionTicket {
+		log.Warn("require_session_ticket is off, ready and leave accept requests without the session ticket")
+	}
+	if s.Cluster != nil && s.conf.Get().FraudWindowSecond > 0 {
+		log.Warn("fraud thresholds count only the matches settled on node %s, a pair spread over several nodes may stay below them", s.Cluster.NodeId())
+	}
+
+	s.Statistics.Start()
+	s.Logic.Start()
+	if s.Cluster != nil {
+		s.Cluster.Start()
+	}
+
+	if s.Admin != nil {
+		if err = s.Admin.Start(); err != nil {
+			return
+		}
+	}
+
+	if s.Grpc != nil {
+		if err = s.Grpc.Start(); err != nil {
+			return
+		}
+	}
+
+	if s.conf.Get().HttpBindAddr != "" {
+		err = s.Http.Start()
+	}
+	return
+}
+
+// 热更新的配置生效，只影响这个Server
+func (s *Server) Reload(cfg *Config) {
+	s.conf.Set(cfg)
+	s.Logic.Reload(cfg)
+}
+
+// 先排空对局，此时http仍然可用，进行中的回合可以完成结算，
+// 然后关闭接口，最后把统计和历史写入存储
+func (s *Server) Shutdown(deadline time.Time) {
+	s.Logic.Drain(deadline)
+
+	if s.conf.Get().HttpBindAddr != "" {
+		if err := s.Http.Shutdown(deadline); err != nil {
+			log.Error("Http shutdown failed: %s", err)
+		}
+	}
+
+	if s.Admin != nil {
+		if err := s.Admin.Shutdown(deadline); err != nil {
+			log.Error("Admin shutdown failed: %s", err)
+		}
+	}
+
+	if s.Grpc != nil {
+		if err := s.Grpc.Shutdown(deadline); err != nil {
+			log.Error("Grpc shutdown failed: %s", err)
+		}
+	}
+
+	// 对局已经排空、接口已经关闭，不再需要集群
+	if s.Cluster != nil {
+		s.Cluster.Close()
+	}
+	if s.Context.rm != nil {
+		s.Context.rm.Close()
+	}
+
+	s.Statistics.Close()
+	s.History.Close()
+	s.PlayerStats.Close()
+	s.Store.Close()
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"fingerplay/wallettest"
+)
+
+// 内存存储、不监听端口的配置，各个测试在此基础上修改
+func newTestConfig(t *testing.T) *Config {
+	cfg := newConfig()
+	cfg.StoreDriver = StoreDriverMemory
+	cfg.StatisticsSpoolDir = t.TempDir()
+	cfg.HistorySpoolDir = t.TempDir()
+	cfg.Levels = []int{100}
+	cfg.OperateTimeoutSecond = 10
+	cfg.MatchWaitSecond = 5
+	cfg.MaxRobotUid = 2000
+	cfg.RobotUid = 1000
+	cfg.RobotLifetimeSecond = 300
+	cfg.Robots = []*RobotAvatar{{Nickname: "Robot", Avatar: "robot.png"}}
+	cfg.BaseOnlineNumbers = make([]int, 24)
+	return cfg
+}
+
+// 使用假的账户服务，除了users之外没有见过的令牌自动注册为新用户
+func useTestWallet(t *testing.T, cfg *Config, users ...*wallettest.User) *wallettest.Wallet {
+	wallet := wallettest.New(wallettest.Options{Users: users})
+	srv := httptest.NewServer(wallet)
+	t.Cleanup(srv.Close)
+	cfg.EndpointDescribeUser = srv.URL + wallettest.PathDescribeUser
+	cfg.EndpointTransfer = srv.URL + wallettest.PathTransfer
+	return wallet
+}
+
+// 返回的Server还没有启动，可以先替换组件和时钟
+func newTestServer(t *testing.T, cfg *Config) *Server {
+	s, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("NewServer() failed: %s", err)
+	}
+	return s
+}
+
+func startTestServer(t *testing.T, s *Server) *Server {
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start() failed: %s", err)
+	}
+	// 按Server的时钟计算，使用ManualClock时不会在Drain中等待
+	t.Cleanup(func() { s.Shutdown(s.Clock.Now()) })
+	return s
+}
+
+// 轮询直到cond成立，用于等待其他goroutine处理完请求
+func waitFor(t *testing.T, what string, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// 只记录调用次数的机器人
+type countingRobots struct {
+	mux   sync.Mutex
+	calls int
+}
+
+func (r *countingRobots) GoGoGo(lv int, balance float64) {
+	r.mux.Lock()
+	r.calls++
+	r.mux.Unlock()
+}
+
+func (r *countingRobots) Calls() int {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return r.calls
+}
+
+func (r *countingRobots) nextRobotAvatar() *RobotAvatar {
+	return &RobotAvatar{Nickname: "Robot", Avatar: "robot.png"}
+}
+
+func (r *countingRobots) Usage() (idle int, playing map[int]int) {
+	return 0, map[int]int{}
+}
+
+func TestServersHaveTheirOwnConfig(t *testing.T) {
+	cfg1 := newTestConfig(t)
+	cfg1.LevelCosts = map[string]float64{"100": 5}
+	cfg1.RateLimits = map[string]*RateLimit{RateLimitDefault: {IpRate: 1, IpBurst: 1}}
+	cfg2 := newTestConfig(t)
+	cfg2.MaxRobotUid = 1000
+
+	s1 := startTestServer(t, newTestServer(t, cfg1))
+	s2 := startTestServer(t, newTestServer(t, cfg2))
+
+	if c1, c2 := s1.Logic.getCost(100), s2.Logic.getCost(100); c1 != 5 || c2 != 0 {
+		t.Errorf("getCost(100) = %v, %v, want 5, 0", c1, c2)
+	}
+	if s1.conf.Get().isMan(1500) || !s2.conf.Get().isMan(1500) {
+		t.Errorf("uid 1500 should be a robot only on the first server")
+	}
+	for i := 0; i < 2; i++ {
+		s2.Limiter.Allow("match", "10.0.0.1", "")
+	}
+	s1.Limiter.Allow("match", "10.0.0.1", "")
+	if wait := s1.Limiter.Allow("match", "10.0.0.1", ""); wait == 0 {
+		t.Errorf("first server should apply its own rate limit")
+	}
+	if wait := s2.Limiter.Allow("match", "10.0.0.1", ""); wait != 0 {
+		t.Errorf("second server has no rate limit, wait = %s", wait)
+	}
+
+	// 热更新只影响自己
+	reloaded := *cfg1
+	reloaded.LevelCosts = map[string]float64{"100": 7}
+	s1.Reload(&reloaded)
+	if c1, c2 := s1.Logic.getCost(100), s2.Logic.getCost(100); c1 != 7 || c2 != 0 {
+		t.Errorf("after reload getCost(100) = %v, %v, want 7, 0", c1, c2)
+	}
+	if GetConf() == s1.conf.Get() || GetConf() == s2.conf.Get() {
+		t.Errorf("servers should not share the process config")
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+
+	"github.com/valyala/fasthttp"
+
+	log "code.google.com/p/log4go"
+)
+
+// 配置了sign_secret时，公开接口的请求需要签名：
+//
+//	X-Fingerplay-Timestamp: unix秒，与服务端相差不超过sign_window_second
+//	X-Fingerplay-Nonce:     每个请求不同的随机串，8到64个字符
+//	X-Fingerplay-Signature: hex(HMAC-SHA256(sign_secret, method\npath\ntimestamp\nnonce\nbody))
+//
+// nonce在两倍的时间窗口内只能使用一次，集群模式下所有节点共享。
+// gRPC接口只在内网使用，不检查签名。
+const (
+	HeaderTimestamp = "X-Fingerplay-Timestamp"
+	HeaderNonce     = "X-Fingerplay-Nonce"
+	HeaderSignature = "X-Fingerplay-Signature"
+	// 转发请求的节点已经检查过nonce，用这个签名告诉对方节点不需要再检查
+	HeaderForwardSignature = "X-Fingerplay-Forward-Signature"
+
+	NonceMinLength = 8
+	NonceMaxLength = 64
+)
+
+func signRequest(secret, method, path, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(method + "\n" + path + "\n" + timestamp + "\n" + nonce + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// 绑定转发节点和原请求的签名，不能用在其他请求上
+func signForward(secret, nodeId, signature string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("forward\n" + nodeId + "\n" + signature))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifyMAC(expected, actual string) bool {
+	a, err := hex.DecodeString(actual)
+	if err != nil {
+		return false
+	}
+	e, _ := hex.DecodeString(expected)
+	return hmac.Equal(e, a)
+}
+
+// 单机模式下记录用过的nonce
+type NonceCache struct {
+	mux     sync.Mutex
+	seen    map[string]int64
+	sweepTs int64
+}
+
+func NewNonceCache() *NonceCache {
+	nc := &NonceCache{}
+	nc.seen = make(map[string]int64)
+	return nc
+}
+
+// 第一次使用nonce时返回true
+func (nc *NonceCache) Claim(nonce string, now int64, ttlSecond int) bool {
+	nc.mux.Lock()
+	defer nc.mux.Unlock()
+
+	// 每个周期清理一次过期的nonce
+	if now-nc.sweepTs >= int64(ttlSecond) {
+		nc.sweepTs = now
+		for k, ts := range nc.seen {
+			if now >= ts {
+				delete(nc.seen, k)
+			}
+		}
+	}
+
+	if ts, ok := nc.seen[nonce]; ok && now < ts {
+		return false
+	}
+	nc.seen[nonce] = now + int64(ttlSecond)
+	return true
+}
+
+// 没有配置sign_secret时不检查，签名不正确时code为ResponseCodeBadSignature
+func (api *HttpApi) verifySignature(ctx *fasthttp.RequestCtx) (code int, err error) {
+	conf := api.conf.Get()
+	if conf.SignSecret == "" {
+		return
+	}
+
+	if err = api.checkSignature(ctx, conf); err == nil {
+		return
+	}
+
+	switch err {
+	case ErrSignatureMissing, ErrSignatureExpired, ErrSignatureMismatch, ErrNonceReplayed:
+		observeSignatureRejected(err)
+		code = ResponseCodeBadSignature
+	default:
+		log.Error("verify signature failed: %s", err)
+		code = ResponseCodeInternalError
+	}
+	return
+}
+
+func (api *HttpApi) checkSignature(ctx *fasthttp.RequestCtx, conf *Config) (err error) {
+	timestamp := string(ctx.Request.Header.Peek(HeaderTimestamp))
+	nonce := string(ctx.Request.Header.Peek(HeaderNonce))
+	signature := string(ctx.Request.Header.Peek(HeaderSignature))
+	if timestamp == "" || signature == "" || len(nonce) < NonceMinLength || len(nonce) > NonceMaxLength {
+		return ErrSignatureMissing
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrSignatureMissing
+	}
+	now := api.clock.Now().Unix()
+	if ts < now-int64(conf.SignWindowSecond) || ts > now+int64(conf.SignWindowSecond) {
+		return ErrSignatureExpired
+	}
+
+	expected := signRequest(conf.SignSecret, string(ctx.Method()), string(ctx.Path()), timestamp, nonce, ctx.PostBody())
+	if !verifyMAC(expected, signature) {
+		return ErrSignatureMismatch
+	}
+
+	if by := string(ctx.Request.Header.Peek(HeaderForwardedBy)); by != "" {
+		if !verifyMAC(signForward(conf.SignSecret, by, signature), string(ctx.Request.Header.Peek(HeaderForwardSignature))) {
+			return ErrSignatureMismatch
+		}
+		return
+	}
+
+	// 时间窗口前后各sign_window_second，nonce保留到时间戳过期之后
+	var (
+		ok  bool
+		ttl = 2 * conf.SignWindowSecond
+	)
+
+	if api.cluster.Enabled() {
+		if ok, err = api.cluster.ClaimNonce(nonce, ttl); err != nil {
+			return
+		}
+	} else {
+		ok = api.nonces.Claim(nonce, now, ttl)
+	}
+
+	if !ok {
+		return ErrNonceReplayed
+	}
+	return
+}
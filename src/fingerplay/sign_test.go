@@ -0,0 +1,170 @@
+package main
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+const testSignSecret = "sign-secret"
+
+func newTestSignApi(t *testing.T) (*HttpApi, *ManualClock) {
+	cfg := newTestConfig(t)
+	cfg.SignSecret = testSignSecret
+	cfg.SignWindowSecond = 30
+	api := NewHttpApi("", nil)
+	api.conf = NewConfigValue(cfg)
+	clock := NewManualClock(time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local))
+	api.clock = clock
+	return api, clock
+}
+
+// 按sign.go的规则签名，secret为空时不带签名头
+func newSignedCtx(secret string, ts int64, nonce string, body []byte) *fasthttp.RequestCtx {
+	path := apiPath(ApiV2, "/ready/status")
+	timestamp := strconv.FormatInt(ts, 10)
+	req := &fasthttp.Request{}
+	req.Header.SetMethod("POST")
+	req.SetRequestURI(path)
+	req.SetBody(body)
+	if secret != "" {
+		req.Header.Set(HeaderTimestamp, timestamp)
+		req.Header.Set(HeaderNonce, nonce)
+		req.Header.Set(HeaderSignature, signRequest(secret, "POST", path, timestamp, nonce, body))
+	}
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(req, &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}, nil)
+	return ctx
+}
+
+func TestCheckSignature(t *testing.T) {
+	api, clock := newTestSignApi(t)
+	conf := api.conf.Get()
+	now := clock.Now().Unix()
+	body := []byte(`{"match_id":"m1"}`)
+
+	for _, c := range []struct {
+		name string
+		ctx  *fasthttp.RequestCtx
+		err  error
+	}{
+		{"valid", newSignedCtx(testSignSecret, now, "nonce-0001", body), nil},
+		{"unsigned", newSignedCtx("", now, "nonce-0002", body), ErrSignatureMissing},
+		{"short nonce", newSignedCtx(testSignSecret, now, "n1", body), ErrSignatureMissing},
+		{"wrong secret", newSignedCtx("other-secret", now, "nonce-0003", body), ErrSignatureMismatch},
+		{"expired", newSignedCtx(testSignSecret, now-31, "nonce-0004", body), ErrSignatureExpired},
+		{"from the future", newSignedCtx(testSignSecret, now+31, "nonce-0005", body), ErrSignatureExpired},
+		{"edge of the window", newSignedCtx(testSignSecret, now-30, "nonce-0006", body), nil},
+	} {
+		if err := api.checkSignature(c.ctx, conf); err != c.err {
+			t.Errorf("%s: checkSignature() = %v, want %v", c.name, err, c.err)
+		}
+	}
+
+	// 签名之后改动body
+	ctx := newSignedCtx(testSignSecret, now, "nonce-0007", body)
+	ctx.Request.SetBody([]byte(`{"match_id":"m2"}`))
+	if err := api.checkSignature(ctx, conf); err != ErrSignatureMismatch {
+		t.Errorf("tampered body: checkSignature() = %v, want %v", err, ErrSignatureMismatch)
+	}
+	if code, _ := api.verifySignature(ctx); code != ResponseCodeBadSignature {
+		t.Errorf("tampered body: verifySignature() = %d, want %d", code, ResponseCodeBadSignature)
+	}
+}
+
+func TestNonceReplay(t *testing.T) {
+	api, clock := newTestSignApi(t)
+	conf := api.conf.Get()
+	body := []byte(`{}`)
+
+	if err := api.checkSignature(newSignedCtx(testSignSecret, clock.Now().Unix(), "nonce-0001", body), conf); err != nil {
+		t.Fatalf("checkSignature() = %v, want nil", err)
+	}
+	// 同一个nonce重新签名也不行
+	clock.Advance(10 * time.Second)
+	if err := api.checkSignature(newSignedCtx(testSignSecret, clock.Now().Unix(), "nonce-0001", body), conf); err != ErrNonceReplayed {
+		t.Errorf("replay: checkSignature() = %v, want %v", err, ErrNonceReplayed)
+	}
+
+	// nonce保留两倍的时间窗口，之后原来的时间戳已经过期
+	clock.Advance(time.Duration(2*conf.SignWindowSecond-10) * time.Second)
+	api.nonces.Claim("nonce-0002", clock.Now().Unix(), 2*conf.SignWindowSecond)
+	if n := len(api.nonces.seen); n != 1 {
+		t.Errorf("%d nonces kept, want the expired one swept", n)
+	}
+	if !api.nonces.Claim("nonce-0001", clock.Now().Unix(), 2*conf.SignWindowSecond) {
+		t.Errorf("nonce still claimed after twice the window")
+	}
+}
+
+func TestForwardSignature(t *testing.T) {
+	api, clock := newTestSignApi(t)
+	conf := api.conf.Get()
+	now := clock.Now().Unix()
+
+	forwarded := func(nonce, by, forwardSignature string) *fasthttp.RequestCtx {
+		ctx := newSignedCtx(testSignSecret, now, nonce, []byte(`{}`))
+		ctx.Request.Header.Set(HeaderForwardedBy, by)
+		if forwardSignature == "" {
+			forwardSignature = signForward(testSignSecret, by, string(ctx.Request.Header.Peek(HeaderSignature)))
+		}
+		ctx.Request.Header.Set(HeaderForwardSignature, forwardSignature)
+		return ctx
+	}
+
+	// 转发的节点已经用过nonce，对方节点不再检查
+	if err := api.checkSignature(newSignedCtx(testSignSecret, now, "nonce-0001", []byte(`{}`)), conf); err != nil {
+		t.Fatalf("checkSignature() = %v, want nil", err)
+	}
+	if err := api.checkSignature(forwarded("nonce-0001", "node-a", ""), conf); err != nil {
+		t.Errorf("forwarded: checkSignature() = %v, want nil", err)
+	}
+
+	// 伪造的转发头不能跳过nonce检查
+	ctx := forwarded("nonce-0001", "node-a", "")
+	ctx.Request.Header.Set(HeaderForwardSignature, signForward("other-secret", "node-a", string(ctx.Request.Header.Peek(HeaderSignature))))
+	for name, ctx := range map[string]*fasthttp.RequestCtx{
+		"wrong secret": ctx,
+		"garbage":      forwarded("nonce-0001", "node-a", "not-hex"),
+		"truncated":    forwarded("nonce-0001", "node-a", "00"),
+		// 转发签名绑定节点和原请求的签名
+		"other node":    forwarded("nonce-0001", "node-b", signForward(testSignSecret, "node-a", string(ctx.Request.Header.Peek(HeaderSignature)))),
+		"other request": forwarded("nonce-0002", "node-a", signForward(testSignSecret, "node-a", string(ctx.Request.Header.Peek(HeaderSignature)))),
+	} {
+		if err := api.checkSignature(ctx, conf); err != ErrSignatureMismatch {
+			t.Errorf("%s: checkSignature() = %v, want %v", name, err, ErrSignatureMismatch)
+		}
+	}
+}
+
+func TestClusterNonceReplay(t *testing.T) {
+	tc := newTestCluster(t)
+	var apis []*HttpApi
+	for _, s := range tc.nodes {
+		api, _ := newTestSignApi(t)
+		api.clock = tc.clock
+		api.cluster = s.Cluster
+		apis = append(apis, api)
+	}
+	conf := apis[0].conf.Get()
+	now := tc.clock.Now().Unix()
+
+	if err := apis[0].checkSignature(newSignedCtx(testSignSecret, now, "nonce-0001", []byte(`{}`)), conf); err != nil {
+		t.Fatalf("checkSignature() on node-a = %v, want nil", err)
+	}
+	// 另一个节点也能看到用过的nonce
+	if err := apis[1].checkSignature(newSignedCtx(testSignSecret, now, "nonce-0001", []byte(`{}`)), conf); err != ErrNonceReplayed {
+		t.Errorf("checkSignature() on node-b = %v, want %v", err, ErrNonceReplayed)
+	}
+	if ttl := tc.redis.TTL(nonceKey("nonce-0001")); ttl != time.Duration(2*conf.SignWindowSecond)*time.Second {
+		t.Errorf("nonce ttl = %s, want twice the window", ttl)
+	}
+
+	tc.redis.FastForward(time.Duration(2*conf.SignWindowSecond) * time.Second)
+	if ok, err := tc.nodes[1].Cluster.ClaimNonce("nonce-0001", 2*conf.SignWindowSecond); err != nil || !ok {
+		t.Errorf("ClaimNonce() after the ttl = %v, %v, want true", ok, err)
+	}
+}
@@ -0,0 +1,455 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "code.google.com/p/log4go"
+)
+
+const (
+	SpoolSegmentSize = 4 * 1024 * 1024
+	SpoolSegmentExt  = ".jsonl"
+)
+
+// Spool是一个追加写的磁盘队列，每条记录一行JSON，按段文件存储。
+// 每次写入都会fsync，进程重启后可以从段文件中恢复。
+type Spool struct {
+	mux  sync.Mutex
+	dir  string
+	f    *os.File
+	size int64
+}
+
+func NewSpool(dir string) (sp *Spool, err error) {
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	sp = &Spool{}
+	sp.dir = dir
+	return
+}
+
+func (sp *Spool) Append(v interface{}) (err error) {
+	var (
+		b []byte
+		n int
+	)
+
+	if b, err = json.Marshal(v); err != nil {
+		return
+	}
+	b = append(b, '\n')
+
+	sp.mux.Lock()
+	defer sp.mux.Unlock()
+
+	if sp.f == nil {
+		name := filepath.Join(sp.dir, fmt.Sprintf("%020d%s", time.Now().UnixNano(), SpoolSegmentExt))
+		if sp.f, err = os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+			sp.f = nil
+			return
+		}
+		sp.size = 0
+	}
+
+	n, err = sp.f.Write(b)
+	sp.size += int64(n)
+	if err != nil {
+		return
+	}
+
+	if err = sp.f.Sync(); err != nil {
+		return
+	}
+
+	if sp.size >= SpoolSegmentSize {
+		sp.rotate()
+	}
+	return
+}
+
+func (sp *Spool) rotate() {
+	if sp.f != nil {
+		if err := sp.f.Close(); err != nil {
+			log.Error("Spool close %s failed: %s", sp.f.Name(), err)
+		}
+		sp.f = nil
+	}
+}
+
+// 返回所有段文件（按写入先后排序），当前正在写的段会被关闭以便读取
+func (sp *Spool) Segments() (names []string, err error) {
+	sp.mux.Lock()
+	sp.rotate()
+	sp.mux.Unlock()
+
+	if names, err = filepath.Glob(filepath.Join(sp.dir, "*"+SpoolSegmentExt)); err != nil {
+		return
+	}
+	sort.Strings(names)
+	return
+}
+
+// 逐行读取段文件，无法解析的行（例如崩溃时写了一半）会被跳过
+func (sp *Spool) Read(name string, fn func(line []byte) error) (err error) {
+	var (
+		f *os.File
+	)
+
+	if f, err = os.Open(name); err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), SpoolSegmentSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if !json.Valid(line) {
+			log.Warn("Spool %s skip bad line: %q", name, line)
+			continue
+		}
+		if err = fn(line); err != nil {
+			return
+		}
+	}
+	return scanner.Err()
+}
+
+func (sp *Spool) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (sp *Spool) Bytes() (n int64) {
+	names, _ := filepath.Glob(filepath.Join(sp.dir, "*"+SpoolSegmentExt))
+	for _, name := range names {
+		if fi, err := os.Stat(name); err == nil {
+			n += fi.Size()
+		}
+	}
+	return
+}
+
+func (sp *Spool) Close() {
+	sp.mux.Lock()
+	sp.rotate()
+	sp.mux.Unlock()
+}
+
+const (
+	SpoolQueueSize           = 20480
+	SpoolQueueBatchSize      = 256
+	SpoolQueueFlushInterval  = 1 * time.Second
+	SpoolQueueReplayInterval = 10 * time.Second
+	SpoolQueueMaxRetries     = 3
+	SpoolQueueRetryBackoff   = 100 * time.Millisecond
+)
+
+type SpoolQueueMetrics struct {
+	QueueDepth int   `json:"queue_depth"`
+	QueueCap   int   `json:"queue_cap"`
+	Enqueued   int64 `json:"enqueued"`
+	Applied    int64 `json:"applied"`
+	Retried    int64 `json:"retried"`
+	Spilled    int64 `json:"spilled"`
+	Replayed   int64 `json:"replayed"`
+	Dropped    int64 `json:"dropped"`
+	SpoolBytes int64 `json:"spool_bytes"`
+}
+
+// SpoolQueue异步批量写入一种记录，做法与StatisticsManager相同：写入失败时有限次重试，
+// 仍然失败的批次写入磁盘队列并定时回放；队列满时不丢弃，交给loop写入磁盘队列。
+// 记录没有批次id，部分写入成功的批次只回放剩下的部分，存储本身部分成功时可能重复。
+type SpoolQueue struct {
+	name string
+	// 按顺序写入items，返回从头开始写入成功的数量
+	apply  func(items []interface{}) (n int, err error)
+	decode func(b []byte) (interface{}, error)
+	q      chan interface{}
+	spool  *Spool
+	mux    sync.RWMutex
+	closed bool
+	done   chan struct{}
+	flushQ chan chan struct{}
+	// 队列满时暂存的记录，由loop整批写入磁盘队列
+	overflowMux sync.Mutex
+	overflow    []interface{}
+	overflowC   chan struct{}
+
+	enqueued int64
+	applied  int64
+	retried  int64
+	spilled  int64
+	replayed int64
+	dropped  int64
+}
+
+func NewSpoolQueue(name, dir string, apply func(items []interface{}) (int, error), decode func(b []byte) (interface{}, error)) (sq *SpoolQueue, err error) {
+	sq = &SpoolQueue{}
+	sq.name = name
+	sq.apply = apply
+	sq.decode = decode
+	sq.q = make(chan interface{}, SpoolQueueSize)
+	sq.done = make(chan struct{})
+	sq.flushQ = make(chan chan struct{})
+	sq.overflowC = make(chan struct{}, 1)
+	if sq.spool, err = NewSpool(dir); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (sq *SpoolQueue) Start() {
+	go sq.loop()
+}
+
+func (sq *SpoolQueue) loop() {
+	defer close(sq.done)
+
+	flushTicker := time.NewTicker(SpoolQueueFlushInterval)
+	defer flushTicker.Stop()
+
+	replayTicker := time.NewTicker(SpoolQueueReplayInterval)
+	defer replayTicker.Stop()
+
+	// 启动时先回放上次未写入的记录
+	sq.replay()
+
+	batch := make([]interface{}, 0, SpoolQueueBatchSize)
+	for {
+		select {
+		case item, ok := <-sq.q:
+			if !ok {
+				sq.flush(batch)
+				sq.spillOverflow()
+				sq.spool.Close()
+				return
+			}
+			batch = append(batch, item)
+			if len(batch) >= SpoolQueueBatchSize {
+				sq.flush(batch)
+				batch = make([]interface{}, 0, SpoolQueueBatchSize)
+			}
+		case <-flushTicker.C:
+			if len(batch) > 0 {
+				sq.flush(batch)
+				batch = make([]interface{}, 0, SpoolQueueBatchSize)
+			}
+		case <-sq.overflowC:
+			sq.spillOverflow()
+		case <-replayTicker.C:
+			sq.replay()
+		case done := <-sq.flushQ:
+			for n := len(sq.q); n > 0; n-- {
+				batch = append(batch, <-sq.q)
+			}
+			sq.flush(batch)
+			batch = make([]interface{}, 0, SpoolQueueBatchSize)
+			sq.replay()
+			close(done)
+		}
+	}
+}
+
+func (sq *SpoolQueue) flush(items []interface{}) {
+	if len(items) == 0 {
+		return
+	}
+
+	backoff := SpoolQueueRetryBackoff
+	for i := 0; i < SpoolQueueMaxRetries; i++ {
+		if i > 0 {
+			atomic.AddInt64(&sq.retried, 1)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		n, err := sq.apply(items)
+		atomic.AddInt64(&sq.applied, int64(n))
+		if items = items[n:]; err == nil || len(items) == 0 {
+			return
+		}
+		log.Warn("Apply %d %s failed (%d/%d): %s", len(items), sq.name, i+1, SpoolQueueMaxRetries, err)
+	}
+
+	sq.spill(items)
+}
+
+func (sq *SpoolQueue) spill(items []interface{}) {
+	if err := sq.spool.Append(items); err != nil {
+		atomic.AddInt64(&sq.dropped, int64(len(items)))
+		log.Error("Spill %d %s failed: %s, lost", len(items), sq.name, err)
+		return
+	}
+	atomic.AddInt64(&sq.spilled, int64(len(items)))
+}
+
+func (sq *SpoolQueue) spillOverflow() {
+	sq.overflowMux.Lock()
+	items := sq.overflow
+	sq.overflow = nil
+	sq.overflowMux.Unlock()
+
+	if len(items) > 0 {
+		sq.spill(items)
+	}
+}
+
+// 磁盘队列中每行是一批记录
+func (sq *SpoolQueue) decodeLine(line []byte) (items []interface{}, err error) {
+	var (
+		raws []json.RawMessage
+		item interface{}
+	)
+
+	if err = json.Unmarshal(line, &raws); err != nil {
+		return
+	}
+	for _, raw := range raws {
+		if item, err = sq.decode(raw); err != nil {
+			return
+		}
+		items = append(items, item)
+	}
+	return
+}
+
+func (sq *SpoolQueue) replay() {
+	names, err := sq.spool.Segments()
+	if err != nil {
+		log.Error("Spool %s segments failed: %s", sq.name, err)
+		return
+	}
+
+	for _, name := range names {
+		if err = sq.replaySegment(name); err != nil {
+			log.Warn("Replay %s spool %s failed: %s", sq.name, name, err)
+			return
+		}
+	}
+}
+
+// 写入失败时，已经写入的部分不再回放，剩下的记录写到新的段
+func (sq *SpoolQueue) replaySegment(name string) (err error) {
+	var (
+		lines [][]byte
+		items []interface{}
+		n     int
+		total int64
+	)
+
+	if err = sq.spool.Read(name, func(line []byte) error {
+		lines = append(lines, append([]byte(nil), line...))
+		return nil
+	}); err != nil {
+		return
+	}
+
+	for i, line := range lines {
+		if items, err = sq.decodeLine(line); err != nil {
+			log.Warn("Spool %s skip bad batch: %s", name, err)
+			continue
+		}
+
+		n, err = sq.apply(items)
+		total += int64(n)
+		if err == nil {
+			continue
+		}
+		if total == 0 {
+			return
+		}
+
+		if n < len(items) {
+			if err = sq.spool.Append(items[n:]); err != nil {
+				return
+			}
+		}
+		for _, rest := range lines[i+1:] {
+			if err = sq.spool.Append(json.RawMessage(rest)); err != nil {
+				return
+			}
+		}
+		break
+	}
+
+	if err = sq.spool.Remove(name); err != nil {
+		return
+	}
+
+	atomic.AddInt64(&sq.replayed, total)
+	atomic.AddInt64(&sq.applied, total)
+	log.Info("Replay %s spool %s: %d written", sq.name, name, total)
+	return
+}
+
+// 在结算中调用，不能等待磁盘
+func (sq *SpoolQueue) Push(item interface{}) {
+	sq.mux.RLock()
+	if !sq.closed {
+		select {
+		case sq.q <- item:
+			atomic.AddInt64(&sq.enqueued, 1)
+		default:
+			log.Warn("Push %s: the queue is full, spill to disk", sq.name)
+			sq.overflowMux.Lock()
+			sq.overflow = append(sq.overflow, item)
+			sq.overflowMux.Unlock()
+			select {
+			case sq.overflowC <- struct{}{}:
+			default:
+			}
+		}
+		sq.mux.RUnlock()
+		return
+	}
+	sq.mux.RUnlock()
+
+	// 已经关闭，loop不再运行
+	sq.spill([]interface{}{item})
+}
+
+// 立即写入队列中的记录并回放磁盘队列，已经关闭时直接返回
+func (sq *SpoolQueue) Flush() {
+	done := make(chan struct{})
+	select {
+	case sq.flushQ <- done:
+		<-done
+	case <-sq.done:
+	}
+}
+
+// 停止接收新记录，把队列中剩余的记录写入存储或磁盘队列
+func (sq *SpoolQueue) Close() {
+	sq.mux.Lock()
+	if !sq.closed {
+		sq.closed = true
+		close(sq.q)
+	}
+	sq.mux.Unlock()
+	<-sq.done
+}
+
+func (sq *SpoolQueue) Metrics() *SpoolQueueMetrics {
+	return &SpoolQueueMetrics{
+		QueueDepth: len(sq.q),
+		QueueCap:   cap(sq.q),
+		Enqueued:   atomic.LoadInt64(&sq.enqueued),
+		Applied:    atomic.LoadInt64(&sq.applied),
+		Retried:    atomic.LoadInt64(&sq.retried),
+		Spilled:    atomic.LoadInt64(&sq.spilled),
+		Replayed:   atomic.LoadInt64(&sq.replayed),
+		Dropped:    atomic.LoadInt64(&sq.dropped),
+		SpoolBytes: sq.spool.Bytes(),
+	}
+}
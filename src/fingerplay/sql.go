@@ -0,0 +1,34 @@
+package main
+
+import (
+	"database/sql"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "modernc.org/sqlite"
+)
+
+type SqlManager struct {
+	driver string
+	db     *sql.DB
+}
+
+// driver为sqlite或mysql，dsn为sqlite文件路径或mysql连接串
+func NewSqlManager(driver, dsn string) (sqm *SqlManager, err error) {
+	sqm = &SqlManager{}
+	sqm.driver = driver
+
+	if sqm.db, err = sql.Open(driver, dsn); err != nil {
+		return nil, err
+	}
+
+	// sqlite同一时间只允许一个写连接
+	if driver == StoreDriverSqlite {
+		sqm.db.SetMaxOpenConns(1)
+	}
+
+	return
+}
+
+func (sqm *SqlManager) GetSession() (db *sql.DB, err error) {
+	return sqm.db, nil
+}
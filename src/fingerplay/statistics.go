@@ -1,105 +1,337 @@
 package main
 
 import (
-	"gopkg.in/mgo.v2"
-	"gopkg.in/mgo.v2/bson"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	log "code.google.com/p/log4go"
 )
 
 const (
 	RankingLimit = 10
+
+	StatisticsQueueSize      = 20480
+	StatisticsBatchSize      = 256
+	StatisticsFlushInterval  = 1 * time.Second
+	StatisticsReplayInterval = 10 * time.Second
+	StatisticsMaxRetries     = 3
+	StatisticsRetryBackoff   = 100 * time.Millisecond
 )
 
 type ResultLog struct {
 	Uid         int     `bson:"uid" json:"-" toml:"-"`
+	Level       int     `bson:"-" json:"-" toml:"-"`
 	Avatar      string  `bson:"avatar" json:"avatar" toml:"avatar"`
 	WinAmount   float64 `bson:"win_amount" json:"win_amount" toml:"win_amount"`
 	Nickname    string  `bson:"nickname" json:"nickname" toml:"nickname"`
 	TimeUpdated int64   `bson:"time_updated" json:"time_updated" toml:"time_updated"`
 }
 
+// 写入磁盘队列的批次，Id在重试和回放时保持不变
+type ResultBatch struct {
+	Id      string           `json:"id"`
+	Results []*SpooledResult `json:"results"`
+}
+
+type SpooledResult struct {
+	Uid         int     `json:"uid"`
+	Level       int     `json:"level"`
+	Avatar      string  `json:"avatar"`
+	WinAmount   float64 `json:"win_amount"`
+	Nickname    string  `json:"nickname"`
+	TimeUpdated int64   `json:"time_updated"`
+}
+
+func NewResultBatch(results []*ResultLog) *ResultBatch {
+	batch := &ResultBatch{}
+	batch.Id = GetGUID()
+	for _, r := range results {
+		batch.Results = append(batch.Results, &SpooledResult{
+			Uid:         r.Uid,
+			Level:       r.Level,
+			Avatar:      r.Avatar,
+			WinAmount:   r.WinAmount,
+			Nickname:    r.Nickname,
+			TimeUpdated: r.TimeUpdated,
+		})
+	}
+	return batch
+}
+
+type StatisticsMetrics struct {
+	QueueDepth int   `json:"queue_depth"`
+	QueueCap   int   `json:"queue_cap"`
+	Enqueued   int64 `json:"enqueued"`
+	Applied    int64 `json:"applied"`
+	Retried    int64 `json:"retried"`
+	Spilled    int64 `json:"spilled"`
+	Replayed   int64 `json:"replayed"`
+	Dropped    int64 `json:"dropped"`
+	SpoolBytes int64 `json:"spool_bytes"`
+}
+
 type StatisticsManager struct {
-	ctx *Context
-	q   chan *ResultLog
+	store  RankingStore
+	clock  Clock
+	q      chan *ResultLog
+	lb     *Leaderboard
+	spool  *Spool
+	mux    sync.RWMutex
+	closed bool
+	done   chan struct{}
+	// 手动回放的请求，在loop中执行，避免和定时回放并发
+	replayQ chan chan struct{}
+	// 队列满时暂存的结果，由loop整批写入磁盘队列，结算时不等待磁盘
+	overflowMux sync.Mutex
+	overflow    []*ResultLog
+	overflowC   chan struct{}
+
+	enqueued int64
+	applied  int64
+	retried  int64
+	spilled  int64
+	replayed int64
+	dropped  int64
 }
 
-func NewStatisticsManager(ctx *Context) *StatisticsManager {
+func NewStatisticsManager(store RankingStore, spoolDir string) *StatisticsManager {
+	var (
+		err error
+	)
+
 	sm := &StatisticsManager{}
-	sm.ctx = ctx
-	sm.q = make(chan *ResultLog, 20480)
-	go sm.loop()
+	sm.store = store
+	sm.clock = DefaultClock
+	sm.lb = NewLeaderboard(store)
+	sm.q = make(chan *ResultLog, StatisticsQueueSize)
+	sm.done = make(chan struct{})
+	sm.replayQ = make(chan chan struct{})
+	sm.overflowC = make(chan struct{}, 1)
+	if sm.spool, err = NewSpool(spoolDir); err != nil {
+		panic(err)
+	}
 	return sm
 }
 
+// 开始写入结果和定时归档榜单，时钟在这之前设置
+func (sm *StatisticsManager) Start() {
+	go sm.loop()
+	sm.lb.Start()
+}
+
 func (sm *StatisticsManager) loop() {
-	session, err := sm.ctx.GetMongoSession()
-	if err != nil {
-		panic(err)
-	}
-	if session == nil {
-		panic("mongodb not connected")
-	}
-	defer session.Close()
+	defer close(sm.done)
 
-	db := Conf.MongoDb
-	co := "ranking"
-	for result := range sm.q {
-		condition := bson.M{
-			"uid": result.Uid,
-		}
+	flushTicker := time.NewTicker(StatisticsFlushInterval)
+	defer flushTicker.Stop()
 
-		_result := &ResultLog{}
-		if err := session.DB(db).C(co).Find(condition).One(_result); err != nil {
-			if mgo.ErrNotFound == err {
-				if err = session.DB(db).C(co).Insert(result); err != nil {
-					log.Error("Insert failed: %#v, error: %s", result, err)
-				}
-			} else {
-				log.Error("Find failed: %s, error: %s", result.Uid, err)
+	replayTicker := time.NewTicker(StatisticsReplayInterval)
+	defer replayTicker.Stop()
+
+	// 启动时先回放上次未写入的结果
+	sm.replay()
+
+	batch := make([]*ResultLog, 0, StatisticsBatchSize)
+	for {
+		select {
+		case result, ok := <-sm.q:
+			if !ok {
+				sm.flush(batch)
+				sm.spillOverflow()
+				sm.spool.Close()
+				return
+			}
+			batch = append(batch, result)
+			if len(batch) >= StatisticsBatchSize {
+				sm.flush(batch)
+				batch = make([]*ResultLog, 0, StatisticsBatchSize)
+			}
+		case <-flushTicker.C:
+			if len(batch) > 0 {
+				sm.flush(batch)
+				batch = make([]*ResultLog, 0, StatisticsBatchSize)
 			}
-		} else {
-			if err = session.DB(db).C(co).Update(condition, bson.M{"$set": bson.M{
-				"win_amount":   _result.WinAmount + result.WinAmount,
-				"time_updated": result.TimeUpdated,
-			}}); err != nil {
-				log.Error("Update failed: %s, error: %s", result.Uid, err)
+		case <-sm.overflowC:
+			sm.spillOverflow()
+		case <-replayTicker.C:
+			sm.replay()
+		case done := <-sm.replayQ:
+			// 连同队列中的结果一起写入
+			for n := len(sm.q); n > 0; n-- {
+				batch = append(batch, <-sm.q)
 			}
+			sm.flush(batch)
+			batch = make([]*ResultLog, 0, StatisticsBatchSize)
+			sm.replay()
+			close(done)
 		}
 	}
 }
 
-func (sm *StatisticsManager) Ranking() (results []*ResultLog, err error) {
-	session, err := sm.ctx.GetMongoSession()
+// 有限次重试后仍然失败的批次写入磁盘队列，等待回放
+func (sm *StatisticsManager) flush(results []*ResultLog) {
+	if len(results) == 0 {
+		return
+	}
+
+	batch := NewResultBatch(results)
+
+	var (
+		err error
+	)
+
+	backoff := StatisticsRetryBackoff
+	for i := 0; i < StatisticsMaxRetries; i++ {
+		if i > 0 {
+			atomic.AddInt64(&sm.retried, 1)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if err = sm.apply(batch); err == nil {
+			atomic.AddInt64(&sm.applied, int64(len(batch.Results)))
+			return
+		}
+		log.Warn("Apply result batch %s failed (%d/%d): %s", batch.Id, i+1, StatisticsMaxRetries, err)
+	}
+
+	sm.spill(batch)
+}
+
+func (sm *StatisticsManager) spill(batch *ResultBatch) {
+	if err := sm.spool.Append(batch); err != nil {
+		atomic.AddInt64(&sm.dropped, int64(len(batch.Results)))
+		log.Error("Spill result batch %s failed: %s, %d results lost", batch.Id, err, len(batch.Results))
+		return
+	}
+	atomic.AddInt64(&sm.spilled, int64(len(batch.Results)))
+}
+
+func (sm *StatisticsManager) spillOverflow() {
+	sm.overflowMux.Lock()
+	results := sm.overflow
+	sm.overflow = nil
+	sm.overflowMux.Unlock()
+
+	if len(results) > 0 {
+		sm.spill(NewResultBatch(results))
+	}
+}
+
+func (sm *StatisticsManager) replay() {
+	names, err := sm.spool.Segments()
 	if err != nil {
-		panic(err)
+		log.Error("Spool segments failed: %s", err)
+		return
 	}
-	if session == nil {
-		panic("mongodb not connected")
+
+	for _, name := range names {
+		n := int64(0)
+		err = sm.spool.Read(name, func(line []byte) (err error) {
+			batch := &ResultBatch{}
+			if err = json.Unmarshal(line, batch); err != nil {
+				log.Warn("Spool %s skip bad batch: %s", name, err)
+				return nil
+			}
+			if err = sm.apply(batch); err != nil {
+				return
+			}
+			n += int64(len(batch.Results))
+			return
+		})
+
+		// 段内已应用的批次在下次回放时会按批次id去重
+		if err != nil {
+			log.Warn("Replay spool %s failed: %s", name, err)
+			return
+		}
+
+		if err = sm.spool.Remove(name); err != nil {
+			log.Error("Remove spool %s failed: %s", name, err)
+			return
+		}
+
+		atomic.AddInt64(&sm.replayed, n)
+		atomic.AddInt64(&sm.applied, n)
+		log.Info("Replay spool %s succeed: %d results", name, n)
 	}
-	defer session.Close()
+}
 
-	db := Conf.MongoDb
-	co := "ranking"
+// 立即写入队列中的结果并回放磁盘队列，已经关闭时直接返回
+func (sm *StatisticsManager) Replay() {
+	done := make(chan struct{})
+	select {
+	case sm.replayQ <- done:
+		<-done
+	case <-sm.done:
+	}
+}
 
-	results = []*ResultLog{}
+// 与榜单归档互斥，避免结果写入正在归档的周期
+func (sm *StatisticsManager) apply(batch *ResultBatch) error {
+	sm.lb.mux.Lock()
+	defer sm.lb.mux.Unlock()
+	return sm.store.ApplyResults(batch, sm.clock.Now())
+}
 
-	if err = session.DB(db).C(co).Find(bson.M{"win_amount": bson.M{"$gt": 0}}).Sort("-win_amount").Limit(RankingLimit).All(&results); err != nil {
+func (sm *StatisticsManager) Ranking() (results []*ResultLog, err error) {
+	if results, err = sm.store.Ranking(RankingLimit); err != nil {
 		log.Error("Find ranking failed: %s", err)
 	}
-
 	return
 }
 
+func (sm *StatisticsManager) Leaderboard() *Leaderboard { return sm.lb }
+
+// 在结算中调用，不能等待磁盘。队列满时不再丢弃，交给loop写入磁盘队列
 func (sm *StatisticsManager) OnResult(result *ResultLog) (err error) {
-	select {
-	case sm.q <- result:
-	default:
-		log.Error("OnResult(%#v) failed: the queue is full", result)
+	sm.mux.RLock()
+	if !sm.closed {
+		select {
+		case sm.q <- result:
+			atomic.AddInt64(&sm.enqueued, 1)
+		default:
+			log.Warn("OnResult(%#v): the queue is full, spill to disk", result)
+			sm.overflowMux.Lock()
+			sm.overflow = append(sm.overflow, result)
+			sm.overflowMux.Unlock()
+			select {
+			case sm.overflowC <- struct{}{}:
+			default:
+			}
+		}
+		sm.mux.RUnlock()
+		return
 	}
+	sm.mux.RUnlock()
+
+	// 已经关闭，loop不再运行
+	sm.spill(NewResultBatch([]*ResultLog{result}))
 	return
 }
 
-var (
-	DefaultStatisticsManager *StatisticsManager
-)
+// 停止接收新结果，把队列中剩余的结果写入mongo或磁盘队列
+func (sm *StatisticsManager) Close() {
+	sm.mux.Lock()
+	if !sm.closed {
+		sm.closed = true
+		close(sm.q)
+	}
+	sm.mux.Unlock()
+	<-sm.done
+}
+
+func (sm *StatisticsManager) Metrics() *StatisticsMetrics {
+	return &StatisticsMetrics{
+		QueueDepth: len(sm.q),
+		QueueCap:   cap(sm.q),
+		Enqueued:   atomic.LoadInt64(&sm.enqueued),
+		Applied:    atomic.LoadInt64(&sm.applied),
+		Retried:    atomic.LoadInt64(&sm.retried),
+		Spilled:    atomic.LoadInt64(&sm.spilled),
+		Replayed:   atomic.LoadInt64(&sm.replayed),
+		Dropped:    atomic.LoadInt64(&sm.dropped),
+		SpoolBytes: sm.spool.Bytes(),
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 第一次ApplyResults阻塞到unblock关闭，模拟存储卡住
+type blockingRankingStore struct {
+	*MemoryStore
+	unblock chan struct{}
+}
+
+func (s *blockingRankingStore) ApplyResults(batch *ResultBatch, now time.Time) error {
+	<-s.unblock
+	return s.MemoryStore.ApplyResults(batch, now)
+}
+
+func TestStatisticsOverflowDoesNotBlock(t *testing.T) {
+	store := &blockingRankingStore{MemoryStore: NewMemoryStore(), unblock: make(chan struct{})}
+	sm := NewStatisticsManager(store, t.TempDir())
+	sm.Start()
+
+	total := StatisticsQueueSize + StatisticsBatchSize + 100
+	begin := time.Now()
+	for i := 0; i < total; i++ {
+		sm.OnResult(&ResultLog{Uid: 100000 + i%10, Level: 100, WinAmount: 1, TimeUpdated: begin.Unix()})
+	}
+	// 溢出的结果只放入内存，不等待存储和磁盘
+	if d := time.Since(begin); d > time.Second {
+		t.Errorf("OnResult took %s with a stuck store", d)
+	}
+
+	close(store.unblock)
+	sm.Close()
+
+	m := sm.Metrics()
+	if m.Spilled == 0 {
+		t.Errorf("spilled = 0, want the overflow on disk")
+	}
+	if m.Applied+m.Spilled != int64(total) || m.Dropped != 0 {
+		t.Errorf("applied %d + spilled %d != %d, dropped %d", m.Applied, m.Spilled, total, m.Dropped)
+	}
+	if n := atomic.LoadInt64(&sm.enqueued); n+m.Spilled != int64(total) {
+		t.Errorf("enqueued %d + spilled %d != %d", n, m.Spilled, total)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"time"
+)
+
+const (
+	StoreDriverMongo  = "mongo"
+	StoreDriverMemory = "memory"
+	StoreDriverSqlite = "sqlite"
+	StoreDriverMysql  = "mysql"
+)
+
+// 排行榜和分时段榜单
+type RankingStore interface {
+	// 按批次id去重，同一批次重复应用不会重复计数
+	ApplyResults(batch *ResultBatch, now time.Time) error
+	Ranking(limit int) ([]*ResultLog, error)
+	LeaderboardTop(window, period string, level, offset, limit int) (entries []*LeaderboardEntry, total int, err error)
+	LeaderboardRank(window, period string, level, uid, n int) (me *LeaderboardEntry, above, below []*LeaderboardEntry, err error)
+	// 没有归档时返回nil, nil
+	LeaderboardArchive(window, period string, level int) (*LeaderboardArchive, error)
+	LeaderboardPeriods(window string) ([]string, error)
+	// 归档window/period下所有等级的榜单，并删除实时数据
+	ArchiveLeaderboard(window, period string, now time.Time) error
+	// 用entries替换window/period下所有等级的实时榜单，用于从转账流水重建
+	ReplaceLeaderboard(window, period string, entries []*LeaderboardEntry) error
+}
+
+type PlayerStatsStore interface {
+	ApplyRound(rl *RoundLog) error
+	// 没有记录时返回只有uid的空数据
+	PlayerStats(uid int) (*PlayerStats, error)
+}
+
+type RiskStore interface {
+	BonusPool() (float64, error)
+	// 原子增减奖池，返回新的奖池金额
+	AddBonusPool(delta float64) (float64, error)
+}
+
+type MatchHistoryStore interface {
+	AppendRounds(rounds []*RoundLog) error
+	// 按时间倒序
+	Rounds(uid, offset, limit int) ([]*RoundLog, error)
+}
+
+type LedgerStore interface {
+	AppendLedger(entries []*LedgerEntry) error
+	// 按时间倒序
+	Ledger(uid, offset, limit int) ([]*LedgerEntry, error)
+	// 所有玩家ts < until的流水，按写入顺序
+	LedgerUntil(until int64, offset, limit int) ([]*LedgerEntry, error)
+}
+
+type Store interface {
+	RankingStore
+	PlayerStatsStore
+	RiskStore
+	MatchHistoryStore
+	LedgerStore
+	Ping() error
+	Close()
+}
+
+// 根据配置选择存储实现，所需的连接会注册到ctx上
+func NewStore(conf *Config, ctx *Context) (store Store, err error) {
+	driver, dsn := conf.StoreDriver, conf.StoreDsn
+	switch driver {
+	case "", StoreDriverMongo:
+		mongoConfig := MongoConfig{}
+		mongoConfig.serverAddr = conf.MongoServerAddrs
+		ctx.mgm = NewMongoManager(mongoConfig)
+		return NewMongoStore(ctx, conf.MongoDb), nil
+	case StoreDriverMemory:
+		return NewMemoryStore(), nil
+	case StoreDriverSqlite, StoreDriverMysql:
+		if ctx.sqm, err = NewSqlManager(driver, dsn); err != nil {
+			return
+		}
+		return NewSqlStore(ctx, driver)
+	default:
+		return nil, ErrStoreDriver
+	}
+}
+
+type rankingTotal struct {
+	Uid         int
+	WinAmount   float64
+	Avatar      string
+	Nickname    string
+	TimeUpdated int64
+}
+
+type leaderboardKey struct {
+	Window string
+	Period string
+	Level  int
+	Uid    int
+}
+
+type leaderboardTotal struct {
+	Key leaderboardKey
+	rankingTotal
+}
+
+// 同一批次内按uid合并
+func (batch *ResultBatch) rankingTotals() (totals []*rankingTotal) {
+	m := make(map[int]*rankingTotal)
+	for _, r := range batch.Results {
+		t := m[r.Uid]
+		if t == nil {
+			t = &rankingTotal{Uid: r.Uid}
+			m[r.Uid] = t
+			totals = append(totals, t)
+		}
+		t.add(r)
+	}
+	return
+}
+
+// 每个结果会同时计入所在等级和全部等级(level=0)的各个周期榜单。
+// 周期按比赛结束的时间计算，从磁盘队列回放的结果也计入原来的周期；
+// 原来的周期已经归档时计入当前周期，保证已归档的周期不会再被写入。
+func (batch *ResultBatch) leaderboardTotals(now time.Time) (totals []*leaderboardTotal) {
+	m := make(map[leaderboardKey]*leaderboardTotal)
+	for _, r := range batch.Results {
+		for _, window := range LeaderboardWindows {
+			period := getLeaderboardResultPeriod(window, r.TimeUpdated, now)
+			for _, level := range []int{r.Level, 0} {
+				k := leaderboardKey{window, period, level, r.Uid}
+				t := m[k]
+				if t == nil {
+					t = &leaderboardTotal{Key: k}
+					t.Uid = r.Uid
+					m[k] = t
+					totals = append(totals, t)
+				}
+				t.add(r)
+			}
+		}
+	}
+	return
+}
+
+func (t *rankingTotal) add(r *SpooledResult) {
+	t.WinAmount += r.WinAmount
+	t.Avatar = r.Avatar
+	t.Nickname = r.Nickname
+	if r.TimeUpdated > t.TimeUpdated {
+		t.TimeUpdated = r.TimeUpdated
+	}
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore把所有数据保存在进程内存中，用于测试和离线开发，重启后数据丢失
+type MemoryStore struct {
+	mux         sync.RWMutex
+	batches     map[string]bool
+	ranking     map[int]*ResultLog
+	leaderboard map[leaderboardKey]*LeaderboardEntry
+	archives    map[leaderboardKey]*LeaderboardArchive
+	stats       map[int]*PlayerStats
+	bonusPool   float64
+	rounds      []*RoundLog
+	ledger      []*LedgerEntry
+}
+
+func NewMemoryStore() *MemoryStore {
+	ms := &MemoryStore{}
+	ms.batches = make(map[string]bool)
+	ms.ranking = make(map[int]*ResultLog)
+	ms.leaderboard = make(map[leaderboardKey]*LeaderboardEntry)
+	ms.archives = make(map[leaderboardKey]*LeaderboardArchive)
+	ms.stats = make(map[int]*PlayerStats)
+	return ms
+}
+
+func (ms *MemoryStore) Ping() error { return nil }
+
+func (ms *MemoryStore) Close() {}
+
+func (ms *MemoryStore) ApplyResults(batch *ResultBatch, now time.Time) (err error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+
+	if ms.batches[batch.Id] {
+		return
+	}
+	ms.batches[batch.Id] = true
+
+	for _, t := range batch.rankingTotals() {
+		r := ms.ranking[t.Uid]
+		if r == nil {
+			r = &ResultLog{Uid: t.Uid}
+			ms.ranking[t.Uid] = r
+		}
+		r.WinAmount += t.WinAmount
+		r.Avatar = t.Avatar
+		r.Nickname = t.Nickname
+		if t.TimeUpdated > r.TimeUpdated {
+			r.TimeUpdated = t.TimeUpdated
+		}
+	}
+
+	for _, t := range batch.leaderboardTotals(now) {
+		e := ms.leaderboard[t.Key]
+		if e == nil {
+			e = &LeaderboardEntry{Window: t.Key.Window, Period: t.Key.Period, Level: t.Key.Level, Uid: t.Key.Uid}
+			ms.leaderboard[t.Key] = e
+		}
+		e.WinAmount += t.WinAmount
+		e.Avatar = t.Avatar
+		e.Nickname = t.Nickname
+		if t.TimeUpdated > e.TimeUpdated {
+			e.TimeUpdated = t.TimeUpdated
+		}
+	}
+	return
+}
+
+func (ms *MemoryStore) Ranking(limit int) (results []*ResultLog, err error) {
+	ms.mux.RLock()
+	defer ms.mux.RUnlock()
+
+	results = []*ResultLog{}
+	for _, r := range ms.ranking {
+		if r.WinAmount > 0 {
+			_r := *r
+			results = append(results, &_r)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].WinAmount > results[j].WinAmount })
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return
+}
+
+// 返回排好序的榜单副本，排序规则与mongo一致: win_amount降序，相同时uid升序
+func (ms *MemoryStore) board(window, period string, level int) (entries []*LeaderboardEntry) {
+	entries = []*LeaderboardEntry{}
+	for k, e := range ms.leaderboard {
+		if k.Window == window && k.Period == period && k.Level == level {
+			_e := *e
+			entries = append(entries, &_e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].WinAmount != entries[j].WinAmount {
+			return entries[i].WinAmount > entries[j].WinAmount
+		}
+		return entries[i].Uid < entries[j].Uid
+	})
+	for i, e := range entries {
+		e.Rank = i + 1
+	}
+	return
+}
+
+func (ms *MemoryStore) LeaderboardTop(window, period string, level, offset, limit int) (entries []*LeaderboardEntry, total int, err error) {
+	ms.mux.RLock()
+	defer ms.mux.RUnlock()
+
+	entries = ms.board(window, period, level)
+	total = len(entries)
+	entries = pageLeaderboard(entries, offset, limit)
+	return
+}
+
+func (ms *MemoryStore) LeaderboardRank(window, period string, level, uid, n int) (me *LeaderboardEntry, above, below []*LeaderboardEntry, err error) {
+	ms.mux.RLock()
+	defer ms.mux.RUnlock()
+
+	entries := ms.board(window, period, level)
+	for i, e := range entries {
+		if e.Uid != uid {
+			continue
+		}
+		me = e
+		if n > 0 {
+			from := i - n
+			if from < 0 {
+				from = 0
+			}
+			to := i + 1 + n
+			if to > len(entries) {
+				to = len(entries)
+			}
+			above = entries[from:i]
+			below = entries[i+1 : to]
+		}
+		break
+	}
+	return
+}
+
+func (ms *MemoryStore) LeaderboardArchive(window, period string, level int) (archive *LeaderboardArchive, err error) {
+	ms.mux.RLock()
+	defer ms.mux.RUnlock()
+
+	return ms.archives[leaderboardKey{Window: window, Period: period, Level: level}], nil
+}
+
+func (ms *MemoryStore) LeaderboardPeriods(window string) (periods []string, err error) {
+	ms.mux.RLock()
+	defer ms.mux.RUnlock()
+
+	seen := make(map[string]bool)
+	for k := range ms.leaderboard {
+		if k.Window == window && !seen[k.Period] {
+			seen[k.Period] = true
+			periods = append(periods, k.Period)
+		}
+	}
+	return
+}
+
+func (ms *MemoryStore) ArchiveLeaderboard(window, period string, now time.Time) (err error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+
+	levels := make(map[int]bool)
+	for k := range ms.leaderboard {
+		if k.Window == window && k.Period == period {
+			levels[k.Level] = true
+		}
+	}
+
+	for level := range levels {
+		entries := ms.board(window, period, level)
+		archive := &LeaderboardArchive{}
+		archive.Window = window
+		archive.Period = period
+		archive.Level = level
+		archive.Total = len(entries)
+		archive.Entries = pageLeaderboard(entries, 0, LeaderboardArchiveLimit)
+		archive.ArchivedAt = now.Unix()
+		ms.archives[leaderboardKey{Window: window, Period: period, Level: level}] = archive
+	}
+
+	for k := range ms.leaderboard {
+		if k.Window == window && k.Period == period {
+			delete(ms.leaderboard, k)
+		}
+	}
+	return
+}
+
+func (ms *MemoryStore) ReplaceLeaderboard(window, period string, entries []*LeaderboardEntry) (err error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+
+	for k := range ms.leaderboard {
+		if k.Window == window && k.Period == period {
+			delete(ms.leaderboard, k)
+		}
+	}
+	for _, e := range entries {
+		_e := *e
+		_e.Window = window
+		_e.Period = period
+		ms.leaderboard[leaderboardKey{Window: window, Period: period, Level: e.Level, Uid: e.Uid}] = &_e
+	}
+	return
+}
+
+func pageLeaderboard(entries []*LeaderboardEntry, offset, limit int) []*LeaderboardEntry {
+	if offset >= len(entries) {
+		return []*LeaderboardEntry{}
+	}
+	end := offset + limit
+	if end > len(entries) {
+		end = len(entries)
+	}
+	return entries[offset:end]
+}
+
+func (ms *MemoryStore) ApplyRound(rl *RoundLog) (err error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+
+	ps := ms.stats[rl.Uid]
+	if ps == nil {
+		ps = &PlayerStats{Uid: rl.Uid}
+		ms.stats[rl.Uid] = ps
+	}
+	ps.apply(rl)
+	return
+}
+
+func (ms *MemoryStore) PlayerStats(uid int) (ps *PlayerStats, err error) {
+	ms.mux.RLock()
+	defer ms.mux.RUnlock()
+
+	ps = &PlayerStats{Uid: uid}
+	if _ps := ms.stats[uid]; _ps != nil {
+		*ps = *_ps
+		ps.LevelNet = make(map[string]float64)
+		for k, v := range _ps.LevelNet {
+			ps.LevelNet[k] = v
+		}
+	}
+	return
+}
+
+func (ms *MemoryStore) BonusPool() (pool float64, err error) {
+	ms.mux.RLock()
+	defer ms.mux.RUnlock()
+	return ms.bonusPool, nil
+}
+
+func (ms *MemoryStore) AddBonusPool(delta float64) (pool float64, err error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	ms.bonusPool += delta
+	return ms.bonusPool, nil
+}
+
+func (ms *MemoryStore) AppendRounds(rounds []*RoundLog) (err error) {
+	ms.mux.Lock()
+	ms.rounds = append(ms.rounds, rounds...)
+	ms.mux.Unlock()
+	return
+}
+
+func (ms *MemoryStore) Rounds(uid, offset, limit int) (rounds []*RoundLog, err error) {
+	ms.mux.RLock()
+	defer ms.mux.RUnlock()
+
+	rounds = []*RoundLog{}
+	for i := len(ms.rounds) - 1; i >= 0 && len(rounds) < limit; i-- {
+		if ms.rounds[i].Uid != uid {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		rounds = append(rounds, ms.rounds[i])
+	}
+	return
+}
+
+func (ms *MemoryStore) AppendLedger(entries []*LedgerEntry) (err error) {
+	ms.mux.Lock()
+	ms.ledger = append(ms.ledger, entries...)
+	ms.mux.Unlock()
+	return
+}
+
+func (ms *MemoryStore) Ledger(uid, offset, limit int) (entries []*LedgerEntry, err error) {
+	ms.mux.RLock()
+	defer ms.mux.RUnlock()
+
+	entries = []*LedgerEntry{}
+	for i := len(ms.ledger) - 1; i >= 0 && len(entries) < limit; i-- {
+		if ms.ledger[i].Uid != uid {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		entries = append(entries, ms.ledger[i])
+	}
+	return
+}
+
+// 按写入顺序返回
+func (ms *MemoryStore) LedgerUntil(until int64, offset, limit int) (entries []*LedgerEntry, err error) {
+	ms.mux.RLock()
+	defer ms.mux.RUnlock()
+
+	entries = []*LedgerEntry{}
+	for _, le := range ms.ledger {
+		if len(entries) >= limit {
+			break
+		}
+		if le.Ts >= until {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		entries = append(entries, le)
+	}
+	return
+}
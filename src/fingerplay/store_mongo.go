@@ -0,0 +1,584 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	log "code.google.com/p/log4go"
+)
+
+const (
+	// 每个文档保留最近应用过的批次id，用于重试和回放时去重
+	MongoBatchHistory = 64
+)
+
+var (
+	RankingCollection            = "ranking"
+	LeaderboardCollection        = "leaderboard"
+	LeaderboardArchiveCollection = "leaderboard_archive"
+	PlayerStatsCollection        = "player_stats"
+	MatchHistoryCollection       = "match_history"
+	LedgerCollection             = "ledger"
+	Collection                   = "risk_control"
+)
+
+type MongoStore struct {
+	ctx *Context
+	db  string
+}
+
+func NewMongoStore(ctx *Context, db string) *MongoStore {
+	ms := &MongoStore{}
+	ms.ctx = ctx
+	ms.db = db
+	go ms.ensureIndexLoop()
+	return ms
+}
+
+func (ms *MongoStore) session() (session *mgo.Session, err error) {
+	if session, err = ms.ctx.GetMongoSession(); err != nil {
+		return
+	}
+	if session == nil {
+		return nil, ErrMongoNotConnected
+	}
+	return
+}
+
+// mongo操作的span，存储接口不带上下文，这里是根span；
+// 奖池的读写由RiskController记录在回合的trace下
+func (ms *MongoStore) span(op string) trace.Span {
+	_, span := startSpan(context.Background(), "mongo."+op, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "mongodb"), attribute.String("db.name", ms.db), attribute.String("db.operation", op)))
+	return span
+}
+
+// mongo可能在启动后才可用，索引建立成功之前一直重试
+func (ms *MongoStore) ensureIndexLoop() {
+	for {
+		if err := ms.ensureIndex(); err != nil {
+			log.Error("MongoStore ensure index failed: %s", err)
+			time.Sleep(10 * time.Second)
+			continue
+		}
+		return
+	}
+}
+
+func (ms *MongoStore) ensureIndex() (err error) {
+	span := ms.span("ensureIndex")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	db := session.DB(ms.db)
+
+	indexes := []struct {
+		co    string
+		index mgo.Index
+	}{
+		{RankingCollection, mgo.Index{Key: []string{"uid"}, Unique: true}},
+		{LeaderboardCollection, mgo.Index{Key: []string{"window", "period", "level", "uid"}, Unique: true}},
+		{LeaderboardCollection, mgo.Index{Key: []string{"window", "period", "level", "-win_amount", "uid"}}},
+		{LeaderboardArchiveCollection, mgo.Index{Key: []string{"window", "period", "level"}, Unique: true}},
+		{PlayerStatsCollection, mgo.Index{Key: []string{"uid"}, Unique: true}},
+		{MatchHistoryCollection, mgo.Index{Key: []string{"uid", "-ts"}}},
+		{LedgerCollection, mgo.Index{Key: []string{"uid", "-ts"}}},
+	}
+
+	for _, i := range indexes {
+		if err = db.C(i.co).EnsureIndex(i.index); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (ms *MongoStore) Ping() (err error) {
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+	return session.Ping()
+}
+
+func (ms *MongoStore) Close() {}
+
+// 已经应用过该批次的文档不会匹配选择条件，upsert会因唯一索引冲突而失败，视为成功。
+func (ms *MongoStore) ApplyResults(batch *ResultBatch, now time.Time) (err error) {
+	span := ms.span("ApplyResults")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	db := session.DB(ms.db)
+
+	bulk := db.C(RankingCollection).Bulk()
+	bulk.Unordered()
+	for _, t := range batch.rankingTotals() {
+		bulk.Upsert(bson.M{
+			"uid":     t.Uid,
+			"batches": bson.M{"$ne": batch.Id},
+		}, ms.resultUpdate(batch, t))
+	}
+
+	if err = runIdempotentBulk(bulk); err != nil {
+		return
+	}
+
+	bulk = db.C(LeaderboardCollection).Bulk()
+	bulk.Unordered()
+	for _, t := range batch.leaderboardTotals(now) {
+		bulk.Upsert(bson.M{
+			"window":  t.Key.Window,
+			"period":  t.Key.Period,
+			"level":   t.Key.Level,
+			"uid":     t.Key.Uid,
+			"batches": bson.M{"$ne": batch.Id},
+		}, ms.resultUpdate(batch, &t.rankingTotal))
+	}
+
+	return runIdempotentBulk(bulk)
+}
+
+func (ms *MongoStore) resultUpdate(batch *ResultBatch, t *rankingTotal) bson.M {
+	return bson.M{
+		"$inc": bson.M{"win_amount": t.WinAmount},
+		"$set": bson.M{"avatar": t.Avatar, "nickname": t.Nickname},
+		"$max": bson.M{"time_updated": t.TimeUpdated},
+		"$push": bson.M{"batches": bson.M{
+			"$each":  []string{batch.Id},
+			"$slice": -MongoBatchHistory,
+		}},
+	}
+}
+
+func runIdempotentBulk(bulk *mgo.Bulk) (err error) {
+	if _, err = bulk.Run(); err == nil {
+		return
+	}
+
+	if e, ok := err.(*mgo.BulkError); ok {
+		for _, c := range e.Cases() {
+			if !mgo.IsDup(c.Err) {
+				return c.Err
+			}
+		}
+		return nil
+	}
+
+	return
+}
+
+func (ms *MongoStore) Ranking(limit int) (results []*ResultLog, err error) {
+	span := ms.span("Ranking")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	results = []*ResultLog{}
+	err = session.DB(ms.db).C(RankingCollection).Find(bson.M{"win_amount": bson.M{"$gt": 0}}).Sort("-win_amount").Limit(limit).All(&results)
+	return
+}
+
+func (ms *MongoStore) LeaderboardTop(window, period string, level, offset, limit int) (entries []*LeaderboardEntry, total int, err error) {
+	span := ms.span("LeaderboardTop")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	entries = []*LeaderboardEntry{}
+
+	query := session.DB(ms.db).C(LeaderboardCollection).Find(bson.M{"window": window, "period": period, "level": level})
+	if total, err = query.Count(); err != nil {
+		return
+	}
+	if err = query.Sort("-win_amount", "uid").Skip(offset).Limit(limit).All(&entries); err != nil {
+		return
+	}
+	for i, e := range entries {
+		e.Rank = offset + i + 1
+	}
+	return
+}
+
+func (ms *MongoStore) LeaderboardRank(window, period string, level, uid, n int) (me *LeaderboardEntry, above, below []*LeaderboardEntry, err error) {
+	span := ms.span("LeaderboardRank")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	co := session.DB(ms.db).C(LeaderboardCollection)
+
+	me = &LeaderboardEntry{}
+	if err = co.Find(bson.M{"window": window, "period": period, "level": level, "uid": uid}).One(me); err != nil {
+		me = nil
+		if err == mgo.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+
+	// 排序规则: win_amount降序，相同时uid升序
+	before := bson.M{"window": window, "period": period, "level": level, "$or": []bson.M{
+		{"win_amount": bson.M{"$gt": me.WinAmount}},
+		{"win_amount": me.WinAmount, "uid": bson.M{"$lt": uid}},
+	}}
+	after := bson.M{"window": window, "period": period, "level": level, "$or": []bson.M{
+		{"win_amount": bson.M{"$lt": me.WinAmount}},
+		{"win_amount": me.WinAmount, "uid": bson.M{"$gt": uid}},
+	}}
+
+	var (
+		count int
+	)
+
+	if count, err = co.Find(before).Count(); err != nil {
+		return
+	}
+	me.Rank = count + 1
+
+	if n <= 0 {
+		return
+	}
+
+	if err = co.Find(before).Sort("win_amount", "-uid").Limit(n).All(&above); err != nil {
+		return
+	}
+	for i, j := 0, len(above)-1; i < j; i, j = i+1, j-1 {
+		above[i], above[j] = above[j], above[i]
+	}
+	for i, e := range above {
+		e.Rank = me.Rank - len(above) + i
+	}
+
+	if err = co.Find(after).Sort("-win_amount", "uid").Limit(n).All(&below); err != nil {
+		return
+	}
+	for i, e := range below {
+		e.Rank = me.Rank + i + 1
+	}
+	return
+}
+
+func (ms *MongoStore) LeaderboardArchive(window, period string, level int) (archive *LeaderboardArchive, err error) {
+	span := ms.span("LeaderboardArchive")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	archive = &LeaderboardArchive{}
+	if err = session.DB(ms.db).C(LeaderboardArchiveCollection).Find(bson.M{"window": window, "period": period, "level": level}).One(archive); err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	return
+}
+
+func (ms *MongoStore) LeaderboardPeriods(window string) (periods []string, err error) {
+	span := ms.span("LeaderboardPeriods")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	err = session.DB(ms.db).C(LeaderboardCollection).Find(bson.M{"window": window}).Distinct("period", &periods)
+	return
+}
+
+func (ms *MongoStore) ArchiveLeaderboard(window, period string, now time.Time) (err error) {
+	span := ms.span("ArchiveLeaderboard")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	db := session.DB(ms.db)
+	co := db.C(LeaderboardCollection)
+
+	var (
+		levels []int
+	)
+
+	if err = co.Find(bson.M{"window": window, "period": period}).Distinct("level", &levels); err != nil {
+		return
+	}
+
+	for _, level := range levels {
+		query := co.Find(bson.M{"window": window, "period": period, "level": level})
+
+		archive := &LeaderboardArchive{}
+		archive.Window = window
+		archive.Period = period
+		archive.Level = level
+		archive.ArchivedAt = now.Unix()
+
+		if archive.Total, err = query.Count(); err != nil {
+			return
+		}
+		if err = query.Sort("-win_amount", "uid").Limit(LeaderboardArchiveLimit).All(&archive.Entries); err != nil {
+			return
+		}
+		for i, e := range archive.Entries {
+			e.Rank = i + 1
+		}
+
+		if _, err = db.C(LeaderboardArchiveCollection).Upsert(bson.M{"window": window, "period": period, "level": level}, archive); err != nil {
+			return
+		}
+	}
+
+	_, err = co.RemoveAll(bson.M{"window": window, "period": period})
+	return
+}
+
+// 先删除再插入，中途失败时榜单不完整，重新执行即可
+func (ms *MongoStore) ReplaceLeaderboard(window, period string, entries []*LeaderboardEntry) (err error) {
+	span := ms.span("ReplaceLeaderboard")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	co := session.DB(ms.db).C(LeaderboardCollection)
+	if _, err = co.RemoveAll(bson.M{"window": window, "period": period}); err != nil || len(entries) == 0 {
+		return
+	}
+
+	bulk := co.Bulk()
+	bulk.Unordered()
+	for _, e := range entries {
+		_e := *e
+		_e.Window = window
+		_e.Period = period
+		_e.Rank = 0
+		bulk.Insert(&_e)
+	}
+	_, err = bulk.Run()
+	return
+}
+
+// 所有计数都通过$inc/$min/$max原子更新，不需要先读后写
+func (ms *MongoStore) ApplyRound(rl *RoundLog) (err error) {
+	span := ms.span("ApplyRound")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	co := session.DB(ms.db).C(PlayerStatsCollection)
+
+	inc := bson.M{
+		"rounds":                               1,
+		"level_net." + strconv.Itoa(rl.Level):  rl.WinAmount,
+		"moves." + getOperateField(rl.Operate): 1,
+	}
+	set := bson.M{}
+
+	switch rl.Result {
+	case Won:
+		inc["wins"] = 1
+		inc["current_win_streak"] = 1
+		set["current_loss_streak"] = 0
+	case Lost:
+		inc["losses"] = 1
+		inc["current_loss_streak"] = 1
+		set["current_win_streak"] = 0
+	default:
+		inc["draws"] = 1
+	}
+
+	update := bson.M{
+		"$inc":         inc,
+		"$min":         bson.M{"first_played": rl.Ts},
+		"$max":         bson.M{"last_played": rl.Ts},
+		"$setOnInsert": bson.M{"uid": rl.Uid},
+	}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+
+	ps := &PlayerStats{}
+	change := mgo.Change{Update: update, Upsert: true, ReturnNew: true}
+	if _, err = co.Find(bson.M{"uid": rl.Uid}).Apply(change, ps); err != nil {
+		return
+	}
+
+	if rl.Result == Draw {
+		return
+	}
+
+	return co.Update(bson.M{"uid": rl.Uid}, bson.M{"$max": bson.M{
+		"longest_win_streak":  ps.CurrentWinStreak,
+		"longest_loss_streak": ps.CurrentLossStreak,
+	}})
+}
+
+func (ms *MongoStore) PlayerStats(uid int) (ps *PlayerStats, err error) {
+	span := ms.span("PlayerStats")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	ps = &PlayerStats{}
+	if err = session.DB(ms.db).C(PlayerStatsCollection).Find(bson.M{"uid": uid}).One(ps); err == mgo.ErrNotFound {
+		ps.Uid = uid
+		err = nil
+	}
+	return
+}
+
+func (ms *MongoStore) BonusPool() (pool float64, err error) {
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	riskConfig := &RiskConfig{}
+	if err = session.DB(ms.db).C(Collection).Find(nil).One(riskConfig); err != nil {
+		return
+	}
+	return riskConfig.BonusPool, nil
+}
+
+func (ms *MongoStore) AddBonusPool(delta float64) (pool float64, err error) {
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	riskConfig := &RiskConfig{}
+	change := mgo.Change{Update: bson.M{"$inc": bson.M{"bonus_pool": delta}}, ReturnNew: true}
+	if _, err = session.DB(ms.db).C(Collection).Find(nil).Apply(change, riskConfig); err != nil {
+		return
+	}
+	return riskConfig.BonusPool, nil
+}
+
+func (ms *MongoStore) AppendRounds(rounds []*RoundLog) (err error) {
+	span := ms.span("AppendRounds")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	docs := make([]interface{}, len(rounds))
+	for i, rl := range rounds {
+		docs[i] = rl
+	}
+	return session.DB(ms.db).C(MatchHistoryCollection).Insert(docs...)
+}
+
+func (ms *MongoStore) Rounds(uid, offset, limit int) (rounds []*RoundLog, err error) {
+	span := ms.span("Rounds")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	rounds = []*RoundLog{}
+	err = session.DB(ms.db).C(MatchHistoryCollection).Find(bson.M{"uid": uid}).Sort("-ts").Skip(offset).Limit(limit).All(&rounds)
+	return
+}
+
+func (ms *MongoStore) AppendLedger(entries []*LedgerEntry) (err error) {
+	span := ms.span("AppendLedger")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	docs := make([]interface{}, len(entries))
+	for i, le := range entries {
+		docs[i] = le
+	}
+	return session.DB(ms.db).C(LedgerCollection).Insert(docs...)
+}
+
+func (ms *MongoStore) Ledger(uid, offset, limit int) (entries []*LedgerEntry, err error) {
+	span := ms.span("Ledger")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	entries = []*LedgerEntry{}
+	err = session.DB(ms.db).C(LedgerCollection).Find(bson.M{"uid": uid}).Sort("-ts").Skip(offset).Limit(limit).All(&entries)
+	return
+}
+
+// 按_id即写入顺序返回
+func (ms *MongoStore) LedgerUntil(until int64, offset, limit int) (entries []*LedgerEntry, err error) {
+	span := ms.span("LedgerUntil")
+	defer func() { finishSpan(span, err) }()
+
+	session, err := ms.session()
+	if err != nil {
+		return
+	}
+	defer session.Close()
+
+	entries = []*LedgerEntry{}
+	err = session.DB(ms.db).C(LedgerCollection).Find(bson.M{"ts": bson.M{"$lt": until}}).Sort("_id").Skip(offset).Limit(limit).All(&entries)
+	return
+}
@@ -0,0 +1,807 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	sqliteSchema = []string{
+		`CREATE TABLE IF NOT EXISTS applied_batches (
+			id VARCHAR(64) PRIMARY KEY,
+			ts BIGINT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS ranking (
+			uid BIGINT PRIMARY KEY,
+			win_amount DOUBLE NOT NULL DEFAULT 0,
+			avatar VARCHAR(512) NOT NULL DEFAULT '',
+			nickname VARCHAR(128) NOT NULL DEFAULT '',
+			time_updated BIGINT NOT NULL DEFAULT 0
+		)`,
+		`CREATE TABLE IF NOT EXISTS leaderboard (
+			board_window VARCHAR(16) NOT NULL,
+			period VARCHAR(16) NOT NULL,
+			level INT NOT NULL,
+			uid BIGINT NOT NULL,
+			win_amount DOUBLE NOT NULL DEFAULT 0,
+			avatar VARCHAR(512) NOT NULL DEFAULT '',
+			nickname VARCHAR(128) NOT NULL DEFAULT '',
+			time_updated BIGINT NOT NULL DEFAULT 0,
+			PRIMARY KEY (board_window, period, level, uid)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_leaderboard_rank ON leaderboard (board_window, period, level, win_amount, uid)`,
+		`CREATE TABLE IF NOT EXISTS leaderboard_archive (
+			board_window VARCHAR(16) NOT NULL,
+			period VARCHAR(16) NOT NULL,
+			level INT NOT NULL,
+			total INT NOT NULL,
+			entries TEXT NOT NULL,
+			archived_at BIGINT NOT NULL,
+			PRIMARY KEY (board_window, period, level)
+		)`,
+		`CREATE TABLE IF NOT EXISTS player_stats (
+			uid BIGINT PRIMARY KEY,
+			rounds INT NOT NULL DEFAULT 0,
+			wins INT NOT NULL DEFAULT 0,
+			losses INT NOT NULL DEFAULT 0,
+			draws INT NOT NULL DEFAULT 0,
+			stone INT NOT NULL DEFAULT 0,
+			paper INT NOT NULL DEFAULT 0,
+			scissors INT NOT NULL DEFAULT 0,
+			current_win_streak INT NOT NULL DEFAULT 0,
+			current_loss_streak INT NOT NULL DEFAULT 0,
+			longest_win_streak INT NOT NULL DEFAULT 0,
+			longest_loss_streak INT NOT NULL DEFAULT 0,
+			first_played BIGINT NOT NULL DEFAULT 0,
+			last_played BIGINT NOT NULL DEFAULT 0
+		)`,
+		`CREATE TABLE IF NOT EXISTS player_level_net (
+			uid BIGINT NOT NULL,
+			level INT NOT NULL,
+			net DOUBLE NOT NULL DEFAULT 0,
+			PRIMARY KEY (uid, level)
+		)`,
+		`CREATE TABLE IF NOT EXISTS risk_control (
+			id INT PRIMARY KEY,
+			bonus_pool DOUBLE NOT NULL DEFAULT 0
+		)`,
+		`CREATE TABLE IF NOT EXISTS match_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			uid BIGINT NOT NULL,
+			level INT NOT NULL,
+			match_id VARCHAR(64) NOT NULL,
+			round INT NOT NULL,
+			operate INT NOT NULL,
+			result INT NOT NULL,
+			win_amount DOUBLE NOT NULL,
+			ts BIGINT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_match_history_uid ON match_history (uid, ts)`,
+		`CREATE TABLE IF NOT EXISTS ledger (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id VARCHAR(64) NOT NULL,
+			round INT NOT NULL,
+			level INT NOT NULL,
+			uid BIGINT NOT NULL,
+			counterparty_uid BIGINT NOT NULL,
+			amount DOUBLE NOT NULL,
+			cost DOUBLE NOT NULL,
+			balance DOUBLE NOT NULL,
+			status VARCHAR(16) NOT NULL,
+			ts BIGINT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_ledger_uid ON ledger (uid, ts)`,
+		`INSERT OR IGNORE INTO risk_control (id, bonus_pool) VALUES (1, 0)`,
+	}
+
+	mysqlSchema = []string{
+		`CREATE TABLE IF NOT EXISTS applied_batches (
+			id VARCHAR(64) PRIMARY KEY,
+			ts BIGINT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS ranking (
+			uid BIGINT PRIMARY KEY,
+			win_amount DOUBLE NOT NULL DEFAULT 0,
+			avatar VARCHAR(512) NOT NULL DEFAULT '',
+			nickname VARCHAR(128) NOT NULL DEFAULT '',
+			time_updated BIGINT NOT NULL DEFAULT 0
+		) DEFAULT CHARSET=utf8mb4`,
+		`CREATE TABLE IF NOT EXISTS leaderboard (
+			board_window VARCHAR(16) NOT NULL,
+			period VARCHAR(16) NOT NULL,
+			level INT NOT NULL,
+			uid BIGINT NOT NULL,
+			win_amount DOUBLE NOT NULL DEFAULT 0,
+			avatar VARCHAR(512) NOT NULL DEFAULT '',
+			nickname VARCHAR(128) NOT NULL DEFAULT '',
+			time_updated BIGINT NOT NULL DEFAULT 0,
+			PRIMARY KEY (board_window, period, level, uid),
+			INDEX idx_leaderboard_rank (board_window, period, level, win_amount, uid)
+		) DEFAULT CHARSET=utf8mb4`,
+		`CREATE TABLE IF NOT EXISTS leaderboard_archive (
+			board_window VARCHAR(16) NOT NULL,
+			period VARCHAR(16) NOT NULL,
+			level INT NOT NULL,
+			total INT NOT NULL,
+			entries MEDIUMTEXT NOT NULL,
+			archived_at BIGINT NOT NULL,
+			PRIMARY KEY (board_window, period, level)
+		) DEFAULT CHARSET=utf8mb4`,
+		`CREATE TABLE IF NOT EXISTS player_stats (
+			uid BIGINT PRIMARY KEY,
+			rounds INT NOT NULL DEFAULT 0,
+			wins INT NOT NULL DEFAULT 0,
+			losses INT NOT NULL DEFAULT 0,
+			draws INT NOT NULL DEFAULT 0,
+			stone INT NOT NULL DEFAULT 0,
+			paper INT NOT NULL DEFAULT 0,
+			scissors INT NOT NULL DEFAULT 0,
+			current_win_streak INT NOT NULL DEFAULT 0,
+			current_loss_streak INT NOT NULL DEFAULT 0,
+			longest_win_streak INT NOT NULL DEFAULT 0,
+			longest_loss_streak INT NOT NULL DEFAULT 0,
+			first_played BIGINT NOT NULL DEFAULT 0,
+			last_played BIGINT NOT NULL DEFAULT 0
+		)`,
+		`CREATE TABLE IF NOT EXISTS player_level_net (
+			uid BIGINT NOT NULL,
+			level INT NOT NULL,
+			net DOUBLE NOT NULL DEFAULT 0,
+			PRIMARY KEY (uid, level)
+		)`,
+		`CREATE TABLE IF NOT EXISTS risk_control (
+			id INT PRIMARY KEY,
+			bonus_pool DOUBLE NOT NULL DEFAULT 0
+		)`,
+		`CREATE TABLE IF NOT EXISTS match_history (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			uid BIGINT NOT NULL,
+			level INT NOT NULL,
+			match_id VARCHAR(64) NOT NULL,
+			round INT NOT NULL,
+			operate INT NOT NULL,
+			result INT NOT NULL,
+			win_amount DOUBLE NOT NULL,
+			ts BIGINT NOT NULL,
+			INDEX idx_match_history_uid (uid, ts)
+		)`,
+		`CREATE TABLE IF NOT EXISTS ledger (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			match_id VARCHAR(64) NOT NULL,
+			round INT NOT NULL,
+			level INT NOT NULL,
+			uid BIGINT NOT NULL,
+			counterparty_uid BIGINT NOT NULL,
+			amount DOUBLE NOT NULL,
+			cost DOUBLE NOT NULL,
+			balance DOUBLE NOT NULL,
+			status VARCHAR(16) NOT NULL,
+			ts BIGINT NOT NULL,
+			INDEX idx_ledger_uid (uid, ts)
+		)`,
+		`INSERT IGNORE INTO risk_control (id, bonus_pool) VALUES (1, 0)`,
+	}
+)
+
+// SqlStore支持sqlite和mysql，两者的差异只在建表语句和upsert语法上
+type SqlStore struct {
+	ctx    *Context
+	driver string
+}
+
+func NewSqlStore(ctx *Context, driver string) (ss *SqlStore, err error) {
+	ss = &SqlStore{}
+	ss.ctx = ctx
+	ss.driver = driver
+
+	var (
+		db *sql.DB
+	)
+
+	if db, err = ss.db(); err != nil {
+		return nil, err
+	}
+
+	schema := sqliteSchema
+	if driver == StoreDriverMysql {
+		schema = mysqlSchema
+	}
+
+	for _, stmt := range schema {
+		if _, err = db.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("%s: %s", err, stmt)
+		}
+	}
+
+	return
+}
+
+func (ss *SqlStore) db() (db *sql.DB, err error) {
+	return ss.ctx.GetMysqlSession()
+}
+
+func (ss *SqlStore) Ping() (err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+	return db.Ping()
+}
+
+func (ss *SqlStore) Close() {
+	if db, err := ss.db(); err == nil {
+		db.Close()
+	}
+}
+
+// 返回upsert语句，inc列累加，max列取较大值，其余列覆盖
+func (ss *SqlStore) upsert(table string, keys, inc, max, set []string) string {
+	cols := append(append(append(append([]string{}, keys...), inc...), max...), set...)
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ")
+
+	updates := []string{}
+	if ss.driver == StoreDriverMysql {
+		for _, c := range inc {
+			updates = append(updates, fmt.Sprintf("%s = %s + VALUES(%s)", c, c, c))
+		}
+		for _, c := range max {
+			updates = append(updates, fmt.Sprintf("%s = GREATEST(%s, VALUES(%s))", c, c, c))
+		}
+		for _, c := range set {
+			updates = append(updates, fmt.Sprintf("%s = VALUES(%s)", c, c))
+		}
+		return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE %s",
+			table, strings.Join(cols, ", "), placeholders, strings.Join(updates, ", "))
+	}
+
+	for _, c := range inc {
+		updates = append(updates, fmt.Sprintf("%s = %s + excluded.%s", c, c, c))
+	}
+	for _, c := range max {
+		updates = append(updates, fmt.Sprintf("%s = MAX(%s, excluded.%s)", c, c, c))
+	}
+	for _, c := range set {
+		updates = append(updates, fmt.Sprintf("%s = excluded.%s", c, c))
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s",
+		table, strings.Join(cols, ", "), placeholders, strings.Join(keys, ", "), strings.Join(updates, ", "))
+}
+
+func (ss *SqlStore) forUpdate() string {
+	if ss.driver == StoreDriverMysql {
+		return " FOR UPDATE"
+	}
+	return ""
+}
+
+// 批次id和数据在同一个事务中写入，批次id已存在说明已经应用过
+func (ss *SqlStore) ApplyResults(batch *ResultBatch, now time.Time) (err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var (
+		n int
+	)
+
+	if err = tx.QueryRow("SELECT COUNT(*) FROM applied_batches WHERE id = ?", batch.Id).Scan(&n); err != nil {
+		return
+	}
+	if n > 0 {
+		return tx.Rollback()
+	}
+
+	if _, err = tx.Exec("INSERT INTO applied_batches (id, ts) VALUES (?, ?)", batch.Id, now.Unix()); err != nil {
+		return
+	}
+
+	rankingStmt := ss.upsert("ranking", []string{"uid"}, []string{"win_amount"}, []string{"time_updated"}, []string{"avatar", "nickname"})
+	for _, t := range batch.rankingTotals() {
+		if _, err = tx.Exec(rankingStmt, t.Uid, t.WinAmount, t.TimeUpdated, t.Avatar, t.Nickname); err != nil {
+			return
+		}
+	}
+
+	leaderboardStmt := ss.upsert("leaderboard", []string{"board_window", "period", "level", "uid"}, []string{"win_amount"}, []string{"time_updated"}, []string{"avatar", "nickname"})
+	for _, t := range batch.leaderboardTotals(now) {
+		if _, err = tx.Exec(leaderboardStmt, t.Key.Window, t.Key.Period, t.Key.Level, t.Key.Uid, t.WinAmount, t.TimeUpdated, t.Avatar, t.Nickname); err != nil {
+			return
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (ss *SqlStore) Ranking(limit int) (results []*ResultLog, err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	rows, err := db.Query("SELECT uid, win_amount, avatar, nickname, time_updated FROM ranking WHERE win_amount > 0 ORDER BY win_amount DESC LIMIT ?", limit)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	results = []*ResultLog{}
+	for rows.Next() {
+		r := &ResultLog{}
+		if err = rows.Scan(&r.Uid, &r.WinAmount, &r.Avatar, &r.Nickname, &r.TimeUpdated); err != nil {
+			return
+		}
+		results = append(results, r)
+	}
+	err = rows.Err()
+	return
+}
+
+func (ss *SqlStore) queryLeaderboard(db *sql.DB, query string, args ...interface{}) (entries []*LeaderboardEntry, err error) {
+	rows, err := db.Query("SELECT board_window, period, level, uid, win_amount, avatar, nickname, time_updated FROM leaderboard WHERE "+query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	entries = []*LeaderboardEntry{}
+	for rows.Next() {
+		e := &LeaderboardEntry{}
+		if err = rows.Scan(&e.Window, &e.Period, &e.Level, &e.Uid, &e.WinAmount, &e.Avatar, &e.Nickname, &e.TimeUpdated); err != nil {
+			return
+		}
+		entries = append(entries, e)
+	}
+	err = rows.Err()
+	return
+}
+
+func (ss *SqlStore) LeaderboardTop(window, period string, level, offset, limit int) (entries []*LeaderboardEntry, total int, err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	if err = db.QueryRow("SELECT COUNT(*) FROM leaderboard WHERE board_window = ? AND period = ? AND level = ?", window, period, level).Scan(&total); err != nil {
+		return
+	}
+
+	if entries, err = ss.queryLeaderboard(db, "board_window = ? AND period = ? AND level = ? ORDER BY win_amount DESC, uid ASC LIMIT ? OFFSET ?", window, period, level, limit, offset); err != nil {
+		return
+	}
+	for i, e := range entries {
+		e.Rank = offset + i + 1
+	}
+	return
+}
+
+func (ss *SqlStore) LeaderboardRank(window, period string, level, uid, n int) (me *LeaderboardEntry, above, below []*LeaderboardEntry, err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	var (
+		entries []*LeaderboardEntry
+		count   int
+	)
+
+	if entries, err = ss.queryLeaderboard(db, "board_window = ? AND period = ? AND level = ? AND uid = ?", window, period, level, uid); err != nil || len(entries) == 0 {
+		return
+	}
+	me = entries[0]
+
+	// 排序规则: win_amount降序，相同时uid升序
+	board := "board_window = ? AND period = ? AND level = ? AND "
+	if err = db.QueryRow("SELECT COUNT(*) FROM leaderboard WHERE "+board+"(win_amount > ? OR (win_amount = ? AND uid < ?))",
+		window, period, level, me.WinAmount, me.WinAmount, uid).Scan(&count); err != nil {
+		return
+	}
+	me.Rank = count + 1
+
+	if n <= 0 {
+		return
+	}
+
+	if above, err = ss.queryLeaderboard(db, board+"(win_amount > ? OR (win_amount = ? AND uid < ?)) ORDER BY win_amount ASC, uid DESC LIMIT ?",
+		window, period, level, me.WinAmount, me.WinAmount, uid, n); err != nil {
+		return
+	}
+	for i, j := 0, len(above)-1; i < j; i, j = i+1, j-1 {
+		above[i], above[j] = above[j], above[i]
+	}
+	for i, e := range above {
+		e.Rank = me.Rank - len(above) + i
+	}
+
+	if below, err = ss.queryLeaderboard(db, board+"(win_amount < ? OR (win_amount = ? AND uid > ?)) ORDER BY win_amount DESC, uid ASC LIMIT ?",
+		window, period, level, me.WinAmount, me.WinAmount, uid, n); err != nil {
+		return
+	}
+	for i, e := range below {
+		e.Rank = me.Rank + i + 1
+	}
+	return
+}
+
+func (ss *SqlStore) LeaderboardArchive(window, period string, level int) (archive *LeaderboardArchive, err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	var (
+		entries string
+	)
+
+	archive = &LeaderboardArchive{Window: window, Period: period, Level: level}
+	if err = db.QueryRow("SELECT total, entries, archived_at FROM leaderboard_archive WHERE board_window = ? AND period = ? AND level = ?", window, period, level).
+		Scan(&archive.Total, &entries, &archive.ArchivedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return
+	}
+
+	err = json.Unmarshal([]byte(entries), &archive.Entries)
+	return
+}
+
+func (ss *SqlStore) LeaderboardPeriods(window string) (periods []string, err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	rows, err := db.Query("SELECT DISTINCT period FROM leaderboard WHERE board_window = ?", window)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var period string
+		if err = rows.Scan(&period); err != nil {
+			return
+		}
+		periods = append(periods, period)
+	}
+	err = rows.Err()
+	return
+}
+
+func (ss *SqlStore) ArchiveLeaderboard(window, period string, now time.Time) (err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	var (
+		levels []int
+	)
+
+	rows, err := db.Query("SELECT DISTINCT level FROM leaderboard WHERE board_window = ? AND period = ?", window, period)
+	if err != nil {
+		return
+	}
+	for rows.Next() {
+		var level int
+		if err = rows.Scan(&level); err != nil {
+			rows.Close()
+			return
+		}
+		levels = append(levels, level)
+	}
+	rows.Close()
+
+	archiveStmt := ss.upsert("leaderboard_archive", []string{"board_window", "period", "level"}, nil, nil, []string{"total", "entries", "archived_at"})
+
+	for _, level := range levels {
+		var (
+			entries []*LeaderboardEntry
+			total   int
+			b       []byte
+		)
+
+		if entries, total, err = ss.LeaderboardTop(window, period, level, 0, LeaderboardArchiveLimit); err != nil {
+			return
+		}
+
+		if b, err = json.Marshal(entries); err != nil {
+			return
+		}
+
+		if _, err = db.Exec(archiveStmt, window, period, level, total, string(b), now.Unix()); err != nil {
+			return
+		}
+	}
+
+	_, err = db.Exec("DELETE FROM leaderboard WHERE board_window = ? AND period = ?", window, period)
+	return
+}
+
+func (ss *SqlStore) ReplaceLeaderboard(window, period string, entries []*LeaderboardEntry) (err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec("DELETE FROM leaderboard WHERE board_window = ? AND period = ?", window, period); err != nil {
+		return
+	}
+
+	for _, e := range entries {
+		if _, err = tx.Exec("INSERT INTO leaderboard (board_window, period, level, uid, win_amount, avatar, nickname, time_updated) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			window, period, e.Level, e.Uid, e.WinAmount, e.Avatar, e.Nickname, e.TimeUpdated); err != nil {
+			return
+		}
+	}
+
+	return tx.Commit()
+}
+
+// 在事务中先读后写，mysql使用行锁，sqlite只有一个写连接
+func (ss *SqlStore) ApplyRound(rl *RoundLog) (err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec(ss.upsert("player_stats", []string{"uid"}, []string{"rounds"}, nil, nil), rl.Uid, 0); err != nil {
+		return
+	}
+
+	ps := &PlayerStats{Uid: rl.Uid}
+	if err = tx.QueryRow("SELECT rounds, wins, losses, draws, stone, paper, scissors, current_win_streak, current_loss_streak, longest_win_streak, longest_loss_streak, first_played, last_played FROM player_stats WHERE uid = ?"+ss.forUpdate(), rl.Uid).
+		Scan(&ps.Rounds, &ps.Wins, &ps.Losses, &ps.Draws, &ps.Moves.Stone, &ps.Moves.Paper, &ps.Moves.Scissors,
+			&ps.CurrentWinStreak, &ps.CurrentLossStreak, &ps.LongestWinStreak, &ps.LongestLossStreak, &ps.FirstPlayed, &ps.LastPlayed); err != nil {
+		return
+	}
+
+	ps.apply(rl)
+
+	if _, err = tx.Exec("UPDATE player_stats SET rounds = ?, wins = ?, losses = ?, draws = ?, stone = ?, paper = ?, scissors = ?, current_win_streak = ?, current_loss_streak = ?, longest_win_streak = ?, longest_loss_streak = ?, first_played = ?, last_played = ? WHERE uid = ?",
+		ps.Rounds, ps.Wins, ps.Losses, ps.Draws, ps.Moves.Stone, ps.Moves.Paper, ps.Moves.Scissors,
+		ps.CurrentWinStreak, ps.CurrentLossStreak, ps.LongestWinStreak, ps.LongestLossStreak, ps.FirstPlayed, ps.LastPlayed, rl.Uid); err != nil {
+		return
+	}
+
+	if _, err = tx.Exec(ss.upsert("player_level_net", []string{"uid", "level"}, []string{"net"}, nil, nil), rl.Uid, rl.Level, rl.WinAmount); err != nil {
+		return
+	}
+
+	return tx.Commit()
+}
+
+func (ss *SqlStore) PlayerStats(uid int) (ps *PlayerStats, err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	ps = &PlayerStats{Uid: uid}
+	if err = db.QueryRow("SELECT rounds, wins, losses, draws, stone, paper, scissors, current_win_streak, current_loss_streak, longest_win_streak, longest_loss_streak, first_played, last_played FROM player_stats WHERE uid = ?", uid).
+		Scan(&ps.Rounds, &ps.Wins, &ps.Losses, &ps.Draws, &ps.Moves.Stone, &ps.Moves.Paper, &ps.Moves.Scissors,
+			&ps.CurrentWinStreak, &ps.CurrentLossStreak, &ps.LongestWinStreak, &ps.LongestLossStreak, &ps.FirstPlayed, &ps.LastPlayed); err != nil {
+		if err == sql.ErrNoRows {
+			err = nil
+		}
+		return
+	}
+
+	rows, err := db.Query("SELECT level, net FROM player_level_net WHERE uid = ?", uid)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	ps.LevelNet = make(map[string]float64)
+	for rows.Next() {
+		var (
+			level int
+			net   float64
+		)
+		if err = rows.Scan(&level, &net); err != nil {
+			return
+		}
+		ps.LevelNet[strconv.Itoa(level)] = net
+	}
+	err = rows.Err()
+	return
+}
+
+func (ss *SqlStore) BonusPool() (pool float64, err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	err = db.QueryRow("SELECT bonus_pool FROM risk_control WHERE id = 1").Scan(&pool)
+	return
+}
+
+func (ss *SqlStore) AddBonusPool(delta float64) (pool float64, err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.Exec("UPDATE risk_control SET bonus_pool = bonus_pool + ? WHERE id = 1", delta); err != nil {
+		return
+	}
+
+	if err = tx.QueryRow("SELECT bonus_pool FROM risk_control WHERE id = 1").Scan(&pool); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+func (ss *SqlStore) AppendRounds(rounds []*RoundLog) (err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for _, rl := range rounds {
+		if _, err = tx.Exec("INSERT INTO match_history (uid, level, match_id, round, operate, result, win_amount, ts) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			rl.Uid, rl.Level, rl.MatchId, rl.Round, rl.Operate, rl.Result, rl.WinAmount, rl.Ts); err != nil {
+			return
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (ss *SqlStore) Rounds(uid, offset, limit int) (rounds []*RoundLog, err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	rows, err := db.Query("SELECT uid, level, match_id, round, operate, result, win_amount, ts FROM match_history WHERE uid = ? ORDER BY ts DESC, id DESC LIMIT ? OFFSET ?", uid, limit, offset)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	rounds = []*RoundLog{}
+	for rows.Next() {
+		rl := &RoundLog{}
+		if err = rows.Scan(&rl.Uid, &rl.Level, &rl.MatchId, &rl.Round, &rl.Operate, &rl.Result, &rl.WinAmount, &rl.Ts); err != nil {
+			return
+		}
+		rounds = append(rounds, rl)
+	}
+	err = rows.Err()
+	return
+}
+
+func (ss *SqlStore) AppendLedger(entries []*LedgerEntry) (err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for _, le := range entries {
+		if _, err = tx.Exec("INSERT INTO ledger (match_id, round, level, uid, counterparty_uid, amount, cost, balance, status, ts) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			le.MatchId, le.Round, le.Level, le.Uid, le.CounterpartyUid, le.Amount, le.Cost, le.Balance, le.Status, le.Ts); err != nil {
+			return
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (ss *SqlStore) Ledger(uid, offset, limit int) (entries []*LedgerEntry, err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	rows, err := db.Query("SELECT match_id, round, level, uid, counterparty_uid, amount, cost, balance, status, ts FROM ledger WHERE uid = ? ORDER BY ts DESC, id DESC LIMIT ? OFFSET ?", uid, limit, offset)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	entries = []*LedgerEntry{}
+	for rows.Next() {
+		le := &LedgerEntry{}
+		if err = rows.Scan(&le.MatchId, &le.Round, &le.Level, &le.Uid, &le.CounterpartyUid, &le.Amount, &le.Cost, &le.Balance, &le.Status, &le.Ts); err != nil {
+			return
+		}
+		entries = append(entries, le)
+	}
+	err = rows.Err()
+	return
+}
+
+func (ss *SqlStore) LedgerUntil(until int64, offset, limit int) (entries []*LedgerEntry, err error) {
+	db, err := ss.db()
+	if err != nil {
+		return
+	}
+
+	rows, err := db.Query("SELECT match_id, round, level, uid, counterparty_uid, amount, cost, balance, status, ts FROM ledger WHERE ts < ? ORDER BY id LIMIT ? OFFSET ?", until, limit, offset)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	entries = []*LedgerEntry{}
+	for rows.Next() {
+		le := &LedgerEntry{}
+		if err = rows.Scan(&le.MatchId, &le.Round, &le.Level, &le.Uid, &le.CounterpartyUid, &le.Amount, &le.Cost, &le.Balance, &le.Status, &le.Ts); err != nil {
+			return
+		}
+		entries = append(entries, le)
+	}
+	err = rows.Err()
+	return
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	log "code.google.com/p/log4go"
+)
+
+const (
+	TracerName = "fingerplay"
+
+	// 默认不导出，span由otel的no-op实现丢弃
+	TraceExporterNone = "none"
+	TraceExporterOTLP = "otlp"
+
+	UserValueTraceContext = "trace_context"
+)
+
+var (
+	tracerProvider *sdktrace.TracerProvider
+)
+
+func init() {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// 根据配置初始化tracing，none时保持no-op
+func InitTracing(cfg *Config) (err error) {
+	switch cfg.TraceExporter {
+	case "", TraceExporterNone:
+		return
+	case TraceExporterOTLP:
+		var (
+			exporter sdktrace.SpanExporter
+		)
+
+		opts := []otlptracehttp.Option{}
+		// 不配置时使用OTEL_EXPORTER_OTLP_ENDPOINT或默认的localhost:4318
+		if cfg.TraceEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.TraceEndpoint))
+		}
+
+		if exporter, err = otlptracehttp.New(context.Background(), opts...); err != nil {
+			return
+		}
+		SetTraceExporter(sdktrace.WithBatcher(exporter), cfg.TraceSampleRatio)
+		log.Info("Tracing: otlp export to %s, sample ratio %.3f", cfg.TraceEndpoint, cfg.TraceSampleRatio)
+		return
+	default:
+		return ErrTraceExporter
+	}
+}
+
+// 安装导出器，进程内的导出器(例如tracetest.InMemoryExporter)可以用sdktrace.WithSyncer
+// 同步导出，便于在测试中检查span
+func SetTraceExporter(exporter sdktrace.TracerProviderOption, ratio float64) {
+	res, _ := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", GetConf().ServerName),
+		attribute.String("service.instance.id", getNodeId(GetConf())),
+	))
+
+	tracerProvider = sdktrace.NewTracerProvider(
+		exporter,
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+}
+
+// 导出剩余的span
+func ShutdownTracing(deadline time.Time) {
+	if tracerProvider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		log.Error("Tracing shutdown failed: %s", err)
+	}
+}
+
+func startSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// 结束span，返回码不是OK或有错误时标记为失败
+func endSpan(span trace.Span, code int, err error) {
+	span.SetAttributes(attribute.Int("fingerplay.code", code))
+	if err == nil && code != ResponseCodeOK {
+		span.SetStatus(codes.Error, getCodeDescription(code))
+	}
+	finishSpan(span, err)
+}
+
+// 用于没有返回码的调用，例如存储
+func finishSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// 请求的关联id和trace上下文，不参与序列化，由http层设置并透传给账户服务
+type Traced struct {
+	RequestId string `json:"-"`
+
+	ctx context.Context
+}
+
+func (t *Traced) SetRequestId(id string) { t.RequestId = id }
+
+func (t *Traced) GetRequestId() string { return t.RequestId }
+
+func (t *Traced) SetContext(ctx context.Context) { t.ctx = ctx }
+
+func (t *Traced) Context() context.Context {
+	if t.ctx == nil {
+		return context.Background()
+	}
+	return t.ctx
+}
+
+// 在当前上下文下开始一个子span，之后的调用都挂在这个span下
+func (t *Traced) StartSpan(name string, opts ...trace.SpanStartOption) trace.Span {
+	var span trace.Span
+	t.ctx, span = startSpan(t.Context(), name, opts...)
+	return span
+}
+
+// fasthttp请求头的TextMapCarrier
+type fasthttpCarrier struct {
+	header *fasthttp.RequestHeader
+}
+
+func (c fasthttpCarrier) Get(key string) string {
+	return string(c.header.Peek(key))
+}
+
+func (c fasthttpCarrier) Set(key, value string) {
+	c.header.Set(key, value)
+}
+
+func (c fasthttpCarrier) Keys() (keys []string) {
+	c.header.VisitAll(func(k, v []byte) {
+		keys = append(keys, string(k))
+	})
+	return
+}
+
+// 取出http层开始的span上下文
+func getTraceContext(ctx *fasthttp.RequestCtx) context.Context {
+	if c, ok := ctx.UserValue(UserValueTraceContext).(context.Context); ok {
+		return c
+	}
+	return context.Background()
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"fingerplay/wallettest"
+)
+
+// 按名字查找span，没有时测试失败
+func findSpans(t *testing.T, spans tracetest.SpanStubs, name string) (found []tracetest.SpanStub) {
+	for _, span := range spans {
+		if span.Name == name {
+			found = append(found, span)
+		}
+	}
+	if len(found) == 0 {
+		t.Fatalf("no %s span", name)
+	}
+	return
+}
+
+func childOf(child, parent tracetest.SpanStub) bool {
+	return child.Parent.SpanID() == parent.SpanContext.SpanID()
+}
+
+func TestSpansAroundMatchAndSettlement(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	SetTraceExporter(sdktrace.WithSyncer(exporter), 1)
+	t.Cleanup(func() {
+		ShutdownTracing(time.Now().Add(time.Second))
+		tracerProvider = nil
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+
+	// uid不大于max_robot_uid的是机器人，有机器人的回合由风控判定并读写奖池
+	cfg := newTestConfig(t)
+	useTestWallet(t, cfg, &wallettest.User{Uid: 1500, AccessToken: "robot-1", Nickname: "Robot", Balance: 10000})
+	s, clock := newClockServer(t, cfg, &countingRobots{})
+
+	tokens := []string{"player-1", "robot-1"}
+	responses := []*MatchResponse{{}, {}}
+	done := make(chan bool, 2)
+	for i := range tokens {
+		go func(i int) {
+			s.Logic.Match(&MatchRequest{Level: 100, AccessToken: tokens[i]}, responses[i])
+			done <- true
+		}(i)
+	}
+	wl := s.Logic.getWaitingList(100)
+	waitFor(t, "both players in the waiting list", func() bool { return wl.waiting() == 2 })
+	stepClock(clock, 1, 1)
+	<-done
+	<-done
+	if responses[0].Code != ResponseCodeOK || responses[1].Code != ResponseCodeOK {
+		t.Fatalf("match responses = %d, %d, want a match", responses[0].Code, responses[1].Code)
+	}
+
+	// 平局不经过风控，双方出不同的拳
+	operates := []int{Stone, Paper}
+	for i := range tokens {
+		go func(i int) {
+			s.Logic.Ready(&ReadyRequest{Operate: operates[i], MatchId: responses[i].Data.MatchId, Round: responses[i].Data.Round,
+				AccessToken: tokens[i], SessionTicket: responses[i].Data.SessionTicket}, &ReadyResponse{})
+			done <- true
+		}(i)
+	}
+	<-done
+	<-done
+
+	spans := exporter.GetSpans()
+
+	// 每次匹配都在Match下查询账户
+	for _, match := range findSpans(t, spans, "LogicImpl.Match") {
+		n := 0
+		for _, describe := range findSpans(t, spans, "AccountManager.DescribeUser") {
+			if childOf(describe, match) {
+				n++
+			}
+		}
+		if n != 1 {
+			t.Errorf("Match span %s has %d DescribeUser children, want 1", match.SpanContext.SpanID(), n)
+		}
+	}
+
+	// 后出拳的Ready完成结算：checkReady -> judge -> 奖池存储，转账在同一个trace中
+	checkReady := findSpans(t, spans, "MatchSession.checkReady")[0]
+	inReady := false
+	for _, ready := range findSpans(t, spans, "LogicImpl.Ready") {
+		inReady = inReady || childOf(checkReady, ready)
+	}
+	if !inReady {
+		t.Errorf("checkReady is not under a Ready span")
+	}
+
+	judge := findSpans(t, spans, "MatchSession.judge")[0]
+	if !childOf(judge, checkReady) {
+		t.Errorf("judge is not under checkReady")
+	}
+	pool := findSpans(t, spans, "RiskStore.BonusPool")[0]
+	if !childOf(pool, judge) {
+		t.Errorf("RiskStore.BonusPool is not under judge")
+	}
+	if !hasAttribute(pool.Attributes, attribute.String("db.system", StoreDriverMemory)) {
+		t.Errorf("RiskStore.BonusPool attributes = %v, want db.system=%s", pool.Attributes, StoreDriverMemory)
+	}
+	for _, transfer := range findSpans(t, spans, "AccountManager.Transfer") {
+		if transfer.SpanContext.TraceID() != checkReady.SpanContext.TraceID() {
+			t.Errorf("Transfer is not in the settlement trace")
+		}
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, kv attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == kv {
+			return true
+		}
+	}
+	return false
+}
@@ -7,7 +7,6 @@ package main
 
 import (
 	"crypto/md5"
-	"crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
@@ -25,7 +24,7 @@ func GetMD5String(src string) string {
 
 func GetGUID() string {
 	b := make([]byte, 48)
-	if _, err := io.ReadFull(rand.Reader, b); nil != err {
+	if _, err := io.ReadFull(DefaultIdSource, b); nil != err {
 		return ""
 	}
 	return GetMD5String(base64.URLEncoding.EncodeToString(b))
@@ -0,0 +1,393 @@
+// Package wallettest是账户服务的假实现，提供describe-user和transfer两个接口，
+// 用于本地开发和端到端测试，不需要连接私有的账户服务。
+//
+//	srv := wallettest.NewServer(wallettest.Options{Balance: 1000})
+//	defer srv.Close()
+//	// endpoint_describe_user = srv.URL + wallettest.PathDescribeUser
+//	// endpoint_transfer      = srv.URL + wallettest.PathTransfer
+//
+// 没有见过的令牌会自动注册为新用户。没有注册的uid(例如机器人的uid)视为庄家，
+// 不记录余额，也不会余额不足。
+package wallettest
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	PathDescribeUser = "/describe_user"
+	PathTransfer     = "/transfer"
+	PathUsers        = "/users"
+	PathFaults       = "/faults"
+	PathTransfers    = "/transfers"
+
+	// 自动注册的uid从这里开始，避开机器人的uid
+	DefaultFirstUid = 100000
+	DefaultBalance  = 10000
+	// 只保留最近的转账记录
+	MaxTransfers = 10000
+)
+
+// 与fingerplay的ret.go一致
+const (
+	CodeOK                  = 0
+	CodeBadAccessToken      = -4
+	CodeBadRequestFormat    = -7
+	CodeInternalError       = -12
+	CodeInsufficientBalance = -13
+	CodeBadUid              = -15
+)
+
+var (
+	ErrUid         = errors.New("bad uid")
+	ErrAccessToken = errors.New("bad access token")
+)
+
+type User struct {
+	Uid         int     `json:"uid"`
+	AccessToken string  `json:"access_token"`
+	Nickname    string  `json:"nickname"`
+	FbOpenId    string  `json:"fb_open_id"`
+	Balance     float64 `json:"balance"`
+}
+
+// 故障注入，运行中可以通过PathFaults修改
+type Faults struct {
+	// 每个请求的固定延迟和随机抖动
+	LatencyMs int `json:"latency_ms"`
+	JitterMs  int `json:"jitter_ms"`
+	// 返回CodeInternalError的概率
+	ErrorRate float64 `json:"error_rate"`
+	// 返回http 500且没有body的概率，模拟服务崩溃
+	FailRate float64 `json:"fail_rate"`
+}
+
+type Options struct {
+	// 预置的用户
+	Users []*User
+	// 自动注册用户的初始余额，0时使用DefaultBalance
+	Balance float64
+	// 关闭后没有见过的令牌返回CodeBadAccessToken
+	NoAutoRegister bool
+	Faults         Faults
+	Seed           int64
+}
+
+type Transfer struct {
+	FromUid     int     `json:"from_uid"`
+	ToUid       int     `json:"to_uid"`
+	Amount      float64 `json:"amount"`
+	FromCost    float64 `json:"from_cost"`
+	ToCost      float64 `json:"to_cost"`
+	FromBalance float64 `json:"from_balance"`
+	ToBalance   float64 `json:"to_balance"`
+	Code        int     `json:"code"`
+	Ts          int64   `json:"ts"`
+}
+
+type response struct {
+	Code int         `json:"code"`
+	Msg  string      `json:"msg"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+type Wallet struct {
+	mux       sync.Mutex
+	opts      Options
+	faults    Faults
+	rnd       *rand.Rand
+	nextUid   int
+	users     map[int]*User
+	tokens    map[string]*User
+	transfers []*Transfer
+}
+
+func New(opts Options) *Wallet {
+	w := &Wallet{}
+	w.opts = opts
+	if w.opts.Balance <= 0 {
+		w.opts.Balance = DefaultBalance
+	}
+	w.faults = opts.Faults
+	w.rnd = rand.New(rand.NewSource(opts.Seed))
+	w.nextUid = DefaultFirstUid
+	w.users = make(map[int]*User)
+	w.tokens = make(map[string]*User)
+	for _, u := range opts.Users {
+		w.AddUser(u)
+	}
+	return w
+}
+
+// 启动一个本地的httptest服务
+func NewServer(opts Options) *httptest.Server {
+	return httptest.NewServer(New(opts))
+}
+
+func (w *Wallet) AddUser(u *User) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	_u := *u
+	if _u.Nickname == "" {
+		_u.Nickname = "user" + strconv.Itoa(_u.Uid)
+	}
+	if old := w.users[_u.Uid]; old != nil {
+		delete(w.tokens, old.AccessToken)
+	}
+	w.users[_u.Uid] = &_u
+	w.tokens[_u.AccessToken] = &_u
+	if _u.Uid >= w.nextUid {
+		w.nextUid = _u.Uid + 1
+	}
+}
+
+// 返回用户的副本
+func (w *Wallet) User(uid int) (u User, ok bool) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if _u := w.users[uid]; _u != nil {
+		return *_u, true
+	}
+	return
+}
+
+func (w *Wallet) Users() (users []*User) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	users = make([]*User, 0, len(w.users))
+	for _, u := range w.users {
+		_u := *u
+		users = append(users, &_u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Uid < users[j].Uid })
+	return
+}
+
+// uid为0时返回所有最近的转账
+func (w *Wallet) Transfers(uid int) (transfers []*Transfer) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	transfers = []*Transfer{}
+	for _, t := range w.transfers {
+		if uid == 0 || t.FromUid == uid || t.ToUid == uid {
+			_t := *t
+			transfers = append(transfers, &_t)
+		}
+	}
+	return
+}
+
+func (w *Wallet) SetFaults(f Faults) {
+	w.mux.Lock()
+	w.faults = f
+	w.mux.Unlock()
+}
+
+func (w *Wallet) Faults() Faults {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return w.faults
+}
+
+func (w *Wallet) DescribeUser(accessToken string) (u User, err error) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	_u := w.tokens[accessToken]
+	if _u == nil {
+		if w.opts.NoAutoRegister || accessToken == "" {
+			return u, ErrAccessToken
+		}
+		_u = &User{Uid: w.nextUid, AccessToken: accessToken, Nickname: accessToken, Balance: w.opts.Balance}
+		w.nextUid++
+		w.users[_u.Uid] = _u
+		w.tokens[accessToken] = _u
+	}
+	return *_u, nil
+}
+
+// 付款方扣除金额和手续费，收款方得到金额减去手续费。没有注册的uid视为庄家。
+func (w *Wallet) Transfer(t *Transfer) (code int) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	t.Ts = time.Now().Unix()
+	defer func() {
+		t.Code = code
+		if len(w.transfers) >= MaxTransfers {
+			w.transfers = w.transfers[1:]
+		}
+		w.transfers = append(w.transfers, t)
+	}()
+
+	if t.Amount <= 0 || t.FromUid == t.ToUid {
+		return CodeBadRequestFormat
+	}
+
+	from, to := w.users[t.FromUid], w.users[t.ToUid]
+	if from != nil && from.Balance < t.Amount+t.FromCost {
+		return CodeInsufficientBalance
+	}
+
+	if from != nil {
+		from.Balance -= t.Amount + t.FromCost
+		t.FromBalance = from.Balance
+	}
+	if to != nil {
+		to.Balance += t.Amount - t.ToCost
+		t.ToBalance = to.Balance
+	}
+	return CodeOK
+}
+
+// 按配置延迟，返回是否注入错误和是否直接失败
+func (w *Wallet) inject() (fail, bad bool) {
+	w.mux.Lock()
+	f := w.faults
+	delay := time.Duration(f.LatencyMs) * time.Millisecond
+	if f.JitterMs > 0 {
+		delay += time.Duration(w.rnd.Intn(f.JitterMs)) * time.Millisecond
+	}
+	fail = w.rnd.Float64() < f.FailRate
+	bad = w.rnd.Float64() < f.ErrorRate
+	w.mux.Unlock()
+
+	time.Sleep(delay)
+	return
+}
+
+func (w *Wallet) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	resp := &response{}
+
+	switch r.URL.Path {
+	case PathDescribeUser, PathTransfer:
+		if r.Method != http.MethodPost {
+			http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if fail, bad := w.inject(); fail {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		} else if bad {
+			resp.Code = CodeInternalError
+			resp.Msg = "injected error"
+			break
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			resp.Code = CodeBadRequestFormat
+			resp.Msg = err.Error()
+			break
+		}
+
+		if r.URL.Path == PathDescribeUser {
+			w.serveDescribeUser(body, resp)
+		} else {
+			w.serveTransfer(body, resp)
+		}
+	case PathUsers:
+		w.serveUsers(r, resp)
+	case PathTransfers:
+		uid, _ := strconv.Atoi(r.URL.Query().Get("uid"))
+		resp.Data = w.Transfers(uid)
+	case PathFaults:
+		w.serveFaults(r, resp)
+	default:
+		http.NotFound(rw, r)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	v, _ := json.Marshal(resp)
+	rw.Write(v)
+}
+
+func (w *Wallet) serveDescribeUser(body []byte, resp *response) {
+	request := struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		resp.Code = CodeBadRequestFormat
+		resp.Msg = err.Error()
+		return
+	}
+
+	u, err := w.DescribeUser(request.AccessToken)
+	if err != nil {
+		resp.Code = CodeBadAccessToken
+		resp.Msg = err.Error()
+		return
+	}
+	resp.Data = map[string]interface{}{"uid": u.Uid, "fb_open_id": u.FbOpenId, "nickname": u.Nickname, "balance": u.Balance}
+}
+
+func (w *Wallet) serveTransfer(body []byte, resp *response) {
+	t := &Transfer{}
+	if err := json.Unmarshal(body, t); err != nil {
+		resp.Code = CodeBadRequestFormat
+		resp.Msg = err.Error()
+		return
+	}
+
+	if resp.Code = w.Transfer(t); resp.Code == CodeOK {
+		resp.Data = map[string]float64{"from_balance": t.FromBalance, "to_balance": t.ToBalance}
+	}
+}
+
+// GET查看余额，uid为空时返回所有用户；POST设置余额或预置用户
+func (w *Wallet) serveUsers(r *http.Request, resp *response) {
+	switch r.Method {
+	case http.MethodGet:
+		if s := r.URL.Query().Get("uid"); s != "" {
+			uid, _ := strconv.Atoi(s)
+			u, ok := w.User(uid)
+			if !ok {
+				resp.Code = CodeBadUid
+				resp.Msg = ErrUid.Error()
+				return
+			}
+			resp.Data = u
+			return
+		}
+		resp.Data = w.Users()
+	case http.MethodPost:
+		u := &User{}
+		if err := json.NewDecoder(r.Body).Decode(u); err != nil || u.Uid <= 0 || u.AccessToken == "" {
+			resp.Code = CodeBadRequestFormat
+			resp.Msg = "uid and access_token are required"
+			return
+		}
+		w.AddUser(u)
+		resp.Data = u
+	default:
+		resp.Code = CodeBadRequestFormat
+		resp.Msg = "method not allowed"
+	}
+}
+
+func (w *Wallet) serveFaults(r *http.Request, resp *response) {
+	if r.Method == http.MethodPost {
+		f := w.Faults()
+		if err := json.NewDecoder(r.Body).Decode(&f); err != nil {
+			resp.Code = CodeBadRequestFormat
+			resp.Msg = err.Error()
+			return
+		}
+		w.SetFaults(f)
+	}
+	resp.Data = w.Faults()
+}